@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+// audience scrapes followers of two or more users and writes follower sets of
+// every user, common followers and exclusive followers of each user as JSON
+// to stdout.
+//
+//	go run . audience -limit 5000 nasa spacex
+func audience(ctx context.Context, scraper *twitterscraper.Scraper, args []string) {
+	flags := flag.NewFlagSet("audience", flag.ContinueOnError)
+	limit := flags.Int("limit", 1000, "maximum number of followers of each user")
+	profiles := flags.Bool("profiles", false, "write profiles of scraped followers too")
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	if flags.NArg() < 2 {
+		exit(summary{}, exitConfig, errors.New("expected at least two usernames"))
+	}
+
+	overlap, err := scraper.GetAudienceOverlap(ctx, flags.Args(), *limit)
+	if err != nil {
+		exit(summary{Targets: flags.NArg(), Failed: flags.NArg()}, exitCode(err), fmt.Errorf("error comparing audiences: %w", err))
+	}
+	if !*profiles {
+		overlap.Profiles = nil
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(overlap); err != nil {
+		exit(summary{Targets: flags.NArg()}, exitPartial, fmt.Errorf("error writing output: %w", err))
+	}
+	exit(summary{Targets: flags.NArg()}, exitSuccess, nil)
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+const browsePageSize = 50
+
+var browseTemplate = template.Must(template.New("browse").Funcs(template.FuncMap{
+	"time": func(tweet *twitterscraper.Tweet) string {
+		return time.Unix(tweet.Timestamp, 0).UTC().Format("2006-01-02 15:04")
+	},
+}).Parse(`<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 640px; margin: 0 auto; padding: 1em; color: #0f1419; }
+nav a, .meta a { color: #1d9bf0; text-decoration: none; margin-right: 1em; }
+.tweet { border-bottom: 1px solid #eff3f4; padding: 1em 0; }
+.meta { color: #536471; font-size: 0.9em; }
+.text { white-space: pre-wrap; margin: 0.5em 0; }
+img, video { max-width: 100%; border-radius: 12px; margin-top: 0.5em; }
+</style>
+</head>
+<body>
+<nav><a href="/">All tweets</a>{{range .Users}}<a href="/?user={{.}}">@{{.}}</a>{{end}}</nav>
+<h1>{{.Title}}</h1>
+{{range .Tweets}}
+<div class="tweet">
+  <div class="meta"><b>{{.Name}}</b> @{{.Username}} · {{time .}}</div>
+  <div class="text">{{.Text}}</div>
+  {{range .Photos}}<img src="{{.URL}}" loading="lazy">{{end}}
+  {{range .Videos}}<video src="{{.URL}}" poster="{{.Preview}}" controls preload="none"></video>{{end}}
+  {{range .GIFs}}<video src="{{.URL}}" poster="{{.Preview}}" autoplay loop muted></video>{{end}}
+  <div class="meta">♥ {{.Likes}} · ↻ {{.Retweets}} · 💬 {{.Replies}}
+    <a href="/thread/{{.ConversationID}}">thread</a>
+    <a href="{{.PermanentURL}}">original</a></div>
+</div>
+{{else}}
+<p>No tweets.</p>
+{{end}}
+{{if .Next}}<nav><a href="{{.Next}}">Older</a></nav>{{end}}
+</body>
+</html>
+`))
+
+type browsePage struct {
+	Title  string
+	Users  []string
+	Tweets []*twitterscraper.Tweet
+	Next   string
+}
+
+// archive is a read-only set of tweets loaded from dump files.
+type archive struct {
+	tweets []*twitterscraper.Tweet // newest first
+	users  []string
+}
+
+// browse serves read-only web UI over NDJSON or JSON dumps and SQLite
+// databases written by timeline.
+//
+//	go run . browse -addr localhost:8080 tweets.ndjson tweets.db
+func browse(args []string) {
+	flags := flag.NewFlagSet("browse", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	if flags.NArg() == 0 {
+		exit(summary{}, exitConfig, errors.New("usage: browse [-addr host:port] <file>..."))
+	}
+
+	a, err := loadArchive(flags.Args())
+	if err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", a.handleTimeline)
+	mux.HandleFunc("GET /thread/{id}", a.handleThread)
+
+	log.Printf("Serving %d tweets of %d users on http://%s", len(a.tweets), len(a.users), *addr)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+}
+
+// loadArchive reads tweets from dumps and SQLite databases (.db) written by
+// timeline -db, keeping the last copy of duplicated tweets.
+func loadArchive(filenames []string) (*archive, error) {
+	byID := make(map[string]*twitterscraper.Tweet)
+	for _, filename := range filenames {
+		tweets, err := readArchiveFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", filename, err)
+		}
+		for _, tweet := range tweets {
+			byID[tweet.ID] = tweet
+		}
+	}
+
+	a := &archive{}
+	users := make(map[string]bool)
+	for _, tweet := range byID {
+		a.tweets = append(a.tweets, tweet)
+		users[tweet.Username] = true
+	}
+	twitterscraper.SortTweets(a.tweets)
+	for user := range users {
+		a.users = append(a.users, user)
+	}
+	sort.Strings(a.users)
+	return a, nil
+}
+
+// readArchiveFile reads tweets of SQLite database with .db extension or dump.
+func readArchiveFile(filename string) ([]*twitterscraper.Tweet, error) {
+	if filepath.Ext(filename) != ".db" {
+		return twitterscraper.ReadTweetsFile(filename)
+	}
+	// opening creates missing database, which would be browsed as empty
+	if _, err := os.Stat(filename); err != nil {
+		return nil, err
+	}
+	store, err := twitterscraper.OpenSQLiteStore("sqlite", filename)
+	if err != nil {
+		return nil, err
+	}
+	tweets, err := store.Tweets()
+	if closeErr := store.Close(); err == nil {
+		err = closeErr
+	}
+	return tweets, err
+}
+
+func (a *archive) handleTimeline(w http.ResponseWriter, r *http.Request) {
+	user := r.URL.Query().Get("user")
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	page := browsePage{Title: "All tweets", Users: a.users}
+	if user != "" {
+		page.Title = "@" + user
+	}
+
+	matched := 0
+	for _, tweet := range a.tweets {
+		if user != "" && tweet.Username != user {
+			continue
+		}
+		matched++
+		if matched <= offset {
+			continue
+		}
+		if len(page.Tweets) == browsePageSize {
+			page.Next = fmt.Sprintf("/?user=%s&offset=%d", url.QueryEscape(user), offset+browsePageSize)
+			break
+		}
+		page.Tweets = append(page.Tweets, tweet)
+	}
+	a.render(w, page)
+}
+
+func (a *archive) handleThread(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	page := browsePage{Title: "Thread " + id, Users: a.users}
+	// tweets are newest first, threads read from oldest
+	for i := len(a.tweets) - 1; i >= 0; i-- {
+		if a.tweets[i].ConversationID == id {
+			page.Tweets = append(page.Tweets, a.tweets[i])
+		}
+	}
+	if len(page.Tweets) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	a.render(w, page)
+}
+
+func (a *archive) render(w http.ResponseWriter, page browsePage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := browseTemplate.Execute(w, page); err != nil {
+		log.Printf("Error rendering page: %v", err)
+	}
+}
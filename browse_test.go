@@ -0,0 +1,48 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestLoadArchive(t *testing.T) {
+	dir := t.TempDir()
+	dump := filepath.Join(dir, "tweets.ndjson")
+	jsonl, err := twitterscraper.OpenJSONLStore(dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonl.Put(&twitterscraper.Tweet{ID: "1", Username: "alice", Timestamp: 1})
+	jsonl.Put(&twitterscraper.Tweet{ID: "2", Username: "alice", Timestamp: 2, Text: "old copy"})
+	if err := jsonl.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	db := filepath.Join(dir, "tweets.db")
+	sqlite, err := twitterscraper.OpenSQLiteStore("sqlite", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlite.Put(&twitterscraper.Tweet{ID: "2", Username: "alice", Timestamp: 2, Text: "new copy"})
+	sqlite.Put(&twitterscraper.Tweet{ID: "3", Username: "bob", Timestamp: 3})
+	if err := sqlite.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := loadArchive([]string{dump, db})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.tweets) != 3 || a.tweets[0].ID != "3" || a.tweets[1].Text != "new copy" || a.tweets[2].ID != "1" {
+		t.Errorf("Expected tweets of both files newest first, got %v", a.tweets)
+	}
+	if len(a.users) != 2 || a.users[0] != "alice" || a.users[1] != "bob" {
+		t.Errorf("Expected users of both files, got %v", a.users)
+	}
+
+	if _, err := loadArchive([]string{filepath.Join(dir, "missing.db")}); err == nil {
+		t.Error("Expected error for missing database")
+	}
+}
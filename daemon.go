@@ -0,0 +1,365 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+// monitorQueueSize is number of new tweets waiting for output.
+const monitorQueueSize = 1000
+
+type (
+	// daemon monitors targets and keeps their status for status endpoint.
+	daemon struct {
+		scraper  *twitterscraper.Scraper
+		started  time.Time
+		interval time.Duration
+		// ctx ends watchers of all targets
+		ctx    context.Context
+		queue  chan monitoredTweet
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		paused bool
+		// cancels stops running watchers by target
+		cancels map[*targetStatus]context.CancelFunc
+		targets []*targetStatus
+	}
+
+	monitoredTweet struct {
+		target *targetStatus
+		tweet  *twitterscraper.Tweet
+	}
+
+	daemonStatus struct {
+		RunID   string    `json:"run_id"`
+		Started time.Time `json:"started"`
+		Paused  bool      `json:"paused"`
+		// QueueDepth is number of new tweets waiting for output.
+		QueueDepth int             `json:"queue_depth"`
+		Targets    []targetStatus  `json:"targets"`
+		Accounts   []accountStatus `json:"accounts"`
+	}
+
+	targetStatus struct {
+		Username string `json:"username"`
+		// Skipped target is not polled until resumed.
+		Skipped bool `json:"skipped"`
+		// LastSuccess is time of the last successful poll.
+		LastSuccess time.Time `json:"last_success"`
+		Tweets      int       `json:"tweets"`
+		LastError   string    `json:"last_error,omitempty"`
+		LastErrorAt time.Time `json:"last_error_at"`
+	}
+
+	accountStatus struct {
+		Label       string    `json:"label"`
+		Healthy     bool      `json:"healthy"`
+		Requests    int       `json:"requests"`
+		Failures    int       `json:"failures"`
+		RateLimited int       `json:"rate_limited"`
+		AvailableAt time.Time `json:"available_at"`
+		LastUsed    time.Time `json:"last_used"`
+		LastError   string    `json:"last_error,omitempty"`
+	}
+)
+
+// monitor runs as daemon: timelines of targets are polled every interval and
+// new tweets are written as NDJSON to stdout until interrupt. With -status-addr
+// /healthz answers 503 when no account of pool is healthy and /status returns
+// targets, last successful poll of every target, accounts and queue depth.
+//
+// The same address serves admin endpoints, so it should not be reachable by
+// others:
+//
+//	POST /pause                     stop polling all targets
+//	POST /resume                    continue polling
+//	POST /targets/{username}/skip   stop polling target
+//	POST /targets/{username}/resume continue polling skipped target
+//	POST /targets/{username}/scrape poll target now, unless paused
+//
+// Admin endpoints return status. Tweets found before pause are not repeated
+// after resume.
+//
+//	go run . monitor -interval 5m -status-addr localhost:8081 elonmusk nasa
+func monitor(ctx context.Context, scraper *twitterscraper.Scraper, args []string) {
+	flags := flag.NewFlagSet("monitor", flag.ContinueOnError)
+	interval := flags.Duration("interval", 5*time.Minute, "time between polls of every target")
+	statusAddr := flags.String("status-addr", "", "address of health and status endpoints, disabled if empty")
+	tz := flags.String("tz", "UTC", "time zone of output times, IANA name or \"account\" for account profile zone")
+	fieldList := flags.String("fields", "", "comma separated fields to output, all by default")
+	politeness := flags.String("politeness", "", "pacing profile of requests: aggressive, normal or stealth")
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	if err := setPoliteness(scraper, *politeness); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+
+	fields, err := parseFields(*fieldList)
+	if err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	loc, err := loadLocation(ctx, scraper, *tz)
+	if err != nil {
+		exit(summary{}, exitConfig, fmt.Errorf("error loading time zone: %w", err))
+	}
+
+	lines := flags.Args()
+	if len(lines) == 0 || (len(lines) == 1 && lines[0] == "-") {
+		if lines, err = readLines(os.Stdin); err != nil {
+			exit(summary{}, exitConfig, fmt.Errorf("error reading stdin: %w", err))
+		}
+	}
+	list, err := parseTargets(lines)
+	if err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+
+	d := &daemon{
+		scraper:  scraper,
+		started:  time.Now(),
+		interval: *interval,
+		ctx:      ctx,
+		queue:    make(chan monitoredTweet, monitorQueueSize),
+		cancels:  make(map[*targetStatus]context.CancelFunc),
+	}
+	d.mu.Lock()
+	for _, t := range list {
+		target := &targetStatus{Username: t.Username}
+		d.targets = append(d.targets, target)
+		d.start(target)
+	}
+	d.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		// watchers are not started after context is done
+		d.mu.Lock()
+		d.mu.Unlock()
+		d.wg.Wait()
+		close(d.queue)
+	}()
+
+	if *statusAddr != "" {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /healthz", d.handleHealth)
+		mux.HandleFunc("GET /status", d.handleStatus)
+		mux.HandleFunc("POST /pause", d.handlePause)
+		mux.HandleFunc("POST /resume", d.handleResume)
+		mux.HandleFunc("POST /targets/{username}/{action}", d.handleTarget)
+		server := &http.Server{Addr: *statusAddr, Handler: mux}
+		go func() {
+			<-ctx.Done()
+			server.Shutdown(context.Background())
+		}()
+		go func() {
+			log.Printf("Serving status on http://%s", *statusAddr)
+			if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("Error serving status: %v", err)
+			}
+		}()
+	}
+
+	out := twitterscraper.NewJSONLStore(os.Stdout).WithEncoding(outputEncoding(loc, fields))
+	result := summary{Targets: len(list)}
+	for monitored := range d.queue {
+		// tweets go out as soon as they are found
+		err := out.Put(monitored.tweet)
+		if err == nil {
+			err = out.Flush()
+		}
+		if err != nil {
+			exit(result, exitPartial, fmt.Errorf("error writing output: %w", err))
+		}
+		d.mu.Lock()
+		monitored.target.Tweets++
+		d.mu.Unlock()
+		result.Tweets++
+	}
+	if err := out.Close(); err != nil {
+		exit(result, exitPartial, fmt.Errorf("error writing output: %w", err))
+	}
+	exit(result, exitSuccess, nil)
+}
+
+// start runs watcher of target, it must be called with d.mu held. Running
+// watcher is stopped first, so new one polls at once.
+func (d *daemon) start(target *targetStatus) {
+	d.stop(target)
+	if d.ctx.Err() != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(d.ctx)
+	d.cancels[target] = cancel
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.watch(ctx, target)
+	}()
+}
+
+// stop ends watcher of target, it must be called with d.mu held.
+func (d *daemon) stop(target *targetStatus) {
+	if cancel, ok := d.cancels[target]; ok {
+		cancel()
+		delete(d.cancels, target)
+	}
+}
+
+// watch queues new tweets of target and records errors until context is done.
+func (d *daemon) watch(ctx context.Context, target *targetStatus) {
+	for result := range d.scraper.MonitorUser(ctx, target.Username, d.interval) {
+		if result.Error != nil {
+			log.Printf("Error monitoring @%s: %v", target.Username, result.Error)
+			d.mu.Lock()
+			target.LastError = result.Error.Error()
+			target.LastErrorAt = time.Now()
+			d.mu.Unlock()
+			continue
+		}
+		tweet := result.Tweet
+		select {
+		case d.queue <- monitoredTweet{target: target, tweet: &tweet}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *daemon) status() daemonStatus {
+	status := daemonStatus{RunID: runID, Started: d.started, QueueDepth: len(d.queue)}
+	d.mu.Lock()
+	status.Paused = d.paused
+	for _, target := range d.targets {
+		t := *target
+		t.LastSuccess = d.scraper.LastMonitorPoll(t.Username)
+		status.Targets = append(status.Targets, t)
+	}
+	d.mu.Unlock()
+
+	for _, account := range d.scraper.Accounts() {
+		a := accountStatus{
+			Label:       account.Label,
+			Healthy:     account.Healthy,
+			Requests:    account.Requests,
+			Failures:    account.Failures,
+			RateLimited: account.RateLimited,
+			AvailableAt: account.AvailableAt,
+			LastUsed:    account.LastUsed,
+		}
+		if account.LastError != nil {
+			a.LastError = account.LastError.Error()
+		}
+		status.Accounts = append(status.Accounts, a)
+	}
+	return status
+}
+
+// handleHealth answers 503 when every account of pool is excluded after auth errors.
+func (d *daemon) handleHealth(w http.ResponseWriter, r *http.Request) {
+	accounts := d.scraper.Accounts()
+	healthy := len(accounts) == 0
+	for _, account := range accounts {
+		healthy = healthy || account.Healthy
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]string{"status": "unhealthy", "error": "no healthy accounts"})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+func (d *daemon) handleStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(d.status())
+}
+
+func (d *daemon) handlePause(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	d.paused = true
+	for _, target := range d.targets {
+		d.stop(target)
+	}
+	d.mu.Unlock()
+	log.Printf("Monitoring paused")
+	d.handleStatus(w, r)
+}
+
+func (d *daemon) handleResume(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	if d.paused {
+		d.paused = false
+		for _, target := range d.targets {
+			if !target.Skipped {
+				d.start(target)
+			}
+		}
+	}
+	d.mu.Unlock()
+	log.Printf("Monitoring resumed")
+	d.handleStatus(w, r)
+}
+
+// handleTarget skips, resumes or polls now one target.
+func (d *daemon) handleTarget(w http.ResponseWriter, r *http.Request) {
+	username := strings.TrimPrefix(r.PathValue("username"), "@")
+	action := r.PathValue("action")
+	if action != "skip" && action != "resume" && action != "scrape" {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown action %q", action))
+		return
+	}
+
+	d.mu.Lock()
+	var target *targetStatus
+	for _, t := range d.targets {
+		if strings.EqualFold(t.Username, username) {
+			target = t
+			break
+		}
+	}
+	if target == nil {
+		d.mu.Unlock()
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown target @%s", username))
+		return
+	}
+	if action != "skip" && d.paused {
+		d.mu.Unlock()
+		writeError(w, http.StatusConflict, errors.New("monitoring is paused"))
+		return
+	}
+	switch action {
+	case "skip":
+		target.Skipped = true
+		d.stop(target)
+	case "resume":
+		if target.Skipped {
+			target.Skipped = false
+			d.start(target)
+		}
+	case "scrape":
+		target.Skipped = false
+		d.start(target)
+	}
+	d.mu.Unlock()
+	log.Printf("Target @%s: %s", target.Username, action)
+	d.handleStatus(w, r)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
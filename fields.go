@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// parseFields splits comma separated list of output fields and resolves them to
+// names from tweet schema ignoring case, so -fields id,text,likes works.
+func parseFields(list string) ([]string, error) {
+	var s schema
+	if err := json.Unmarshal(tweetSchema, &s); err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(s.Properties))
+	for name := range s.Properties {
+		names[strings.ToLower(name)] = name
+	}
+
+	var fields []string
+	for _, field := range strings.Split(list, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		name, ok := names[strings.ToLower(field)]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+		fields = append(fields, name)
+	}
+	return fields, nil
+}
+
+// onlyFields returns JSON object of value with the given top level fields only.
+func onlyFields(value interface{}, fields []string) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	projected := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := object[field]; ok {
+			projected[field] = value
+		}
+	}
+	return projected, nil
+}
+
+// withoutFields returns JSON object of tweet without fields, they are removed from
+// referenced tweets too.
+func withoutFields(value interface{}, fields []string) (map[string]interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	dropFields(object, fields)
+	return object, nil
+}
+
+func dropFields(tweet map[string]interface{}, fields []string) {
+	for _, field := range fields {
+		delete(tweet, field)
+	}
+	for _, name := range []string{"InReplyToStatus", "QuotedStatus", "RetweetedStatus"} {
+		if nested, ok := tweet[name].(map[string]interface{}); ok {
+			dropFields(nested, fields)
+		}
+	}
+	if thread, ok := tweet["Thread"].([]interface{}); ok {
+		for _, t := range thread {
+			if nested, ok := t.(map[string]interface{}); ok {
+				dropFields(nested, fields)
+			}
+		}
+	}
+}
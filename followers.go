@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+// followers compares followers of the logged in account with snapshot of the
+// previous run and writes follow and unfollow events as NDJSON to stdout, then
+// replaces snapshot. The first run only saves snapshot. With -interval it
+// keeps comparing until interrupted, otherwise it can be run by cron.
+//
+//	go run . followers -snapshot followers.json -interval 6h >> follower-events.ndjson
+func followers(ctx context.Context, scraper *twitterscraper.Scraper, args []string) {
+	flags := flag.NewFlagSet("followers", flag.ContinueOnError)
+	snapshot := flags.String("snapshot", "followers.json", "file with own graph of the previous run")
+	interval := flags.Duration("interval", 0, "time between comparisons, compare once if 0")
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+
+	previous, err := readGraph(*snapshot)
+	if err != nil {
+		exit(summary{}, exitConfig, fmt.Errorf("error reading snapshot: %w", err))
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for {
+		graph, err := scraper.ExportOwnGraph(ctx, previous)
+		switch {
+		case ctx.Err() != nil:
+			exit(summary{}, exitSuccess, nil)
+		case err != nil && *interval == 0:
+			exit(summary{}, exitCode(err), fmt.Errorf("error exporting graph: %w", err))
+		case err != nil:
+			log.Printf("error exporting graph: %v", err)
+		default:
+			if previous != nil {
+				for _, event := range twitterscraper.DiffFollowers(previous, graph, time.Now()) {
+					if err := encoder.Encode(event); err != nil {
+						exit(summary{}, exitPartial, fmt.Errorf("error writing output: %w", err))
+					}
+				}
+			}
+			if err := writeGraph(*snapshot, graph); err != nil {
+				exit(summary{}, exitPartial, fmt.Errorf("error writing snapshot: %w", err))
+			}
+			previous = graph
+		}
+
+		if *interval == 0 {
+			exit(summary{}, exitSuccess, nil)
+		}
+		select {
+		case <-ctx.Done():
+			exit(summary{}, exitSuccess, nil)
+		case <-time.After(*interval):
+		}
+	}
+}
+
+// readGraph reads snapshot of own graph, nil if it doesn't exist yet.
+func readGraph(filename string) ([]twitterscraper.Relationship, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	graph := []twitterscraper.Relationship{}
+	return graph, json.Unmarshal(data, &graph)
+}
+
+// writeGraph replaces snapshot of own graph, so interrupted write keeps the
+// previous one.
+func writeGraph(filename string, graph []twitterscraper.Relationship) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := json.NewEncoder(tmp).Encode(graph); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
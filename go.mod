@@ -4,13 +4,31 @@ go 1.23.2
 
 require (
 	github.com/imperatrona/twitter-scraper v0.0.14
+	github.com/imperatrona/twitter-scraper/script v0.0.0
 	github.com/joho/godotenv v1.5.1
+	modernc.org/sqlite v1.34.1
 )
 
 require (
 	github.com/AlexEidt/Vidio v1.5.1 // indirect
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/hashicorp/golang-lru/v2 v2.0.7 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/ncruces/go-strftime v0.1.9 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
+	go.starlark.net v0.0.0-20230525235612-a134d8f9ddca // indirect
 	golang.org/x/net v0.29.0 // indirect
+	golang.org/x/sys v0.25.0 // indirect
+	modernc.org/gc/v3 v3.0.0-20240107210532-573471604cb6 // indirect
+	modernc.org/libc v1.55.3 // indirect
+	modernc.org/mathutil v1.6.0 // indirect
+	modernc.org/memory v1.8.0 // indirect
+	modernc.org/strutil v1.2.0 // indirect
+	modernc.org/token v1.1.0 // indirect
 )
 
 // Replace the remote module with your local copy
 replace github.com/imperatrona/twitter-scraper => ../twitter-scraper/library
+
+replace github.com/imperatrona/twitter-scraper/script => ../twitter-scraper/library/script
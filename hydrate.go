@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+// hydrate reads file of tweet IDs or links, "-" for stdin, and writes tweets
+// as NDJSON to stdout. Tweets are requested in batches of 100, unavailable
+// ones are logged and counted as failed.
+//
+//	go run . hydrate -fields id,text,likes ids.txt > tweets.ndjson
+func hydrate(ctx context.Context, scraper *twitterscraper.Scraper, args []string) {
+	flags := flag.NewFlagSet("hydrate", flag.ContinueOnError)
+	tz := flags.String("tz", "UTC", "time zone of output times, IANA name or \"account\" for account profile zone")
+	fieldList := flags.String("fields", "", "comma separated fields to output, all by default")
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	if flags.NArg() != 1 {
+		exit(summary{}, exitConfig, errors.New("expected file of tweet IDs"))
+	}
+
+	fields, err := parseFields(*fieldList)
+	if err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	loc, err := loadLocation(ctx, scraper, *tz)
+	if err != nil {
+		exit(summary{}, exitConfig, fmt.Errorf("error loading time zone: %w", err))
+	}
+
+	var ids []string
+	if flags.Arg(0) == "-" {
+		ids, err = twitterscraper.ReadIDs(os.Stdin)
+	} else {
+		ids, err = twitterscraper.ReadIDsFile(flags.Arg(0))
+	}
+	if err != nil {
+		exit(summary{}, exitConfig, fmt.Errorf("error reading IDs: %w", err))
+	}
+
+	out := twitterscraper.NewJSONLStore(os.Stdout).WithEncoding(outputEncoding(loc, fields))
+	result := summary{Targets: len(ids)}
+	var lastErr error
+	for tweet := range scraper.GetTweetsByIDs(ctx, ids) {
+		if tweet.Error != nil {
+			log.Printf("Error hydrating %v", tweet.Error)
+			result.Failed++
+			lastErr = tweet.Error
+			continue
+		}
+		if err := out.Put(&tweet.Tweet); err != nil {
+			out.Close()
+			exit(result, exitPartial, fmt.Errorf("error writing output: %w", err))
+		}
+		result.Tweets++
+	}
+	if err := out.Close(); err != nil {
+		exit(result, exitPartial, fmt.Errorf("error writing output: %w", err))
+	}
+	if skipped := scraper.Stats().AgeRestricted; len(skipped) > 0 {
+		log.Printf("Age-restricted tweets skipped without age-verified account: %v", skipped)
+	}
+
+	switch {
+	case result.Failed == 0:
+		exit(result, exitSuccess, nil)
+	case result.Tweets == 0:
+		exit(result, exitCode(lastErr), lastErr)
+	default:
+		exit(result, exitPartial, nil)
+	}
+}
@@ -1,11 +1,24 @@
 package twitterscraper
 
+import (
+	"context"
+	"time"
+)
+
+// TimeZone of account profile.
+type TimeZone struct {
+	Name       string `json:"name"`
+	UTCOffset  int    `json:"utc_offset"`
+	TZInfoName string `json:"tzinfo_name"`
+}
+
 type AccountSettings struct {
-	ScreenName            string `json:"screen_name"`
-	Protected             bool   `json:"protected"`
-	DisplaySensitiveMedia bool   `json:"display_sensitive_media"`
-	Language              string `json:"language"`
-	CountryCode           string `json:"country_code"`
+	ScreenName            string    `json:"screen_name"`
+	Protected             bool      `json:"protected"`
+	DisplaySensitiveMedia bool      `json:"display_sensitive_media"`
+	Language              string    `json:"language"`
+	CountryCode           string    `json:"country_code"`
+	TimeZone              *TimeZone `json:"time_zone"`
 
 	DiscoverableByEmail                          bool   `json:"discoverable_by_email"`
 	DiscoverableByMobilePhone                    bool   `json:"discoverable_by_mobile_phone"`
@@ -44,9 +57,9 @@ type AccountList struct {
 	Users []Account `json:"users"`
 }
 
-func (s *Scraper) GetAccountSettings() (AccountSettings, error) {
+func (s *Scraper) GetAccountSettings(ctx context.Context) (AccountSettings, error) {
 	var settings AccountSettings
-	req, err := s.newRequest("GET", "https://api.twitter.com/1.1/account/settings.json")
+	req, err := s.newRequest(ctx, "GET", "https://api.twitter.com/1.1/account/settings.json")
 	if err != nil {
 		return settings, err
 	}
@@ -55,9 +68,22 @@ func (s *Scraper) GetAccountSettings() (AccountSettings, error) {
 	return settings, err
 }
 
-func (s *Scraper) GetAccountList() ([]Account, error) {
+// Location returns time zone of account, UTC if it isn't set.
+func (settings AccountSettings) Location() *time.Location {
+	if settings.TimeZone == nil {
+		return time.UTC
+	}
+	if settings.TimeZone.TZInfoName != "" {
+		if loc, err := time.LoadLocation(settings.TimeZone.TZInfoName); err == nil {
+			return loc
+		}
+	}
+	return time.FixedZone(settings.TimeZone.Name, settings.TimeZone.UTCOffset)
+}
+
+func (s *Scraper) GetAccountList(ctx context.Context) ([]Account, error) {
 	var list AccountList
-	req, err := s.newRequest("GET", "https://api.twitter.com/1.1/account/multi/list.json")
+	req, err := s.newRequest(ctx, "GET", "https://api.twitter.com/1.1/account/multi/list.json")
 	if err != nil {
 		return list.Users, err
 	}
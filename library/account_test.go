@@ -1,7 +1,11 @@
 package twitterscraper_test
 
 import (
+	"context"
 	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
 )
 
 func TestGetAccountSettings(t *testing.T) {
@@ -9,7 +13,7 @@ func TestGetAccountSettings(t *testing.T) {
 		t.Skip("Skipping test due to environment variable")
 	}
 
-	settings, err := testScraper.GetAccountSettings()
+	settings, err := testScraper.GetAccountSettings(context.Background())
 	if err != nil {
 		t.Error(err)
 	}
@@ -24,7 +28,7 @@ func TestGetAccountList(t *testing.T) {
 		t.Skip("Skipping test due to environment variable")
 	}
 
-	accounts, err := testScraper.GetAccountList()
+	accounts, err := testScraper.GetAccountList(context.Background())
 	if err != nil {
 		t.Error(err)
 	}
@@ -33,3 +37,16 @@ func TestGetAccountList(t *testing.T) {
 		t.Errorf("Returned %d accounts", len(accounts))
 	}
 }
+
+func TestAccountSettingsLocation(t *testing.T) {
+	var settings twitterscraper.AccountSettings
+	if loc := settings.Location(); loc != time.UTC {
+		t.Errorf("Expected UTC without time zone, got %s", loc)
+	}
+
+	settings.TimeZone = &twitterscraper.TimeZone{Name: "Custom", UTCOffset: 3 * 3600}
+	tm := time.Date(2023, 6, 5, 12, 0, 0, 0, time.UTC).In(settings.Location())
+	if got := tm.Format(time.RFC3339); got != "2023-06-05T15:00:00+03:00" {
+		t.Errorf("Expected offset time, got %s", got)
+	}
+}
@@ -0,0 +1,270 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrNoAccounts is returned when all accounts of pool are unhealthy or rate limited.
+var ErrNoAccounts = errors.New("no available accounts in pool")
+
+type (
+	// AccountStatus is health of account in pool.
+	AccountStatus struct {
+		// Label is account-N, where N is number of account in order of adding.
+		Label    string
+		Requests int
+		Failures int
+		// RateLimited is number of 429 responses.
+		RateLimited int
+		// Healthy is false after auth error, account is not used anymore.
+		Healthy bool
+		// AvailableAt is time when rate limit of account resets.
+		AvailableAt time.Time
+		LastError   error
+		LastUsed    time.Time
+		// Proxy bound with BindAccountToProxy, empty if account uses proxy of scraper.
+		Proxy string
+		// AgeVerified is set with MarkAgeVerified, only such accounts get age-restricted tweets.
+		AgeVerified bool
+	}
+
+	poolAccount struct {
+		token     AuthToken
+		status    AccountStatus
+		transport http.RoundTripper
+	}
+
+	accountPool struct {
+		mu       sync.Mutex
+		accounts []*poolAccount
+		next     int
+	}
+
+	// ageVerifiedKey of context makes requests use only age-verified accounts.
+	ageVerifiedKey struct{}
+)
+
+// AddAccount adds account to pool. When pool has accounts, they are used in turn
+// for every request instead of cookies of scraper, rate limited accounts rest until
+// limit resets and accounts with auth errors are excluded.
+func (s *Scraper) AddAccount(token AuthToken) *Scraper {
+	if s.pool == nil {
+		s.pool = &accountPool{}
+		s.isLogged = true
+		s.setBearerToken(bearerToken2)
+	}
+
+	s.pool.mu.Lock()
+	defer s.pool.mu.Unlock()
+	s.pool.accounts = append(s.pool.accounts, &poolAccount{
+		token: token,
+		status: AccountStatus{
+			Label:   fmt.Sprintf("account-%d", len(s.pool.accounts)+1),
+			Healthy: true,
+		},
+	})
+	return s
+}
+
+// Accounts returns status of every account in pool.
+func (s *Scraper) Accounts() []AccountStatus {
+	if s.pool == nil {
+		return nil
+	}
+
+	s.pool.mu.Lock()
+	defer s.pool.mu.Unlock()
+	statuses := make([]AccountStatus, 0, len(s.pool.accounts))
+	for _, account := range s.pool.accounts {
+		statuses = append(statuses, account.status)
+	}
+	return statuses
+}
+
+// BindAccountToProxy makes account of pool with token always use proxy, so it
+// exits from the same IP, which reduces lockouts. Other accounts keep proxy of
+// scraper. Empty proxyURL removes binding.
+func (s *Scraper) BindAccountToProxy(token AuthToken, proxyURL string) error {
+	var transport http.RoundTripper
+	if proxyURL != "" {
+		t, err := s.newTransport(proxyURL)
+		if err != nil {
+			return err
+		}
+		transport = t
+	}
+	if s.pool == nil {
+		return ErrNoAccounts
+	}
+
+	s.pool.mu.Lock()
+	defer s.pool.mu.Unlock()
+	for _, account := range s.pool.accounts {
+		if account.token.Token == token.Token {
+			account.transport = transport
+			account.status.Proxy = proxyURL
+			return nil
+		}
+	}
+	return fmt.Errorf("account with token %.4s... is not in pool", token.Token)
+}
+
+// MarkAgeVerified marks account of pool with token as age-verified, so
+// GetTweet requests age-restricted tweets with it.
+func (s *Scraper) MarkAgeVerified(token AuthToken, verified bool) error {
+	if s.pool == nil {
+		return ErrNoAccounts
+	}
+
+	s.pool.mu.Lock()
+	defer s.pool.mu.Unlock()
+	for _, account := range s.pool.accounts {
+		if account.token.Token == token.Token {
+			account.status.AgeVerified = verified
+			return nil
+		}
+	}
+	return fmt.Errorf("account with token %.4s... is not in pool", token.Token)
+}
+
+// withAgeVerified returns context in which requests use only age-verified accounts of pool.
+func withAgeVerified(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ageVerifiedKey{}, true)
+}
+
+// isAgeVerifiedOnly checks if requests in ctx use only age-verified accounts.
+func isAgeVerifiedOnly(ctx context.Context) bool {
+	verified, _ := ctx.Value(ageVerifiedKey{}).(bool)
+	return verified
+}
+
+// pick returns the next healthy account which is not rate limited and its
+// bound transport, nil if account uses transport of scraper. With ageVerified
+// only age-verified accounts are picked. Request reports picked account
+// itself, as concurrent requests pick accounts at once.
+func (p *accountPool) pick(ageVerified bool) (*poolAccount, http.RoundTripper, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for i := 0; i < len(p.accounts); i++ {
+		account := p.accounts[(p.next+i)%len(p.accounts)]
+		if !account.status.Healthy || now.Before(account.status.AvailableAt) || (ageVerified && !account.status.AgeVerified) {
+			continue
+		}
+		p.next = (p.next + i + 1) % len(p.accounts)
+		account.status.Requests++
+		account.status.LastUsed = now
+		return account, account.transport, nil
+	}
+	return nil, nil, ErrNoAccounts
+}
+
+// transport returns transport bound to account labelled label, or to the
+// healthy account picked next if there is no such account, nil if account
+// uses transport of scraper.
+func (p *accountPool) transport(label string) http.RoundTripper {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, account := range p.accounts {
+		if account.status.Label == label {
+			return account.transport
+		}
+	}
+	for i := 0; i < len(p.accounts); i++ {
+		if account := p.accounts[(p.next+i)%len(p.accounts)]; account.status.Healthy {
+			return account.transport
+		}
+	}
+	return nil
+}
+
+// hasAgeVerified checks if pool has healthy age-verified account.
+func (p *accountPool) hasAgeVerified() bool {
+	if p == nil {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, account := range p.accounts {
+		if account.status.Healthy && account.status.AgeVerified {
+			return true
+		}
+	}
+	return false
+}
+
+// availableAt returns the earliest time when a healthy account is available,
+// now for account which isn't resting, zero time if there are no healthy
+// accounts. With ageVerified only age-verified accounts are considered, as
+// by pick.
+func (p *accountPool) availableAt(ageVerified bool) time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var earliest time.Time
+	found := false
+	for _, account := range p.accounts {
+		if !account.status.Healthy || (ageVerified && !account.status.AgeVerified) {
+			continue
+		}
+		at := account.status.AvailableAt
+		if at.Before(now) {
+			at = now
+		}
+		if !found || at.Before(earliest) {
+			earliest = at
+			found = true
+		}
+	}
+	return earliest
+}
+
+// report updates health of account by result of request, resp is nil if request failed.
+func (p *accountPool) report(account *poolAccount, resp *http.Response, err error) {
+	if account == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err == nil {
+		// rest before the next request gets 429
+		if resp.Header.Get("X-Rate-Limit-Remaining") == "0" {
+			account.status.AvailableAt = rateLimitReset(resp.Header)
+		}
+		return
+	}
+	account.status.Failures++
+	account.status.LastError = err
+
+	if resp == nil {
+		return
+	}
+	switch {
+	case errors.Is(err, ErrAuthExpired), errors.Is(err, ErrAccountLocked), errors.Is(err, ErrAccountSuspended):
+		account.status.Healthy = false
+	case errors.Is(err, ErrRateLimited):
+		account.status.RateLimited++
+		account.status.AvailableAt = rateLimitReset(resp.Header)
+	}
+}
+
+// apply sets cookies and CSRF token of account to request.
+func (account *poolAccount) apply(req *http.Request) {
+	req.AddCookie(&http.Cookie{Name: "auth_token", Value: account.token.Token})
+	req.AddCookie(&http.Cookie{Name: "ct0", Value: account.token.CSRFToken})
+	req.Header.Set("X-CSRF-Token", account.token.CSRFToken)
+}
@@ -0,0 +1,184 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestAccountPool(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("auth_token")
+		if err != nil || r.Header.Get("X-CSRF-Token") != "csrf-"+cookie.Value {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch cookie.Value {
+		case "limited":
+			w.Header().Set("X-Rate-Limit-Reset", strconv.FormatInt(reset, 10))
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "revoked":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.Write([]byte("{}"))
+		}
+	}))
+	defer ts.Close()
+
+	scraper := twitterscraper.New()
+	for _, token := range []string{"limited", "ok", "revoked"} {
+		scraper.AddAccount(twitterscraper.AuthToken{Token: token, CSRFToken: "csrf-" + token})
+	}
+
+	expected := []struct {
+		account string
+		failed  bool
+	}{
+		{"account-1", true},
+		{"account-2", false},
+		{"account-3", true},
+		{"account-2", false},
+	}
+	for i, e := range expected {
+		req, _ := http.NewRequest("GET", ts.URL, nil)
+		err := scraper.RequestAPI(req, nil)
+		if (err != nil) != e.failed {
+			t.Errorf("Request %d: unexpected error %v", i, err)
+		}
+		if account := scraper.LastAccount(); account != e.account {
+			t.Errorf("Request %d: expected %s, got %s", i, e.account, account)
+		}
+	}
+
+	accounts := scraper.Accounts()
+	if len(accounts) != 3 {
+		t.Fatalf("Expected 3 accounts, got %d", len(accounts))
+	}
+	if accounts[0].RateLimited != 1 || accounts[0].AvailableAt.Unix() != reset || !accounts[0].Healthy {
+		t.Errorf("Expected account-1 rate limited until reset, got %+v", accounts[0])
+	}
+	if accounts[1].Requests != 2 || accounts[1].Failures != 0 {
+		t.Errorf("Expected 2 successful requests of account-2, got %+v", accounts[1])
+	}
+	if accounts[2].Healthy {
+		t.Error("Expected account-3 to be unhealthy")
+	}
+
+	scraper = twitterscraper.New()
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "revoked", CSRFToken: "csrf-revoked"})
+	req, _ := http.NewRequest("GET", ts.URL, nil)
+	scraper.RequestAPI(req, nil)
+	if err := scraper.RequestAPI(req, nil); !errors.Is(err, twitterscraper.ErrNoAccounts) {
+		t.Errorf("Expected ErrNoAccounts, got %v", err)
+	}
+}
+
+func TestBindAccountToProxy(t *testing.T) {
+	// proxies answer themselves and remember tokens of accounts used them
+	newProxy := func(tokens *[]string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if cookie, err := r.Cookie("auth_token"); err == nil {
+				*tokens = append(*tokens, cookie.Value)
+			}
+			w.Write([]byte("{}"))
+		}))
+	}
+	var boundTokens, sharedTokens []string
+	bound, shared := newProxy(&boundTokens), newProxy(&sharedTokens)
+	defer bound.Close()
+	defer shared.Close()
+
+	sticky := twitterscraper.AuthToken{Token: "sticky", CSRFToken: "csrf-sticky"}
+	scraper := twitterscraper.New()
+	scraper.AddAccount(sticky)
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "free", CSRFToken: "csrf-free"})
+	if err := scraper.SetProxy(shared.URL); err != nil {
+		t.Fatal(err)
+	}
+	if err := scraper.BindAccountToProxy(sticky, bound.URL); err != nil {
+		t.Fatal(err)
+	}
+	if err := scraper.BindAccountToProxy(twitterscraper.AuthToken{Token: "unknown"}, bound.URL); err == nil {
+		t.Error("Expected error for account not in pool")
+	}
+
+	for i := 0; i < 4; i++ {
+		req, _ := http.NewRequest("GET", "http://api.example.com/", nil)
+		if err := scraper.RequestAPI(req, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(boundTokens) != 2 || boundTokens[0] != "sticky" || boundTokens[1] != "sticky" {
+		t.Errorf("Expected bound proxy used by sticky account only, got %v", boundTokens)
+	}
+	if len(sharedTokens) != 2 || sharedTokens[0] != "free" || sharedTokens[1] != "free" {
+		t.Errorf("Expected scraper proxy used by other account, got %v", sharedTokens)
+	}
+	if accounts := scraper.Accounts(); accounts[0].Proxy != bound.URL || accounts[1].Proxy != "" {
+		t.Errorf("Expected proxy in status of bound account only, got %+v", accounts)
+	}
+}
+
+func TestMarkAgeVerified(t *testing.T) {
+	// only verified account gets the tweet, others get age-restricted tombstone
+	var tokens []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, _ := r.Cookie("auth_token")
+		tokens = append(tokens, cookie.Value)
+		result := `{"__typename":"TweetTombstone","tombstone":{"text":{"text":"Age-restricted adult content."}}}`
+		if cookie.Value == "verified" {
+			result = `{"__typename":"Tweet","legacy":{"id_str":"1","full_text":"adult"}}`
+		}
+		w.Write([]byte(`{"data":{"threaded_conversation_with_injections_v2":{"instructions":[{"entries":[{"entryId":"tweet-1",
+			"content":{"itemContent":{"tweet_results":{"result":` + result + `}}}}]}]}}}`))
+	}))
+	defer ts.Close()
+	target, _ := url.Parse(ts.URL)
+
+	verified := twitterscraper.AuthToken{Token: "verified", CSRFToken: "csrf"}
+	scraper := twitterscraper.New()
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "regular", CSRFToken: "csrf"})
+	scraper.AddAccount(verified)
+	scraper.BeforeRequest(func(req *http.Request) {
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+	})
+
+	if _, err := scraper.GetTweet(context.Background(), "1"); !errors.Is(err, twitterscraper.ErrAgeRestricted) {
+		t.Errorf("Expected ErrAgeRestricted without age-verified account, got %v", err)
+	}
+	if skipped := scraper.Stats().AgeRestricted; len(skipped) != 1 || skipped[0] != "1" {
+		t.Errorf("Expected skipped tweet 1 in stats, got %v", skipped)
+	}
+
+	// regular account is next in turn again
+	scraper.GetTweet(context.Background(), "0")
+	if err := scraper.MarkAgeVerified(verified, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := scraper.MarkAgeVerified(twitterscraper.AuthToken{Token: "unknown"}, true); err == nil {
+		t.Error("Expected error for account not in pool")
+	}
+	tokens = nil
+	tweet, err := scraper.GetTweet(context.Background(), "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweet.Text != "adult" || tweet.Provenance.Account != "account-2" {
+		t.Errorf("Expected tweet of age-verified account-2, got %q of %s", tweet.Text, tweet.Provenance.Account)
+	}
+	if len(tokens) != 2 || tokens[0] != "regular" || tokens[1] != "verified" {
+		t.Errorf("Expected retry with verified account, got %v", tokens)
+	}
+	if accounts := scraper.Accounts(); accounts[0].AgeVerified || !accounts[1].AgeVerified {
+		t.Errorf("Expected only account-2 age-verified, got %+v", accounts)
+	}
+}
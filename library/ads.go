@@ -0,0 +1,60 @@
+package twitterscraper
+
+// AdsMode is handling of promoted tweets returned in timelines and search.
+type AdsMode int
+
+const (
+	// AdsInclude keeps promoted tweets at their place flagged with IsAd,
+	// default.
+	AdsInclude AdsMode = iota
+	// AdsExclude removes promoted tweets.
+	AdsExclude
+)
+
+// promotedMetadata of timeline entry of promoted tweet.
+type promotedMetadata struct {
+	AdvertiserResults struct {
+		Result struct {
+			RestID string     `json:"rest_id"`
+			Legacy legacyUser `json:"legacy"`
+		} `json:"result"`
+	} `json:"advertiser_results"`
+	DisclosureType string `json:"disclosureType"`
+}
+
+// SetAdsMode set handling of promoted tweets, which API mixes into home
+// timelines, search and conversations. Single tweet requested by ID is
+// flagged, but never removed.
+func (s *Scraper) SetAdsMode(mode AdsMode) *Scraper {
+	s.adsMode = mode
+	return s
+}
+
+// flag marks tweet of promoted entry as ad, metadata is nil for organic entry.
+func (metadata *promotedMetadata) flag(tweet *Tweet) {
+	if metadata == nil || tweet == nil {
+		return
+	}
+	advertiser := metadata.AdvertiserResults.Result
+	tweet.IsAd = true
+	tweet.Ad = &Ad{
+		AdvertiserID:       advertiser.RestID,
+		AdvertiserUsername: advertiser.Legacy.ScreenName,
+		AdvertiserName:     advertiser.Legacy.Name,
+		DisclosureType:     metadata.DisclosureType,
+	}
+}
+
+// excludeAds applies ads mode to page of tweets.
+func (s *Scraper) excludeAds(tweets []*Tweet) []*Tweet {
+	if s.adsMode != AdsExclude {
+		return tweets
+	}
+	organic := tweets[:0]
+	for _, tweet := range tweets {
+		if !tweet.IsAd {
+			organic = append(organic, tweet)
+		}
+	}
+	return organic
+}
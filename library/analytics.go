@@ -0,0 +1,113 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+)
+
+// OwnTweetAnalytics of tweet posted by the authenticated account.
+// Metrics which are not available for the tweet are zero.
+type OwnTweetAnalytics struct {
+	TweetID          string
+	Impressions      int
+	Engagements      int
+	DetailExpands    int
+	LinkClicks       int
+	ProfileVisits    int
+	Follows          int
+	URLClicks        int
+	HashtagClicks    int
+	PermalinkClicks  int
+	MediaViews       int
+	MediaEngagements int
+	Likes            int
+	Retweets         int
+	Replies          int
+	Bookmarks        int
+}
+
+// metrics requested for own tweets analytics
+var ownTweetMetrics = []string{
+	"Impressions", "Engagements", "DetailExpands", "LinkClicks", "ProfileVisits", "Follows",
+	"UrlClicks", "HashtagClicks", "PermalinkClicks", "MediaViews", "MediaEngagements",
+	"Likes", "Retweets", "Replies", "Bookmarks",
+}
+
+type tweetActivity struct {
+	Data struct {
+		Result struct {
+			Result struct {
+				OrganicMetricsTimeSeries []struct {
+					MetricValues []struct {
+						MetricValue int    `json:"metric_value"`
+						MetricType  string `json:"metric_type"`
+					} `json:"metric_values"`
+				} `json:"organic_metrics_time_series"`
+			} `json:"result"`
+		} `json:"result"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// GetOwnTweetAnalytics returns detailed analytics of tweet posted by the authenticated account.
+func (s *Scraper) GetOwnTweetAnalytics(ctx context.Context, tweetID string, from time.Time, to time.Time) (*OwnTweetAnalytics, error) {
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/Ek2yIkuWs8jYJd1yZwgBAA/TweetActivityQuery")
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"restId":                     tweetID,
+		"from_time":                  from.UTC().Format(time.RFC3339),
+		"to_time":                    to.UTC().Format(time.RFC3339),
+		"first_48_hours_time":        from.Add(48 * time.Hour).UTC().Format(time.RFC3339),
+		"requested_organic_metrics":  ownTweetMetrics,
+		"requested_promoted_metrics": []string{},
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	req.URL.RawQuery = query.Encode()
+
+	var activity tweetActivity
+	err = s.RequestAPI(req, &activity)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(activity.Errors) > 0 {
+		return nil, errors.New(activity.Errors[0].Message)
+	}
+
+	analytics := &OwnTweetAnalytics{TweetID: tweetID}
+	metrics := map[string]*int{
+		"Impressions":      &analytics.Impressions,
+		"Engagements":      &analytics.Engagements,
+		"DetailExpands":    &analytics.DetailExpands,
+		"LinkClicks":       &analytics.LinkClicks,
+		"ProfileVisits":    &analytics.ProfileVisits,
+		"Follows":          &analytics.Follows,
+		"UrlClicks":        &analytics.URLClicks,
+		"HashtagClicks":    &analytics.HashtagClicks,
+		"PermalinkClicks":  &analytics.PermalinkClicks,
+		"MediaViews":       &analytics.MediaViews,
+		"MediaEngagements": &analytics.MediaEngagements,
+		"Likes":            &analytics.Likes,
+		"Retweets":         &analytics.Retweets,
+		"Replies":          &analytics.Replies,
+		"Bookmarks":        &analytics.Bookmarks,
+	}
+	for _, series := range activity.Data.Result.Result.OrganicMetricsTimeSeries {
+		for _, value := range series.MetricValues {
+			if metric, ok := metrics[value.MetricType]; ok {
+				*metric += value.MetricValue
+			}
+		}
+	}
+
+	return analytics, nil
+}
@@ -0,0 +1,32 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetOwnTweetAnalytics(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	settings, err := testScraper.GetAccountSettings(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tweets, _, err := testScraper.FetchTweets(context.Background(), settings.ScreenName, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) == 0 {
+		t.Skip("Skipping test, account has no tweets")
+	}
+
+	analytics, err := testScraper.GetOwnTweetAnalytics(context.Background(), tweets[0].ID, tweets[0].TimeParsed, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analytics.TweetID != tweets[0].ID {
+		t.Errorf("Expected tweet ID %s, got %s", tweets[0].ID, analytics.TweetID)
+	}
+}
@@ -1,7 +1,10 @@
 package twitterscraper
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,30 +13,182 @@ import (
 
 const bearerToken string = "AAAAAAAAAAAAAAAAAAAAAPYXBAAAAAAACLXUNDekMxqa8h%2F40K4moUkGsoc%3DTYfbDKbT3jJPCEVnMYqilB28NHfOPqkca3qaAxGfsyKCs0wRbw"
 
-// RequestAPI get JSON from frontend API and decodes it
+// maximum retries of 5xx and network errors, see IsRetryable
+const maxTransientRetries = 3
+
+// requestMeta describes request served response, it's set in provenance of
+// tweets parsed from response.
+type requestMeta struct {
+	requestID string
+	// account is label of pool account, empty without pool.
+	account string
+	// proxy bound to pool account, empty if request used proxy of scraper.
+	proxy string
+}
+
+// RequestAPI get JSON from frontend API and decodes it.
+// Rate limited requests are retried according to rate limit strategy,
+// with Wait and Rotate strategies other retryable errors are retried
+// up to 3 times with exponential backoff, unless SetRetry is used.
 func (s *Scraper) RequestAPI(req *http.Request, target interface{}) error {
+	_, err := s.requestAPIMeta(req, target)
+	return err
+}
+
+// requestAPIMeta is RequestAPI returning metadata of the last attempt.
+func (s *Scraper) requestAPIMeta(req *http.Request, target interface{}) (requestMeta, error) {
+	policy := s.retryPolicy()
+	if s.rateLimitStrategy == RateLimitFail && policy == nil && s.isLogged {
+		return s.requestAPI(req, target)
+	}
+
+	// every attempt uses copy of request, as headers are set on sending
+	base := req.Clone(req.Context())
+	guestRefreshed := false
+	for attempt := 1; ; {
+		meta, err := s.requestAPI(req, target)
+		allResting := err == ErrNoAccounts && !s.pool.availableAt(isAgeVerifiedOnly(req.Context())).IsZero()
+		switch {
+		case !guestRefreshed && s.isGuestTokenRejected(req, err):
+			// guest token can be revoked before its TTL, it's refetched once
+			guestRefreshed = true
+		case s.rateLimitStrategy != RateLimitFail && (isRateLimit(err) || allResting):
+			if err := s.waitRateLimit(req.Context(), err); err != nil {
+				return meta, err
+			}
+		case policy != nil && isTransient(err) && attempt < policy.maxAttempts && req.Context().Err() == nil:
+			delay := policy.delay(attempt)
+			s.reportRetry(RetryInfo{Attempt: attempt, RequestID: meta.requestID, Endpoint: endpointName(req.URL), Delay: delay, Err: err})
+			if err := sleepContext(req.Context(), delay); err != nil {
+				return meta, err
+			}
+			attempt++
+		case errors.Is(err, ErrEmptyResponse):
+			// response of the last attempt is already decoded
+			return meta, nil
+		default:
+			return meta, err
+		}
+
+		retry := base.Clone(base.Context())
+		if base.Body != nil {
+			if base.GetBody == nil {
+				return meta, err
+			}
+			if retry.Body, err = base.GetBody(); err != nil {
+				return meta, err
+			}
+		}
+		req = retry
+	}
+}
+
+func (s *Scraper) requestAPI(req *http.Request, target interface{}) (requestMeta, error) {
 	s.wg.Wait()
-	if s.delay > 0 {
-		defer s.delayRequest()
+	if slots := s.requestSlots; slots != nil {
+		select {
+		case slots <- struct{}{}:
+		case <-req.Context().Done():
+			return requestMeta{}, req.Context().Err()
+		}
+		defer func() { <-slots }()
+	}
+	politeness := s.politenessOf(req.Context())
+	if politeness.MaxDelay > 0 || politeness.MinDelay > 0 {
+		defer s.delayRequest(politeness.requestDelay())
+	} else if s.delay > 0 {
+		defer s.delayRequest(time.Second * time.Duration(s.delay))
+	}
+
+	var account *poolAccount
+	var transport http.RoundTripper
+	if s.pool != nil {
+		var err error
+		if account, transport, err = s.pool.pick(isAgeVerifiedOnly(req.Context())); err != nil {
+			return requestMeta{}, err
+		}
+	}
+
+	meta := requestMeta{requestID: newRequestID()}
+	if account != nil {
+		meta.account = account.status.Label
+		meta.proxy = account.status.Proxy
 	}
 
 	if err := s.prepareRequest(req); err != nil {
-		return err
+		return meta, err
 	}
 
-	resp, err := s.client.Do(req)
+	client := s.client
+	timeout := s.endpointTimeout(endpointName(req.URL))
+	if account != nil || timeout > 0 {
+		c := *s.client
+		if account != nil {
+			account.apply(req)
+			// cookies of pool account must not mix with cookies in jar
+			c.Jar = nil
+			// bound proxy is set on copy, so concurrent requests keep their own
+			if transport != nil {
+				c.Transport = transport
+			}
+		}
+		if timeout > 0 {
+			c.Timeout = timeout
+		}
+		client = &c
+	}
+
+	var reqBody []byte
+	if s.har != nil && req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return meta, err
+		}
+		reqBody = body
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	requestID := meta.requestID
+	s.beforeRequest(req)
+	started := time.Now()
+	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		err = &RequestError{RequestID: requestID, Err: err}
+		s.pool.report(account, nil, err)
+		s.recordRequest(requestID, req, account, nil, started, err)
+		s.logRequest(requestID, req, nil, time.Since(started), err)
+		return meta, err
 	}
 	defer resp.Body.Close()
+	s.onResponse(resp, time.Since(started))
+
+	if s.har != nil {
+		content, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return meta, err
+		}
+		s.har.record(requestID, req, reqBody, resp, content, started)
+		resp.Body = io.NopCloser(bytes.NewReader(content))
+	}
 
-	return s.handleResponse(resp, target)
+	err = s.handleResponse(resp, target)
+	if apiErr, ok := err.(*APIError); ok {
+		apiErr.RequestID = requestID
+	}
+	s.pool.report(account, resp, err)
+	s.recordRequest(requestID, req, account, resp, started, err)
+	s.logRequest(requestID, req, resp, time.Since(started), err)
+	if account != nil && (errors.Is(err, ErrAuthExpired) || errors.Is(err, ErrAccountLocked) || errors.Is(err, ErrAccountSuspended)) {
+		s.logWarn("twitterscraper: account excluded from pool", "account", account.status.Label, "error", err)
+	}
+	return meta, err
 }
 
-func (s *Scraper) delayRequest() {
+func (s *Scraper) delayRequest(delay time.Duration) {
 	s.wg.Add(1)
 	go func() {
-		time.Sleep(time.Second * time.Duration(s.delay))
+		time.Sleep(delay)
 		s.wg.Done()
 	}()
 }
@@ -54,8 +209,11 @@ func (s *Scraper) prepareRequest(req *http.Request) error {
 }
 
 func (s *Scraper) setGuestToken(req *http.Request) error {
-	if !s.IsGuestToken() || s.guestCreatedAt.Before(time.Now().Add(-time.Hour*3)) {
-		if err := s.GetGuestToken(); err != nil {
+	s.guestMu.Lock()
+	defer s.guestMu.Unlock()
+	// concurrent requests wait for one refresh instead of fetching a token each
+	if s.guestToken == "" || time.Since(s.guestCreatedAt) > s.guestTTL {
+		if err := s.getGuestToken(req.Context()); err != nil {
 			return err
 		}
 	}
@@ -86,24 +244,50 @@ func (s *Scraper) handleResponse(resp *http.Response, target interface{}) error
 		return err
 	}
 
+	code := responseErrorCode(content)
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("response status %s: %s", resp.Status, content)
+		return &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Code: code, Header: resp.Header, Body: content}
 	}
 
-	if resp.Header.Get("X-Rate-Limit-Remaining") == "0" {
-		s.guestToken = ""
+	if resp.Header.Get("X-Rate-Limit-Remaining") == "0" || code == errCodeRateLimited {
+		s.invalidateGuestToken(resp.Request)
+	}
+
+	// some endpoints respond with 200 status and errors array instead of data
+	if isAccountErrorCode(code) {
+		return &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Code: code, Header: resp.Header, Body: content}
 	}
 
 	if target == nil {
 		return nil
 	}
+	if s.retry.maxAttempts > 0 && isEmptyGraphQL(resp, content) {
+		if len(bytes.TrimSpace(content)) > 0 {
+			json.Unmarshal(content, target)
+		}
+		return ErrEmptyResponse
+	}
 
-	return json.Unmarshal(content, target)
+	err = json.Unmarshal(content, target)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && !s.failOnParseError {
+		// the rest of response is decoded, only mismatched field is left empty
+		s.reportParseError(&ParseError{Type: "response", ID: endpointName(resp.Request.URL), Field: typeErr.Field, Err: typeErr})
+		return nil
+	}
+	return err
 }
 
 // GetGuestToken from Twitter API
-func (s *Scraper) GetGuestToken() error {
-	req, err := http.NewRequest("POST", "https://api.twitter.com/1.1/guest/activate.json", nil)
+func (s *Scraper) GetGuestToken(ctx context.Context) error {
+	s.guestMu.Lock()
+	defer s.guestMu.Unlock()
+	return s.getGuestToken(ctx)
+}
+
+// getGuestToken requests new guest token, guestMu must be held.
+func (s *Scraper) getGuestToken(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.twitter.com/1.1/guest/activate.json", nil)
 	if err != nil {
 		return err
 	}
@@ -120,7 +304,7 @@ func (s *Scraper) GetGuestToken() error {
 		return err
 	}
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("response status %s: %s", resp.Status, body)
+		return &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Header: resp.Header, Body: body}
 	}
 
 	var jsn map[string]interface{}
@@ -137,8 +321,10 @@ func (s *Scraper) GetGuestToken() error {
 }
 
 func (s *Scraper) ClearGuestToken() error {
+	s.guestMu.Lock()
 	s.guestToken = ""
 	s.guestCreatedAt = time.Time{}
+	s.guestMu.Unlock()
 
 	return nil
 }
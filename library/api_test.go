@@ -1,13 +1,16 @@
 package twitterscraper_test
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestGetGuestToken(t *testing.T) {
 	scraper := newTestScraper(true)
 
-	if err := scraper.GetGuestToken(); err != nil {
+	if err := scraper.GetGuestToken(context.Background()); err != nil {
 		t.Errorf("getGuestToken() error = %v", err)
 	}
 	if !scraper.IsGuestToken() {
@@ -19,8 +22,35 @@ func TestClearGuestToken(t *testing.T) {
 	scraper := newTestScraper(false)
 
 	scraper.ClearGuestToken()
-	
+
 	if scraper.IsGuestToken() {
 		t.Error("Expected empty guestToken")
 	}
 }
+
+func TestGuestTokenTTL(t *testing.T) {
+	scraper := newTestScraper(true)
+	scraper.SetGuestTokenTTL(time.Nanosecond)
+
+	// every request refetches expired token, concurrent ones share refresh
+	var wg sync.WaitGroup
+	errs := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := scraper.GetProfile(context.Background(), "x")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if !scraper.IsGuestToken() {
+		t.Error("Expected non-empty guestToken")
+	}
+}
@@ -0,0 +1,88 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// AudienceOverlap of followers between several users.
+type AudienceOverlap struct {
+	// Followers IDs of each user.
+	Followers map[string][]string
+	// Common followers of all users.
+	Common []string
+	// Exclusive followers which follow only this user from compared.
+	Exclusive map[string][]string
+	// Profiles of all scraped followers by ID.
+	Profiles map[string]Profile
+}
+
+// GetAudienceOverlap scrapes up to maxFollowersNbr followers of each user and compares their audiences.
+func (s *Scraper) GetAudienceOverlap(ctx context.Context, users []string, maxFollowersNbr int) (*AudienceOverlap, error) {
+	if len(users) < 2 {
+		return nil, errors.New("at least two users required to compare audiences")
+	}
+
+	overlap := &AudienceOverlap{
+		Followers: make(map[string][]string),
+		Exclusive: make(map[string][]string),
+		Profiles:  make(map[string]Profile),
+	}
+
+	followedBy := make(map[string]int)
+	for _, user := range users {
+		if _, ok := overlap.Followers[user]; ok {
+			return nil, errors.New("duplicated user " + user)
+		}
+		overlap.Followers[user] = []string{}
+
+		seen := make(map[string]bool)
+		for profile := range getUserTimeline(ctx, user, maxFollowersNbr, s.FetchFollowers) {
+			if profile.Error != nil {
+				return nil, profile.Error
+			}
+			if seen[profile.UserID] {
+				continue
+			}
+			seen[profile.UserID] = true
+			followedBy[profile.UserID]++
+			overlap.Profiles[profile.UserID] = profile.Profile
+			overlap.Followers[user] = append(overlap.Followers[user], profile.UserID)
+		}
+		sort.Strings(overlap.Followers[user])
+	}
+
+	for _, user := range users {
+		for _, id := range overlap.Followers[user] {
+			if followedBy[id] == 1 {
+				overlap.Exclusive[user] = append(overlap.Exclusive[user], id)
+			}
+		}
+	}
+
+	for id, count := range followedBy {
+		if count == len(users) {
+			overlap.Common = append(overlap.Common, id)
+		}
+	}
+	sort.Strings(overlap.Common)
+
+	return overlap, nil
+}
+
+// Shared returns IDs of followers shared by two compared users.
+func (overlap *AudienceOverlap) Shared(user1, user2 string) []string {
+	followers := make(map[string]bool)
+	for _, id := range overlap.Followers[user1] {
+		followers[id] = true
+	}
+
+	var shared []string
+	for _, id := range overlap.Followers[user2] {
+		if followers[id] {
+			shared = append(shared, id)
+		}
+	}
+	return shared
+}
@@ -0,0 +1,34 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetAudienceOverlap(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+
+	overlap, err := testScraper.GetAudienceOverlap(context.Background(), []string{"Support", "X"}, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, user := range []string{"Support", "X"} {
+		if len(overlap.Followers[user]) == 0 {
+			t.Errorf("Expected followers of %s", user)
+		}
+		for _, id := range overlap.Exclusive[user] {
+			if _, ok := overlap.Profiles[id]; !ok {
+				t.Errorf("Expected profile of exclusive follower %s", id)
+			}
+		}
+	}
+
+	for _, id := range overlap.Common {
+		if len(overlap.Exclusive["Support"]) > 0 && overlap.Exclusive["Support"][0] == id {
+			t.Errorf("Common follower %s can't be exclusive", id)
+		}
+	}
+}
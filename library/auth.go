@@ -2,6 +2,7 @@ package twitterscraper
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha1"
 	"encoding/base64"
@@ -43,6 +44,9 @@ type (
 		Subtasks  []struct {
 			SubtaskID   string      `json:"subtask_id"`
 			OpenAccount OpenAccount `json:"open_account"`
+			EnterText   struct {
+				KeyboardType string `json:"keyboard_type"`
+			} `json:"enter_text"`
 		} `json:"subtasks"`
 	}
 
@@ -54,8 +58,8 @@ type (
 	}
 )
 
-func (s *Scraper) getAccessToken(consumerKey, consumerSecret string) (string, error) {
-	req, err := http.NewRequest("POST", oAuthURL, strings.NewReader("grant_type=client_credentials"))
+func (s *Scraper) getAccessToken(ctx context.Context, consumerKey, consumerSecret string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", oAuthURL, strings.NewReader("grant_type=client_credentials"))
 	if err != nil {
 		return "", err
 	}
@@ -82,12 +86,12 @@ func (s *Scraper) getAccessToken(consumerKey, consumerSecret string) (string, er
 	return a.AccessToken, nil
 }
 
-func (s *Scraper) getFlow(data map[string]interface{}) (*flow, error) {
+func (s *Scraper) getFlow(ctx context.Context, data map[string]interface{}) (*flow, error) {
 	headers := http.Header{
 		"Authorization":             []string{"Bearer " + s.bearerToken},
 		"Content-Type":              []string{"application/json"},
 		"User-Agent":                []string{s.userAgent},
-		"X-Guest-Token":             []string{s.guestToken},
+		"X-Guest-Token":             []string{s.currentGuestToken()},
 		"X-Twitter-Auth-Type":       []string{"OAuth2Client"},
 		"X-Twitter-Active-User":     []string{"yes"},
 		"X-Twitter-Client-Language": []string{"en"},
@@ -97,7 +101,7 @@ func (s *Scraper) getFlow(data map[string]interface{}) (*flow, error) {
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", loginURL, bytes.NewReader(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", loginURL, bytes.NewReader(jsonData))
 	if err != nil {
 		return nil, err
 	}
@@ -119,8 +123,8 @@ func (s *Scraper) getFlow(data map[string]interface{}) (*flow, error) {
 	return &info, nil
 }
 
-func (s *Scraper) getFlowToken(data map[string]interface{}) (string, error) {
-	info, err := s.getFlow(data)
+func (s *Scraper) getFlowToken(ctx context.Context, data map[string]interface{}) (string, error) {
+	info, err := s.getFlow(ctx, data)
 	if err != nil {
 		return "", err
 	}
@@ -145,10 +149,10 @@ func (s *Scraper) getFlowToken(data map[string]interface{}) (string, error) {
 }
 
 // IsLoggedIn check if scraper logged in
-func (s *Scraper) IsLoggedIn() bool {
+func (s *Scraper) IsLoggedIn(ctx context.Context) bool {
 	s.isLogged = true
 	s.setBearerToken(bearerToken2)
-	req, err := http.NewRequest("GET", "https://api.twitter.com/1.1/account/verify_credentials.json", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.twitter.com/1.1/account/verify_credentials.json", nil)
 	if err != nil {
 		return false
 	}
@@ -173,161 +177,31 @@ func randomDelay() {
 // Use Login(username, password) for ordinary login
 // or Login(username, password, email) for login if you have email confirmation
 // or Login(username, password, code_for_2FA) for login if you have two-factor authentication
-func (s *Scraper) Login(credentials ...string) error {
-	var username, password, confirmation string
+// See LoginWithOptions to answer challenges with TOTP secret or code callback.
+func (s *Scraper) Login(ctx context.Context, credentials ...string) error {
 	if len(credentials) < 2 || len(credentials) > 3 {
 		return fmt.Errorf("invalid credentials")
 	}
 
-	username, password = credentials[0], credentials[1]
+	var opts []LoginOption
 	if len(credentials) == 3 {
-		confirmation = credentials[2]
+		confirmation := credentials[2]
+		opts = append(opts, WithLoginEmail(confirmation), WithLoginCode(func(context.Context, string) (string, error) {
+			return confirmation, nil
+		}))
 	}
-
-	s.setBearerToken(bearerToken2)
-
-	err := s.GetGuestToken()
-	if err != nil {
-		return err
-	}
-
-	randomDelay()
-
-	// flow start
-	data := map[string]interface{}{
-		"flow_name": "login",
-		"input_flow_data": map[string]interface{}{
-			"flow_context": map[string]interface{}{
-				"debug_overrides": map[string]interface{}{},
-				"start_location":  map[string]interface{}{"location": "splash_screen"},
-			},
-		},
-	}
-	flowToken, err := s.getFlowToken(data)
-	if err != nil {
-		return err
-	}
-
-	randomDelay()
-
-	// flow instrumentation step
-	data = map[string]interface{}{
-		"flow_token": flowToken,
-		"subtask_inputs": []map[string]interface{}{
-			{
-				"subtask_id":         "LoginJsInstrumentationSubtask",
-				"js_instrumentation": map[string]interface{}{"response": "{}", "link": "next_link"},
-			},
-		},
-	}
-	flowToken, err = s.getFlowToken(data)
-	if err != nil {
-		return err
-	}
-
-	randomDelay()
-
-	// flow username step
-	data = map[string]interface{}{
-		"flow_token": flowToken,
-		"subtask_inputs": []map[string]interface{}{
-			{
-				"subtask_id": "LoginEnterUserIdentifierSSO",
-				"settings_list": map[string]interface{}{
-					"setting_responses": []map[string]interface{}{
-						{
-							"key":           "user_identifier",
-							"response_data": map[string]interface{}{"text_data": map[string]interface{}{"result": username}},
-						},
-					},
-					"link": "next_link",
-				},
-			},
-		},
-	}
-	flowToken, err = s.getFlowToken(data)
-	if err != nil {
-		return err
-	}
-
-	randomDelay()
-
-	// flow password step
-	data = map[string]interface{}{
-		"flow_token": flowToken,
-		"subtask_inputs": []map[string]interface{}{
-			{
-				"subtask_id":     "LoginEnterPassword",
-				"enter_password": map[string]interface{}{"password": password, "link": "next_link"},
-			},
-		},
-	}
-	flowToken, err = s.getFlowToken(data)
-	if err != nil {
-		return err
-	}
-
-	randomDelay()
-
-	// flow duplication check
-	data = map[string]interface{}{
-		"flow_token": flowToken,
-		"subtask_inputs": []map[string]interface{}{
-			{
-				"subtask_id":              "AccountDuplicationCheck",
-				"check_logged_in_account": map[string]interface{}{"link": "AccountDuplicationCheck_false"},
-			},
-		},
-	}
-	flowToken, err = s.getFlowToken(data)
-	if err != nil {
-		var confirmationSubtask string
-		for _, subtask := range []string{"LoginAcid", "LoginTwoFactorAuthChallenge"} {
-			if strings.Contains(err.Error(), subtask) {
-				confirmationSubtask = subtask
-				break
-			}
-		}
-		if confirmationSubtask != "" {
-			if confirmation == "" {
-				return fmt.Errorf("confirmation data required for %v", confirmationSubtask)
-			}
-
-			randomDelay()
-
-			// flow confirmation
-			data = map[string]interface{}{
-				"flow_token": flowToken,
-				"subtask_inputs": []map[string]interface{}{
-					{
-						"subtask_id": confirmationSubtask,
-						"enter_text": map[string]interface{}{"text": confirmation, "link": "next_link"},
-					},
-				},
-			}
-			_, err = s.getFlowToken(data)
-			if err != nil {
-				return err
-			}
-		} else {
-			return err
-		}
-	}
-
-	s.isLogged = true
-	s.isOpenAccount = false
-	return nil
+	return s.LoginWithOptions(ctx, credentials[0], credentials[1], opts...)
 }
 
 // LoginOpenAccount as Twitter app
-func (s *Scraper) LoginOpenAccount() (OpenAccount, error) {
-	accessToken, err := s.getAccessToken(appConsumerKey, appConsumerSecret)
+func (s *Scraper) LoginOpenAccount(ctx context.Context) (OpenAccount, error) {
+	accessToken, err := s.getAccessToken(ctx, appConsumerKey, appConsumerSecret)
 	if err != nil {
 		return OpenAccount{}, err
 	}
 	s.setBearerToken(accessToken)
 
-	err = s.GetGuestToken()
+	err = s.GetGuestToken(ctx)
 	if err != nil {
 		return OpenAccount{}, err
 	}
@@ -342,7 +216,7 @@ func (s *Scraper) LoginOpenAccount() (OpenAccount, error) {
 			},
 		},
 	}
-	flowToken, err := s.getFlowToken(data)
+	flowToken, err := s.getFlowToken(ctx, data)
 	if err != nil {
 		return OpenAccount{}, err
 	}
@@ -356,7 +230,7 @@ func (s *Scraper) LoginOpenAccount() (OpenAccount, error) {
 			},
 		},
 	}
-	info, err := s.getFlow(data)
+	info, err := s.getFlow(ctx, data)
 	if err != nil {
 		return OpenAccount{}, err
 	}
@@ -387,8 +261,8 @@ func (s *Scraper) WithOpenAccount(openAccount OpenAccount) {
 }
 
 // Logout is reset session
-func (s *Scraper) Logout() error {
-	req, err := http.NewRequest("POST", logoutURL, nil)
+func (s *Scraper) Logout(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", logoutURL, nil)
 	if err != nil {
 		return err
 	}
@@ -399,7 +273,6 @@ func (s *Scraper) Logout() error {
 
 	s.isLogged = false
 	s.isOpenAccount = false
-	s.guestToken = ""
 	s.oAuthToken = ""
 	s.oAuthSecret = ""
 	s.client.Jar, _ = cookiejar.New(nil)
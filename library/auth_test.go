@@ -1,12 +1,14 @@
 package twitterscraper_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	twitterscraper "github.com/imperatrona/twitter-scraper"
 )
@@ -20,6 +22,7 @@ var (
 	username      = os.Getenv("TWITTER_USERNAME")
 	password      = os.Getenv("TWITTER_PASSWORD")
 	email         = os.Getenv("TWITTER_EMAIL")
+	totpSecret    = os.Getenv("TWITTER_TOTP_SECRET")
 	skipAuthTest  = os.Getenv("SKIP_AUTH_TEST") != ""
 	testScraper   = newTestScraper(false)
 )
@@ -31,7 +34,7 @@ func init() {
 
 	if authToken != "" && csrfToken != "" {
 		testScraper.SetAuthToken(twitterscraper.AuthToken{Token: authToken, CSRFToken: csrfToken})
-		if !testScraper.IsLoggedIn() {
+		if !testScraper.IsLoggedIn(context.Background()) {
 			panic("Invalid AuthToken")
 		}
 		return
@@ -41,14 +44,14 @@ func init() {
 		var parsedCookies []*http.Cookie
 		json.NewDecoder(strings.NewReader(cookies)).Decode(&parsedCookies)
 		testScraper.SetCookies(parsedCookies)
-		if !testScraper.IsLoggedIn() {
+		if !testScraper.IsLoggedIn(context.Background()) {
 			panic("Invalid Cookies")
 		}
 		return
 	}
 
 	if username != "" && password != "" {
-		err := testScraper.Login(username, password, email)
+		err := testScraper.Login(context.Background(), username, password, email)
 		if err != nil {
 			panic(fmt.Sprintf("Login() error = %v", err))
 		}
@@ -70,7 +73,7 @@ func newTestScraper(skip_auth bool) *twitterscraper.Scraper {
 	}
 
 	// Check connection by getting guest token
-	if err := s.GetGuestToken(); err != nil {
+	if err := s.GetGuestToken(context.Background()); err != nil {
 		panic(fmt.Sprintf("cannot get guest token, can also be error with connection to twitter.\n %v", err))
 	}
 
@@ -87,20 +90,66 @@ func TestLoginPassword(t *testing.T) {
 		t.Skip("Skipping test due to environment variable")
 	}
 	scraper := newTestScraper(true)
-	if err := scraper.Login(username, password, email); err != nil {
+	if err := scraper.Login(context.Background(), username, password, email); err != nil {
 		t.Fatalf("Login() error = %v", err)
 	}
-	if !scraper.IsLoggedIn() {
+	if !scraper.IsLoggedIn(context.Background()) {
 		t.Fatalf("Expected IsLoggedIn() = true")
 	}
-	if err := scraper.Logout(); err != nil {
+	if err := scraper.Logout(context.Background()); err != nil {
 		t.Errorf("Logout() error = %v", err)
 	}
-	if scraper.IsLoggedIn() {
+	if scraper.IsLoggedIn(context.Background()) {
 		t.Error("Expected IsLoggedIn() = false")
 	}
 }
 
+func TestLoginWithOptions(t *testing.T) {
+	if skipAuthTest || username == "" || password == "" || totpSecret == "" {
+		t.Skip("Skipping test due to environment variable")
+	}
+	scraper := newTestScraper(true)
+	err := scraper.LoginWithOptions(context.Background(), username, password,
+		twitterscraper.WithLoginEmail(email),
+		twitterscraper.WithLoginTOTP(totpSecret),
+	)
+	if err != nil {
+		t.Fatalf("LoginWithOptions() error = %v", err)
+	}
+	if !scraper.IsLoggedIn(context.Background()) {
+		t.Fatalf("Expected IsLoggedIn() = true")
+	}
+	if err := scraper.Logout(context.Background()); err != nil {
+		t.Errorf("Logout() error = %v", err)
+	}
+}
+
+func TestTOTP(t *testing.T) {
+	// RFC 6238 test vectors of SHA1 truncated to 6 digits
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	for unix, want := range map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	} {
+		code, err := twitterscraper.TOTP(secret, time.Unix(unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != want {
+			t.Errorf("Expected TOTP at %d = %s, got %s", unix, want, code)
+		}
+	}
+
+	if code, _ := twitterscraper.TOTP("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", time.Unix(59, 0)); code != "287082" {
+		t.Errorf("Expected secret to be normalized, got %s", code)
+	}
+	if _, err := twitterscraper.TOTP("not base32!", time.Now()); err == nil {
+		t.Error("Expected error for invalid secret")
+	}
+}
+
 func TestLoginToken(t *testing.T) {
 	if skipAuthTest || authToken == "" || csrfToken == "" {
 		t.Skip("Skipping test due to environment variable")
@@ -109,7 +158,7 @@ func TestLoginToken(t *testing.T) {
 	scraper := newTestScraper(true)
 
 	scraper.SetAuthToken(twitterscraper.AuthToken{Token: authToken, CSRFToken: csrfToken})
-	if !scraper.IsLoggedIn() {
+	if !scraper.IsLoggedIn(context.Background()) {
 		t.Error("Expected IsLoggedIn() = true")
 	}
 }
@@ -126,7 +175,7 @@ func TestLoginCookie(t *testing.T) {
 	json.NewDecoder(strings.NewReader(cookies)).Decode(&c)
 
 	scraper.SetCookies(c)
-	if !scraper.IsLoggedIn() {
+	if !scraper.IsLoggedIn(context.Background()) {
 		t.Error("Expected IsLoggedIn() = true")
 	}
 }
@@ -143,7 +192,7 @@ func TestLoginOpenAccount(t *testing.T) {
 			panic(fmt.Sprintf("SetProxy() error = %v", err))
 		}
 	}
-	account, err := scraper.LoginOpenAccount()
+	account, err := scraper.LoginOpenAccount(context.Background())
 
 	if err != nil {
 		t.Fatalf("LoginOpenAccount() error = %v", err)
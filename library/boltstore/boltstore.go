@@ -0,0 +1,92 @@
+// Package boltstore keeps tweets scraped by twitterscraper in BoltDB file. It's
+// a separate module, so scraper doesn't depend on BoltDB.
+package boltstore
+
+import (
+	"encoding/json"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+	bolt "go.etcd.io/bbolt"
+)
+
+var tweetsBucket = []byte("tweets")
+
+// Store is twitterscraper.TweetStore keeping tweets as JSON by ID in tweets
+// bucket of BoltDB database.
+type Store struct {
+	db   *bolt.DB
+	lock *twitterscraper.FileLock
+}
+
+// Open opens database file, creating it and tweets bucket if needed. File is
+// locked until Close like file of twitterscraper.OpenSQLiteStore, LockError
+// is returned if another run uses it.
+func Open(filename string) (*Store, error) {
+	lock, err := twitterscraper.LockFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(filename, 0644, nil)
+	if err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(tweetsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		lock.Unlock()
+		return nil, err
+	}
+	return &Store{db: db, lock: lock}, nil
+}
+
+// Put saves tweet, if it isn't in bucket.
+func (store *Store) Put(tweet *twitterscraper.Tweet) error {
+	data, err := json.Marshal(tweet)
+	if err != nil {
+		return err
+	}
+	return store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(tweetsBucket)
+		if bucket.Get([]byte(tweet.ID)) != nil {
+			return nil
+		}
+		return bucket.Put([]byte(tweet.ID), data)
+	})
+}
+
+// Exists tells if tweet is in bucket.
+func (store *Store) Exists(id string) (bool, error) {
+	var exists bool
+	err := store.db.View(func(tx *bolt.Tx) error {
+		exists = tx.Bucket(tweetsBucket).Get([]byte(id)) != nil
+		return nil
+	})
+	return exists, err
+}
+
+// Get returns stored tweet, nil if it isn't in bucket.
+func (store *Store) Get(id string) (*twitterscraper.Tweet, error) {
+	var tweet *twitterscraper.Tweet
+	err := store.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(tweetsBucket).Get([]byte(id))
+		if data == nil {
+			return nil
+		}
+		tweet = &twitterscraper.Tweet{}
+		return json.Unmarshal(data, tweet)
+	})
+	return tweet, err
+}
+
+// Close closes and unlocks database.
+func (store *Store) Close() error {
+	err := store.db.Close()
+	if unlockErr := store.lock.Unlock(); err == nil {
+		err = unlockErr
+	}
+	return err
+}
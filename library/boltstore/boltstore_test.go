@@ -0,0 +1,50 @@
+package boltstore_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+	"github.com/imperatrona/twitter-scraper/boltstore"
+)
+
+func TestStore(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "tweets.db")
+	store, err := boltstore.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := boltstore.Open(filename); !errors.Is(err, twitterscraper.ErrLocked) {
+		t.Errorf("Expected ErrLocked, got %v", err)
+	}
+	var tweetStore twitterscraper.TweetStore = store
+	for _, tweet := range []*twitterscraper.Tweet{{ID: "1", Text: "first"}, {ID: "2"}, {ID: "1", Text: "again"}} {
+		if err := tweetStore.Put(tweet); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = boltstore.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	for _, id := range []string{"1", "2"} {
+		if exists, err := store.Exists(id); err != nil || !exists {
+			t.Errorf("Expected tweet %s in reopened store, got %v %v", id, exists, err)
+		}
+	}
+	if exists, _ := store.Exists("3"); exists {
+		t.Error("Expected tweet 3 not in store")
+	}
+	if tweet, err := store.Get("1"); err != nil || tweet.Text != "first" {
+		t.Errorf("Expected stored tweet kept, got %+v %v", tweet, err)
+	}
+	if tweet, err := store.Get("3"); err != nil || tweet != nil {
+		t.Errorf("Expected nil for tweet not in store, got %+v %v", tweet, err)
+	}
+}
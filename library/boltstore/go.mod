@@ -0,0 +1,10 @@
+module github.com/imperatrona/twitter-scraper/boltstore
+
+go 1.16
+
+require (
+	github.com/imperatrona/twitter-scraper v0.0.14
+	go.etcd.io/bbolt v1.3.6
+)
+
+replace github.com/imperatrona/twitter-scraper => ../
@@ -2,23 +2,39 @@ package twitterscraper
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/url"
 )
 
-// GetBookmarks returns channel with tweets from user bookmarks.
+// BookmarkFolder of the authenticated account.
+type BookmarkFolder struct {
+	ID   string
+	Name string
+}
+
+// GetBookmarks returns channel with tweets from user bookmarks. Scrape stopped
+// before the end of list resumes from the last page on the next call, as
+// GetFollowers.
 func (s *Scraper) GetBookmarks(ctx context.Context, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, "", maxTweetsNbr, func(unused string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-		return s.FetchBookmarks(maxTweetsNbr, cursor)
-	})
+	return s.getTweetTimeline(ctx, "", maxTweetsNbr, s.trackTweets("bookmarks", func(ctx context.Context, unused string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		return s.FetchBookmarks(ctx, maxTweetsNbr, cursor)
+	}))
+}
+
+// GetBookmarkFolderTweets returns channel with tweets from bookmark folder,
+// resumes as GetBookmarks.
+func (s *Scraper) GetBookmarkFolderTweets(ctx context.Context, folderID string, maxTweetsNbr int) <-chan *TweetResult {
+	return s.getTweetTimeline(ctx, folderID, maxTweetsNbr, s.trackTweets(cursorKey("bookmarks", folderID), s.FetchBookmarkFolderTweets))
 }
 
 // FetchBookmarks gets bookmarked tweets via the Twitter frontend GraphQL API.
-func (s *Scraper) FetchBookmarks(maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+func (s *Scraper) FetchBookmarks(ctx context.Context, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxTweetsNbr > 200 {
 		maxTweetsNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/-IyJFt9_jS_9d_vS3NN-fA/Bookmarks")
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/-IyJFt9_jS_9d_vS3NN-fA/Bookmarks")
 	if err != nil {
 		return nil, "", err
 	}
@@ -57,15 +73,224 @@ func (s *Scraper) FetchBookmarks(maxTweetsNbr int, cursor string) ([]*Tweet, str
 
 	query := url.Values{}
 	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
 	req.URL.RawQuery = query.Encode()
 
 	var timeline bookmarksTimelineV2
-	err = s.RequestAPI(req, &timeline)
+	meta, err := s.requestAPIMeta(req, &timeline)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tweets, nextCursor := timeline.parseTweets()
+	s.setProvenance(tweets, meta, EndpointBookmarks, cursor)
+	if tweets, err = s.filterTweets(tweets); err != nil {
+		return nil, "", err
+	}
+	return tweets, nextCursor, nil
+}
+
+// GetBookmarkFolders returns bookmark folders of the authenticated account.
+func (s *Scraper) GetBookmarkFolders(ctx context.Context) ([]BookmarkFolder, error) {
+	var folders []BookmarkFolder
+	var cursor string
+	for {
+		req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/i78YDd0Tza-dV4SYs58kRg/BookmarkFoldersSlice")
+		if err != nil {
+			return nil, err
+		}
+
+		variables := map[string]interface{}{}
+		if cursor != "" {
+			variables["cursor"] = cursor
+		}
+		query := url.Values{}
+		query.Set("variables", mapToJSONString(variables))
+		req.URL.RawQuery = query.Encode()
+
+		var response struct {
+			Data struct {
+				Viewer struct {
+					UserResults struct {
+						Result struct {
+							Slice struct {
+								Items []struct {
+									ID   string `json:"id"`
+									Name string `json:"name"`
+								} `json:"items"`
+								SliceInfo struct {
+									NextCursor string `json:"next_cursor"`
+								} `json:"slice_info"`
+							} `json:"bookmark_collections_slice"`
+						} `json:"result"`
+					} `json:"user_results"`
+				} `json:"viewer"`
+			} `json:"data"`
+		}
+		if err := s.RequestAPI(req, &response); err != nil {
+			return nil, err
+		}
+
+		slice := response.Data.Viewer.UserResults.Result.Slice
+		for _, item := range slice.Items {
+			folders = append(folders, BookmarkFolder{ID: item.ID, Name: item.Name})
+		}
+		if len(slice.Items) == 0 || slice.SliceInfo.NextCursor == "" || slice.SliceInfo.NextCursor == cursor {
+			return folders, nil
+		}
+		cursor = slice.SliceInfo.NextCursor
+	}
+}
+
+// FetchBookmarkFolderTweets gets tweets of bookmark folder via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchBookmarkFolderTweets(ctx context.Context, folderID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	if maxTweetsNbr > 200 {
+		maxTweetsNbr = 200
+	}
+
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/e1T2rbPyW4gDfMNhvqPqxA/BookmarkFolderTimeline")
+	if err != nil {
+		return nil, "", err
+	}
+
+	variables := map[string]interface{}{
+		"bookmark_collection_id": folderID,
+		"count":                  maxTweetsNbr,
+		"includePromotedContent": false,
+	}
+	features := map[string]interface{}{
+		"graphql_timeline_v2_bookmark_timeline":                                   true,
+		"responsive_web_graphql_exclude_directive_enabled":                        true,
+		"verified_phone_label_enabled":                                            false,
+		"creator_subscriptions_tweet_preview_api_enabled":                         true,
+		"responsive_web_graphql_timeline_navigation_enabled":                      true,
+		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
+		"tweetypie_unmention_optimization_enabled":                                true,
+		"responsive_web_edit_tweet_api_enabled":                                   true,
+		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+		"view_counts_everywhere_api_enabled":                                      true,
+		"longform_notetweets_consumption_enabled":                                 true,
+		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+		"tweet_awards_web_tipping_enabled":                                        false,
+		"freedom_of_speech_not_reach_fetch_enabled":                               true,
+		"standardized_nudges_misinfo":                                             true,
+		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+		"rweb_video_timestamps_enabled":                                           true,
+		"longform_notetweets_rich_text_read_enabled":                              true,
+		"longform_notetweets_inline_media_enabled":                                true,
+		"responsive_web_enhance_cards_enabled":                                    false,
+	}
+
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
+	req.URL.RawQuery = query.Encode()
+
+	var timeline bookmarkFolderTimelineV2
+	meta, err := s.requestAPIMeta(req, &timeline)
 	if err != nil {
 		return nil, "", err
 	}
 
 	tweets, nextCursor := timeline.parseTweets()
+	s.setProvenance(tweets, meta, EndpointBookmarks, cursor)
+	if tweets, err = s.filterTweets(tweets); err != nil {
+		return nil, "", err
+	}
 	return tweets, nextCursor, nil
 }
+
+// BookmarkTweet adds tweet to bookmarks of the authenticated account.
+func (s *Scraper) BookmarkTweet(ctx context.Context, tweetId string) error {
+	req, err := s.newRequest(ctx, "POST", "https://twitter.com/i/api/graphql/aoDbu3RHznuiSkQ9aNM67Q/CreateBookmark")
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("content-type", "application/json")
+	variables := map[string]interface{}{
+		"tweet_id": tweetId,
+	}
+	body := map[string]interface{}{
+		"variables": variables,
+		"queryId":   "aoDbu3RHznuiSkQ9aNM67Q",
+	}
+
+	b, _ := json.Marshal(body)
+	setRequestBody(req, b)
+
+	var response struct {
+		Data struct {
+			TweetBookmarkPut string `json:"tweet_bookmark_put"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+			Code    int    `json:"code"`
+		} `json:"errors"`
+	}
+
+	err = s.RequestAPI(req, &response)
+	if err != nil {
+		return err
+	}
+
+	if len(response.Errors) > 0 {
+		return errors.New(response.Errors[0].Message)
+	}
+
+	if response.Data.TweetBookmarkPut != "Done" {
+		return errors.New("unknown error")
+	}
+
+	return nil
+}
+
+// UnbookmarkTweet removes tweet from bookmarks of the authenticated account.
+func (s *Scraper) UnbookmarkTweet(ctx context.Context, tweetId string) error {
+	req, err := s.newRequest(ctx, "POST", "https://twitter.com/i/api/graphql/Wlmlj2-xzyS1GN3a6cj-mQ/DeleteBookmark")
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("content-type", "application/json")
+	variables := map[string]interface{}{
+		"tweet_id": tweetId,
+	}
+	body := map[string]interface{}{
+		"variables": variables,
+		"queryId":   "Wlmlj2-xzyS1GN3a6cj-mQ",
+	}
+
+	b, _ := json.Marshal(body)
+	setRequestBody(req, b)
+
+	var response struct {
+		Data struct {
+			TweetBookmarkDelete string `json:"tweet_bookmark_delete"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+			Code    int    `json:"code"`
+		} `json:"errors"`
+	}
+
+	err = s.RequestAPI(req, &response)
+	if err != nil {
+		return err
+	}
+
+	if len(response.Errors) > 0 {
+		return errors.New(response.Errors[0].Message)
+	}
+
+	if response.Data.TweetBookmarkDelete != "Done" {
+		return errors.New("unknown error")
+	}
+
+	return nil
+}
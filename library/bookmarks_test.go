@@ -61,3 +61,42 @@ func TestGetBookmarks(t *testing.T) {
 		t.Errorf("Expected tweets count=%v, got: %v", maxTweetsNbr, count)
 	}
 }
+
+func TestBookmarkAndUnbookmarkTweet(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	if err := testScraper.BookmarkTweet(context.Background(), "1665602315745673217"); err != nil {
+		t.Fatal(err)
+	}
+	if err := testScraper.UnbookmarkTweet(context.Background(), "1665602315745673217"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetBookmarkFolders(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	folders, err := testScraper.GetBookmarkFolders(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, folder := range folders {
+		if folder.ID == "" || folder.Name == "" {
+			t.Errorf("Expected folder with ID and name, got %+v", folder)
+		}
+	}
+	if len(folders) == 0 {
+		t.Skip("Account has no bookmark folders")
+	}
+
+	for tweet := range testScraper.GetBookmarkFolderTweets(context.Background(), folders[0].ID, 20) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		if tweet.ID == "" {
+			t.Error("Expected tweet ID is empty")
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package twitterscraper
+
+// AuthState of scraper, endpoints available depend on it.
+type AuthState int
+
+const (
+	// AuthGuest - not logged in, guest token is used
+	AuthGuest AuthState = iota
+	// AuthOpenAccount - logged in with OpenAccount
+	AuthOpenAccount
+	// AuthLogin - logged in with password, cookies or auth token
+	AuthLogin
+)
+
+func (state AuthState) String() string {
+	switch state {
+	case AuthOpenAccount:
+		return "open account"
+	case AuthLogin:
+		return "login"
+	default:
+		return "guest"
+	}
+}
+
+// Capability of scraper method in current auth state.
+type Capability struct {
+	Method    string
+	Available bool
+	// MinAuth is the least auth state the method works with.
+	MinAuth AuthState
+}
+
+// least auth state required by methods
+var capabilityMatrix = []struct {
+	method  string
+	minAuth AuthState
+}{
+	{"GetTweet", AuthGuest},
+	{"GetTweetsByIDs", AuthGuest},
+	{"GetTweetReplies", AuthGuest},
+	{"GetTweetRetweeters", AuthGuest},
+	{"GetTweets", AuthGuest},
+	{"GetTweetsAndReplies", AuthGuest},
+	{"GetTweetsByUserID", AuthGuest},
+	{"GetMediaTweets", AuthGuest},
+	{"GetProfile", AuthGuest},
+	{"GetProfileByID", AuthGuest},
+	{"GetProfilesByIDs", AuthGuest},
+	{"GetTrends", AuthGuest},
+	{"DoGraphQL", AuthGuest},
+	{"GetBookmarks", AuthLogin},
+	{"GetHomeTweets", AuthLogin},
+	{"GetForYouTweets", AuthLogin},
+	{"SearchTweets", AuthLogin},
+	{"SearchProfiles", AuthLogin},
+	{"GetUserCounts", AuthLogin},
+	{"FetchFollowing", AuthLogin},
+	{"FetchFollowers", AuthLogin},
+	{"GetAudienceOverlap", AuthLogin},
+	{"GetUserLists", AuthLogin},
+	{"CreateList", AuthLogin},
+	{"AddListMember", AuthLogin},
+	{"RemoveListMember", AuthLogin},
+	{"BlockUser", AuthLogin},
+	{"MuteUser", AuthLogin},
+	{"ModerateUsers", AuthLogin},
+	{"GetSpace", AuthLogin},
+	{"LikeTweet", AuthLogin},
+	{"UnlikeTweet", AuthLogin},
+	{"BookmarkTweet", AuthLogin},
+	{"UnbookmarkTweet", AuthLogin},
+	{"CreateTweet", AuthLogin},
+	{"DeleteTweet", AuthLogin},
+	{"CreateRetweet", AuthLogin},
+	{"DeleteRetweet", AuthLogin},
+	{"FetchScheduledTweets", AuthLogin},
+	{"CreateScheduledTweet", AuthLogin},
+	{"DeleteScheduledTweet", AuthLogin},
+	{"UploadMedia", AuthLogin},
+	{"GetOwnTweetAnalytics", AuthLogin},
+	{"GetAccountSettings", AuthLogin},
+	{"GetAccountList", AuthLogin},
+}
+
+// AuthState returns current auth state.
+func (s *Scraper) AuthState() AuthState {
+	if s.isOpenAccount {
+		return AuthOpenAccount
+	}
+	if s.isLogged {
+		return AuthLogin
+	}
+	return AuthGuest
+}
+
+// Capabilities reports which methods are usable in the current auth state,
+// so callers can degrade gracefully instead of discovering restrictions via errors.
+// Sensitive content is returned only when logged in, even by methods available for guests.
+func (s *Scraper) Capabilities() []Capability {
+	state := s.AuthState()
+	capabilities := make([]Capability, 0, len(capabilityMatrix))
+	for _, c := range capabilityMatrix {
+		capabilities = append(capabilities, Capability{
+			Method:    c.method,
+			Available: state >= c.minAuth,
+			MinAuth:   c.minAuth,
+		})
+	}
+	return capabilities
+}
+
+// Can checks if method is usable in the current auth state.
+// Unknown methods are reported as usable.
+func (s *Scraper) Can(method string) bool {
+	for _, c := range s.Capabilities() {
+		if c.Method == method {
+			return c.Available
+		}
+	}
+	return true
+}
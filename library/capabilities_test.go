@@ -0,0 +1,30 @@
+package twitterscraper_test
+
+import (
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestCapabilities(t *testing.T) {
+	scraper := twitterscraper.New()
+	if scraper.AuthState() != twitterscraper.AuthGuest {
+		t.Errorf("Expected guest auth state, got %s", scraper.AuthState())
+	}
+	if !scraper.Can("GetTweet") {
+		t.Error("Expected GetTweet available for guest")
+	}
+	if scraper.Can("SearchTweets") {
+		t.Error("Expected SearchTweets unavailable for guest")
+	}
+
+	scraper.WithOpenAccount(twitterscraper.OpenAccount{OAuthToken: "token", OAuthTokenSecret: "secret"})
+	if scraper.AuthState() != twitterscraper.AuthOpenAccount {
+		t.Errorf("Expected open account auth state, got %s", scraper.AuthState())
+	}
+	for _, capability := range scraper.Capabilities() {
+		if capability.Available != (capability.MinAuth != twitterscraper.AuthLogin) {
+			t.Errorf("Unexpected capability %+v for open account", capability)
+		}
+	}
+}
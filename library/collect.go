@@ -0,0 +1,92 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CollectErrors are errors of results collected by CollectTweetResults and
+// CollectProfileResults, errors.Is matches any of them.
+type CollectErrors []error
+
+func (errs CollectErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors: %s", len(errs), strings.Join(messages, "; "))
+}
+
+// Is reports whether any of errors matches target.
+func (errs CollectErrors) Is(target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// CollectTweetResults drains channel returned by Get* and Search* methods and
+// returns all tweets with error of results: nil, the only error or
+// CollectErrors. Tweets collected before error are returned too.
+func CollectTweetResults(results <-chan *TweetResult) ([]*Tweet, error) {
+	var tweets []*Tweet
+	var errs CollectErrors
+	for result := range results {
+		if result.Error != nil {
+			errs = append(errs, result.Error)
+			continue
+		}
+		tweet := result.Tweet
+		tweets = append(tweets, &tweet)
+	}
+	return tweets, errs.err()
+}
+
+// CollectProfileResults is CollectTweetResults for profiles.
+func CollectProfileResults(results <-chan *ProfileResult) ([]*Profile, error) {
+	var profiles []*Profile
+	var errs CollectErrors
+	for result := range results {
+		if result.Error != nil {
+			errs = append(errs, result.Error)
+			continue
+		}
+		profile := result.Profile
+		profiles = append(profiles, &profile)
+	}
+	return profiles, errs.err()
+}
+
+// CollectTweets returns up to maxTweetsNbr tweets of user, see GetTweets.
+func (s *Scraper) CollectTweets(ctx context.Context, user string, maxTweetsNbr int) ([]*Tweet, error) {
+	return CollectTweetResults(s.GetTweets(ctx, user, maxTweetsNbr))
+}
+
+// CollectSearchTweets returns up to maxTweetsNbr tweets found by query, see SearchTweets.
+func (s *Scraper) CollectSearchTweets(ctx context.Context, query string, maxTweetsNbr int) ([]*Tweet, error) {
+	return CollectTweetResults(s.SearchTweets(ctx, query, maxTweetsNbr))
+}
+
+// CollectFollowers returns up to maxUsersNbr followers of user, see GetFollowers.
+func (s *Scraper) CollectFollowers(ctx context.Context, user string, maxUsersNbr int) ([]*Profile, error) {
+	return CollectProfileResults(s.GetFollowers(ctx, user, maxUsersNbr))
+}
+
+// CollectFollowing returns up to maxUsersNbr profiles followed by user, see GetFollowing.
+func (s *Scraper) CollectFollowing(ctx context.Context, user string, maxUsersNbr int) ([]*Profile, error) {
+	return CollectProfileResults(s.GetFollowing(ctx, user, maxUsersNbr))
+}
+
+func (errs CollectErrors) err() error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return errs
+}
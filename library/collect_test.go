@@ -0,0 +1,47 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestCollectTweetResults(t *testing.T) {
+	results := make(chan *twitterscraper.TweetResult, 4)
+	results <- &twitterscraper.TweetResult{Tweet: twitterscraper.Tweet{ID: "1"}}
+	results <- &twitterscraper.TweetResult{Error: twitterscraper.ErrTweetNotFound}
+	results <- &twitterscraper.TweetResult{Tweet: twitterscraper.Tweet{ID: "2"}}
+	results <- &twitterscraper.TweetResult{Error: twitterscraper.ErrRateLimited}
+	close(results)
+
+	tweets, err := twitterscraper.CollectTweetResults(results)
+	if len(tweets) != 2 || tweets[0].ID != "1" || tweets[1].ID != "2" {
+		t.Errorf("Expected tweets 1 and 2, got %v", tweets)
+	}
+	var errs twitterscraper.CollectErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("Expected 2 collected errors, got %v", err)
+	}
+	if !errors.Is(err, twitterscraper.ErrRateLimited) || errors.Is(err, twitterscraper.ErrUserNotFound) {
+		t.Errorf("Expected errors.Is to match collected errors only, got %v", err)
+	}
+
+	results = make(chan *twitterscraper.TweetResult, 1)
+	results <- &twitterscraper.TweetResult{Error: twitterscraper.ErrRateLimited}
+	close(results)
+	if _, err := twitterscraper.CollectTweetResults(results); err != twitterscraper.ErrRateLimited {
+		t.Errorf("Expected the only error as is, got %v", err)
+	}
+}
+
+func TestCollectTweets(t *testing.T) {
+	tweets, err := testScraper.CollectTweets(context.Background(), "x", 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) == 0 || len(tweets) > 30 {
+		t.Errorf("Expected up to 30 tweets, got %d", len(tweets))
+	}
+}
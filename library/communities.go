@@ -0,0 +1,174 @@
+package twitterscraper
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Community of twitter users.
+type Community struct {
+	ID          string
+	Name        string
+	Description string
+	MemberCount int
+	// JoinPolicy is Open or RestrictedJoinRequestsRequireModeratorApproval.
+	JoinPolicy string
+	CreatedAt  time.Time
+}
+
+type communityResult struct {
+	RestID      string `json:"rest_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MemberCount int    `json:"member_count"`
+	JoinPolicy  string `json:"join_policy"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+func (community *communityResult) parse() *Community {
+	result := &Community{
+		ID:          community.RestID,
+		Name:        community.Name,
+		Description: community.Description,
+		MemberCount: community.MemberCount,
+		JoinPolicy:  community.JoinPolicy,
+	}
+	if community.CreatedAt > 0 {
+		result.CreatedAt = time.Unix(community.CreatedAt/1000, 0)
+	}
+	return result
+}
+
+type communityTimeline struct {
+	Data struct {
+		CommunityResults struct {
+			Result struct {
+				RankedCommunityTimeline struct {
+					Timeline struct {
+						Instructions []struct {
+							Entries []entry `json:"entries"`
+							Type    string  `json:"type"`
+						} `json:"instructions"`
+					} `json:"timeline"`
+				} `json:"ranked_community_timeline"`
+			} `json:"result"`
+		} `json:"communityResults"`
+	} `json:"data"`
+}
+
+func (timeline *communityTimeline) parseTweets() ([]*Tweet, string) {
+	var bookmarks bookmarksTimelineV2
+	bookmarks.Data.Bookmarks.Timeline = timeline.Data.CommunityResults.Result.RankedCommunityTimeline.Timeline
+	return bookmarks.parseTweets()
+}
+
+// GetCommunityTweets returns channel with tweets of community, the most recent
+// first. Scrape stopped before the end of timeline resumes from the last page
+// on the next call, as GetFollowers.
+func (s *Scraper) GetCommunityTweets(ctx context.Context, communityID string, maxTweetsNbr int) <-chan *TweetResult {
+	return s.getTweetTimeline(ctx, communityID, maxTweetsNbr, s.trackTweets(cursorKey("community", communityID), s.FetchCommunityTweets))
+}
+
+// FetchCommunityTweets gets tweets of community via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchCommunityTweets(ctx context.Context, communityID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	if maxTweetsNbr > 100 {
+		maxTweetsNbr = 100
+	}
+
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/7B2AdxSuC-Er8qUr3Plm_w/CommunityTweetsTimeline")
+	if err != nil {
+		return nil, "", err
+	}
+
+	variables := map[string]interface{}{
+		"communityId":     communityID,
+		"count":           maxTweetsNbr,
+		"displayLocation": "Community",
+		"rankingMode":     "Recency",
+		"withCommunity":   true,
+	}
+	features := map[string]interface{}{
+		"rweb_tipjar_consumption_enabled":                                         true,
+		"responsive_web_graphql_exclude_directive_enabled":                        true,
+		"verified_phone_label_enabled":                                            false,
+		"creator_subscriptions_tweet_preview_api_enabled":                         true,
+		"responsive_web_graphql_timeline_navigation_enabled":                      true,
+		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+		"communities_web_enable_tweet_community_results_fetch":                    true,
+		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
+		"articles_preview_enabled":                                                true,
+		"responsive_web_edit_tweet_api_enabled":                                   true,
+		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+		"view_counts_everywhere_api_enabled":                                      true,
+		"longform_notetweets_consumption_enabled":                                 true,
+		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+		"tweet_awards_web_tipping_enabled":                                        false,
+		"freedom_of_speech_not_reach_fetch_enabled":                               true,
+		"standardized_nudges_misinfo":                                             true,
+		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+		"rweb_video_timestamps_enabled":                                           true,
+		"longform_notetweets_rich_text_read_enabled":                              true,
+		"longform_notetweets_inline_media_enabled":                                true,
+		"responsive_web_enhance_cards_enabled":                                    false,
+	}
+
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
+	req.URL.RawQuery = query.Encode()
+
+	var timeline communityTimeline
+	meta, err := s.requestAPIMeta(req, &timeline)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tweets, nextCursor := timeline.parseTweets()
+	s.setProvenance(tweets, meta, EndpointCommunity, cursor)
+	if tweets, err = s.filterTweets(tweets); err != nil {
+		return nil, "", err
+	}
+	return tweets, nextCursor, nil
+}
+
+// SearchCommunities returns communities matching query, via the Twitter
+// frontend GraphQL API.
+func (s *Scraper) SearchCommunities(ctx context.Context, query string) ([]*Community, error) {
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/daVUkhfHn7-Z8llpYVKJSw/CommunitiesSearchQuery")
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"query": query,
+	}
+	q := url.Values{}
+	q.Set("variables", mapToJSONString(variables))
+	req.URL.RawQuery = q.Encode()
+
+	var response struct {
+		Data struct {
+			CommunitiesSearchSlice struct {
+				ItemsResults []struct {
+					Result communityResult `json:"result"`
+				} `json:"items_results"`
+			} `json:"communities_search_slice"`
+		} `json:"data"`
+	}
+	if err := s.RequestAPI(req, &response); err != nil {
+		return nil, err
+	}
+
+	var communities []*Community
+	for _, item := range response.Data.CommunitiesSearchSlice.ItemsResults {
+		if item.Result.RestID != "" {
+			communities = append(communities, item.Result.parse())
+		}
+	}
+	return communities, nil
+}
@@ -0,0 +1,45 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestGetCommunityTweets(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	count := 0
+	for tweet := range testScraper.GetCommunityTweets(context.Background(), "1493446837214187523", 20) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		if tweet.ID == "" || tweet.Provenance == nil || tweet.Provenance.Endpoint != twitterscraper.EndpointCommunity {
+			t.Errorf("Unexpected tweet %+v", tweet.Tweet)
+		}
+		count++
+	}
+	if count == 0 {
+		t.Error("Expected community tweets")
+	}
+}
+
+func TestSearchCommunities(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	communities, err := testScraper.SearchCommunities(context.Background(), "golang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(communities) == 0 {
+		t.Fatal("Expected communities")
+	}
+	for _, community := range communities {
+		if community.ID == "" || community.Name == "" {
+			t.Errorf("Unexpected community %+v", community)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// UserCounts of twitter user without the rest of profile.
+type UserCounts struct {
+	UserID         string
+	Username       string
+	FollowersCount int
+	FollowingCount int
+	TweetsCount    int
+	ListedCount    int
+}
+
+// maximum users in one lookup request
+const userLookupBatchSize = 100
+
+// GetUserCounts return followers, following, tweets and listed counts for usernames.
+// Users are requested in batches of 100, suspended and not found users are omitted.
+func (s *Scraper) GetUserCounts(ctx context.Context, usernames []string) ([]UserCounts, error) {
+	return s.lookupUserCounts(ctx, "screen_name", usernames)
+}
+
+// GetUserCountsByIDs return followers, following, tweets and listed counts for user IDs.
+// Users are requested in batches of 100, suspended and not found users are omitted.
+func (s *Scraper) GetUserCountsByIDs(ctx context.Context, userIDs []string) ([]UserCounts, error) {
+	return s.lookupUserCounts(ctx, "user_id", userIDs)
+}
+
+// UserCheck is result of lightweight check of username, see CheckUsers.
+type UserCheck struct {
+	Username    string
+	UserID      string
+	Exists      bool
+	Suspended   bool
+	Protected   bool
+	TweetsCount int
+}
+
+// CheckUsers reports if users exist, are suspended or protected and how many tweets they have,
+// to validate target lists before full scrapes. Existing users are looked up in batches of 100,
+// only missing ones are requested one by one to tell suspended from not found.
+// Results are in order of usernames.
+func (s *Scraper) CheckUsers(ctx context.Context, usernames []string) ([]UserCheck, error) {
+	users, err := s.lookupUsers(ctx, "screen_name", usernames)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]legacyUser, len(users))
+	for _, user := range users {
+		found[strings.ToLower(user.ScreenName)] = user
+	}
+
+	checks := make([]UserCheck, 0, len(usernames))
+	for _, username := range usernames {
+		check := UserCheck{Username: username}
+		if user, ok := found[strings.ToLower(username)]; ok {
+			check.Username = user.ScreenName
+			check.UserID = user.IDStr
+			check.Exists = true
+			check.Protected = user.Protected
+			check.TweetsCount = user.StatusesCount
+		} else {
+			_, err := s.GetProfile(ctx, username)
+			switch {
+			case errors.Is(err, ErrUserSuspended):
+				check.Exists = true
+				check.Suspended = true
+			case errors.Is(err, ErrUserNotFound):
+			case err != nil:
+				return nil, err
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// GetUserTweetsCount returns number of tweets user claims to have with one cheap request.
+// Returns ErrUserNotFound or ErrUserSuspended if there is no such active user.
+func (s *Scraper) GetUserTweetsCount(ctx context.Context, username string) (int, error) {
+	checks, err := s.CheckUsers(ctx, []string{username})
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case checks[0].Suspended:
+		return 0, ErrUserSuspended
+	case !checks[0].Exists:
+		return 0, ErrUserNotFound
+	}
+	return checks[0].TweetsCount, nil
+}
+
+func (s *Scraper) lookupUserCounts(ctx context.Context, param string, values []string) ([]UserCounts, error) {
+	users, err := s.lookupUsers(ctx, param, values)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := []UserCounts{}
+	for _, user := range users {
+		counts = append(counts, UserCounts{
+			UserID:         user.IDStr,
+			Username:       user.ScreenName,
+			FollowersCount: user.FollowersCount,
+			FollowingCount: user.FriendsCount,
+			TweetsCount:    user.StatusesCount,
+			ListedCount:    user.ListedCount,
+		})
+	}
+	return counts, nil
+}
+
+// lookupUsers requests users in batches, suspended and not found users are omitted.
+func (s *Scraper) lookupUsers(ctx context.Context, param string, values []string) ([]legacyUser, error) {
+	var users []legacyUser
+	for start := 0; start < len(values); start += userLookupBatchSize {
+		end := start + userLookupBatchSize
+		if end > len(values) {
+			end = len(values)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "https://api.twitter.com/1.1/users/lookup.json", nil)
+		if err != nil {
+			return nil, err
+		}
+		q := req.URL.Query()
+		q.Add(param, strings.Join(values[start:end], ","))
+		q.Add("include_entities", "false")
+		q.Add("skip_status", "true")
+		req.URL.RawQuery = q.Encode()
+
+		var batch []legacyUser
+		err = s.RequestAPI(req, &batch)
+		var apiErr *APIError
+		// lookup responds with 404 if none of users is found
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, batch...)
+	}
+	return users, nil
+}
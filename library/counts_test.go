@@ -0,0 +1,50 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestGetUserCounts(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	counts, err := testScraper.GetUserCountsByIDs(context.Background(), []string{"1221221876849995777", "783214"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 2 {
+		t.Fatalf("Expected 2 users, got %d", len(counts))
+	}
+	for _, user := range counts {
+		if user.Username == "" || user.FollowersCount == 0 {
+			t.Errorf("Expected username and followers count, got %+v", user)
+		}
+	}
+}
+
+func TestCheckUsers(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	checks, err := testScraper.CheckUsers(context.Background(), []string{"support", "nomadic_ua", "sample3123131"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checks) != 3 {
+		t.Fatalf("Expected 3 checks, got %d", len(checks))
+	}
+	if !checks[0].Exists || checks[0].UserID == "" || checks[0].TweetsCount == 0 {
+		t.Errorf("Expected existing user with tweets, got %+v", checks[0])
+	}
+	if checks[2].Exists {
+		t.Errorf("Expected not existing user, got %+v", checks[2])
+	}
+
+	if _, err := testScraper.GetUserTweetsCount(context.Background(), "sample3123131"); !errors.Is(err, twitterscraper.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
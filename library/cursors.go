@@ -0,0 +1,231 @@
+package twitterscraper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type (
+	// CursorStore keeps cursors of interrupted scrapes across runs, see
+	// WithCursorStore. Empty cursor removes key.
+	CursorStore interface {
+		LoadCursors() (map[string]string, error)
+		SaveCursor(key, cursor string) error
+	}
+
+	// FileCursorStore keeps cursors as JSON object in file.
+	FileCursorStore struct {
+		mu       sync.Mutex
+		filename string
+		cursors  map[string]string
+	}
+
+	// cursorTracker keeps cursor of the last page of paginated scrapes by
+	// key, so interrupted scrape starts again from that page instead of the
+	// beginning. Cursors are saved with session file and to store if any.
+	cursorTracker struct {
+		mu      sync.Mutex
+		cursors map[string]string
+		store   CursorStore
+	}
+)
+
+// NewFileCursorStore keeps cursors in file, which is created on the first
+// save and replaced at once on every save, so it's never half written.
+func NewFileCursorStore(filename string) *FileCursorStore {
+	return &FileCursorStore{filename: filename}
+}
+
+// LoadCursors reads cursors from file, none if file doesn't exist.
+func (store *FileCursorStore) LoadCursors() (map[string]string, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.cursors = make(map[string]string)
+	data, err := os.ReadFile(store.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &store.cursors); err != nil {
+		return nil, err
+	}
+	cursors := make(map[string]string, len(store.cursors))
+	for key, cursor := range store.cursors {
+		cursors[key] = cursor
+	}
+	return cursors, nil
+}
+
+// SaveCursor writes cursor of key to file.
+func (store *FileCursorStore) SaveCursor(key, cursor string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if store.cursors == nil {
+		store.cursors = make(map[string]string)
+	}
+	if cursor == "" {
+		delete(store.cursors, key)
+	} else {
+		store.cursors[key] = cursor
+	}
+	data, err := json.Marshal(store.cursors)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(store.filename), filepath.Base(store.filename)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), store.filename)
+}
+
+// SetCursorStore loads cursors tracked by scraper from store and saves every
+// change of them to it, so interrupted scrapes resume in the next run
+// without session file.
+func (s *Scraper) SetCursorStore(store CursorStore) error {
+	cursors, err := store.LoadCursors()
+	if err != nil {
+		return err
+	}
+	s.cursors.mu.Lock()
+	defer s.cursors.mu.Unlock()
+	s.cursors.cursors = cursors
+	s.cursors.store = store
+	return nil
+}
+
+func (tracker *cursorTracker) get(key string) string {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	return tracker.cursors[key]
+}
+
+// set cursor of key, empty cursor removes key. Change is saved to store.
+func (tracker *cursorTracker) set(key, cursor string) error {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	if cursor == "" {
+		if _, ok := tracker.cursors[key]; !ok {
+			return nil
+		}
+		delete(tracker.cursors, key)
+	} else {
+		if tracker.cursors[key] == cursor {
+			return nil
+		}
+		if tracker.cursors == nil {
+			tracker.cursors = make(map[string]string)
+		}
+		tracker.cursors[key] = cursor
+	}
+	if tracker.store != nil {
+		return tracker.store.SaveCursor(key, cursor)
+	}
+	return nil
+}
+
+func (tracker *cursorTracker) all() map[string]string {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	cursors := make(map[string]string, len(tracker.cursors))
+	for key, cursor := range tracker.cursors {
+		cursors[key] = cursor
+	}
+	return cursors
+}
+
+func (tracker *cursorTracker) reset(cursors map[string]string) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	tracker.cursors = cursors
+}
+
+// trackProfiles wraps fetchFunc to start from tracked cursor and track cursor
+// of every fetched page. Cursor of the page itself is kept, not the next one,
+// as consumer may stop in the middle of page: resumed scrape repeats some
+// profiles rather than misses them. Cursor is removed at the end of list.
+func (s *Scraper) trackProfiles(key string, fetchFunc fetchProfileFunc) fetchProfileFunc {
+	return func(ctx context.Context, query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+		resumed := cursor == ""
+		if resumed {
+			cursor = s.cursors.get(key)
+		}
+		profiles, next, err := fetchFunc(ctx, query, maxProfilesNbr, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		tracked := cursor
+		if len(profiles) == 0 || next == "" {
+			s.warnCursorReset(key, resumed && cursor != "" && len(profiles) == 0)
+			tracked = ""
+		}
+		if err := s.cursors.set(key, tracked); err != nil {
+			return nil, "", err
+		}
+		return profiles, next, nil
+	}
+}
+
+// trackTweets is trackProfiles for tweets.
+func (s *Scraper) trackTweets(key string, fetchFunc fetchTweetFunc) fetchTweetFunc {
+	return func(ctx context.Context, query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		resumed := cursor == ""
+		if resumed {
+			cursor = s.cursors.get(key)
+		}
+		tweets, next, err := fetchFunc(ctx, query, maxTweetsNbr, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		tracked := cursor
+		if len(tweets) == 0 || next == "" {
+			s.warnCursorReset(key, resumed && cursor != "" && len(tweets) == 0)
+			tracked = ""
+		}
+		if err := s.cursors.set(key, tracked); err != nil {
+			return nil, "", err
+		}
+		return tweets, next, nil
+	}
+}
+
+// warnCursorReset logs tracked cursor which returned empty page on resume, it
+// may be expired, so the next scrape starts from the beginning.
+func (s *Scraper) warnCursorReset(key string, empty bool) {
+	if empty {
+		s.logWarn("twitterscraper: resumed cursor returned no results, cursor reset", "key", key)
+	}
+}
+
+// ClearCursors forgets tracked cursors, so GetFollowers, GetFollowing,
+// GetLikedTweets, GetRetweeters, GetQuoteTweets, SearchProfiles and
+// bookmarks start from the beginning. Cursors are removed from store too.
+func (s *Scraper) ClearCursors() {
+	for key := range s.cursors.all() {
+		if err := s.cursors.set(key, ""); err != nil {
+			s.logWarn("twitterscraper: cursor is not removed from store", "key", key, "error", err)
+		}
+	}
+}
+
+func cursorKey(kind, username string) string {
+	return kind + "/" + strings.ToLower(username)
+}
@@ -0,0 +1,105 @@
+package twitterscraper
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	reNumericID = regexp.MustCompile(`^[0-9]+$`)
+	reIDInURL   = regexp.MustCompile(`/(?:status|statuses|user|i/user)/([0-9]+)`)
+)
+
+// ReadTweets decodes tweets previously saved with encoding/json,
+// either as a JSON array or as newline delimited JSON.
+func ReadTweets(r io.Reader) ([]*Tweet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var tweets []*Tweet
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &tweets); err != nil {
+			return nil, err
+		}
+		return tweets, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var tweet Tweet
+		if err := decoder.Decode(&tweet); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		tweets = append(tweets, &tweet)
+	}
+	return tweets, nil
+}
+
+// ReadTweetsFile decodes tweets from JSON or newline delimited JSON file.
+func ReadTweetsFile(filename string) ([]*Tweet, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadTweets(f)
+}
+
+// ReadIDs reads one tweet or user ID per line, as a number or a link like
+// https://twitter.com/x/status/1234. Empty lines and lines starting with # are skipped,
+// duplicates are removed keeping the original order.
+func ReadIDs(r io.Reader) ([]string, error) {
+	var ids []string
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for lineNbr := 1; scanner.Scan(); lineNbr++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		id := line
+		if !reNumericID.MatchString(line) {
+			match := reIDInURL.FindStringSubmatch(line)
+			if match == nil {
+				return nil, fmt.Errorf("line %d: invalid ID %q", lineNbr, line)
+			}
+			id = match[1]
+		}
+
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, scanner.Err()
+}
+
+// ReadIDsFile reads tweet or user IDs from file, see ReadIDs.
+func ReadIDsFile(filename string) ([]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadIDs(f)
+}
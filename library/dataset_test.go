@@ -0,0 +1,32 @@
+package twitterscraper_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestReadIDs(t *testing.T) {
+	input := `# tweets from dataset
+1665602315745673217
+
+https://twitter.com/x/status/1554522888904101890
+https://x.com/x/status/1554522888904101890?s=20
+  1237110897597976576  
+`
+	ids, err := twitterscraper.ReadIDs(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"1665602315745673217", "1554522888904101890", "1237110897597976576"}
+	if diff := cmp.Diff(expected, ids); diff != "" {
+		t.Error("Resulting IDs does not match the sample", diff)
+	}
+
+	_, err = twitterscraper.ReadIDs(strings.NewReader("123\nnot an id\n"))
+	if err == nil || err.Error() != `line 2: invalid ID "not an id"` {
+		t.Errorf("Expected invalid ID error, got %v", err)
+	}
+}
@@ -0,0 +1,273 @@
+package twitterscraper
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// maximum inbox pages requested by GetDMInbox
+const maxDMInboxPages = 100
+
+type (
+	// DMConversation of direct messages of the logged in account.
+	DMConversation struct {
+		ID string
+		// Type is ONE_TO_ONE or GROUP_DM.
+		Type string
+		// Name of group, empty for one to one conversation.
+		Name         string
+		Participants []Profile
+		LastActivity time.Time
+	}
+
+	// DirectMessage of DMConversation.
+	DirectMessage struct {
+		ID             string
+		ConversationID string
+		SenderID       string
+		// RecipientID is empty in group conversation.
+		RecipientID string
+		Text        string
+		Time        time.Time
+		Photos      []Photo
+		Videos      []Video
+		GIFs        []GIF
+		URLs        []string
+	}
+
+	dmTimeline struct {
+		// Status is HAS_MORE or AT_END.
+		Status     string `json:"status"`
+		MinEntryID string `json:"min_entry_id"`
+		Entries    []struct {
+			Message *struct {
+				ID             string        `json:"id"`
+				Time           string        `json:"time"`
+				ConversationID string        `json:"conversation_id"`
+				MessageData    dmMessageData `json:"message_data"`
+			} `json:"message"`
+		} `json:"entries"`
+		Users         map[string]legacyUser     `json:"users"`
+		Conversations map[string]dmConversation `json:"conversations"`
+	}
+
+	dmConversation struct {
+		ConversationID string `json:"conversation_id"`
+		Type           string `json:"type"`
+		Name           string `json:"name"`
+		SortTimestamp  string `json:"sort_timestamp"`
+		Participants   []struct {
+			UserID string `json:"user_id"`
+		} `json:"participants"`
+	}
+
+	dmMessageData struct {
+		ID          string          `json:"id"`
+		SenderID    string          `json:"sender_id"`
+		RecipientID string          `json:"recipient_id"`
+		Text        string          `json:"text"`
+		Entities    json.RawMessage `json:"entities"`
+		Attachment  struct {
+			Photo       json.RawMessage `json:"photo"`
+			Video       json.RawMessage `json:"video"`
+			AnimatedGIF json.RawMessage `json:"animated_gif"`
+		} `json:"attachment"`
+	}
+)
+
+// GetDMInbox returns all conversations of direct messages of the logged in
+// account, the most recently active first.
+func (s *Scraper) GetDMInbox(ctx context.Context) ([]*DMConversation, error) {
+	var initial struct {
+		InboxInitialState struct {
+			dmTimeline
+			InboxTimelines struct {
+				Trusted struct {
+					Status     string `json:"status"`
+					MinEntryID string `json:"min_entry_id"`
+				} `json:"trusted"`
+			} `json:"inbox_timelines"`
+		} `json:"inbox_initial_state"`
+	}
+	if err := s.requestDM(ctx, "https://twitter.com/i/api/1.1/dm/inbox_initial_state.json", "", &initial); err != nil {
+		return nil, err
+	}
+
+	state := initial.InboxInitialState
+	users := state.Users
+	conversations := state.Conversations
+	status, cursor := state.InboxTimelines.Trusted.Status, state.InboxTimelines.Trusted.MinEntryID
+	for page := 0; status == "HAS_MORE" && cursor != "" && page < maxDMInboxPages; page++ {
+		var older struct {
+			InboxTimeline dmTimeline `json:"inbox_timeline"`
+		}
+		if err := s.requestDM(ctx, "https://twitter.com/i/api/1.1/dm/inbox_timeline/trusted.json", cursor, &older); err != nil {
+			return nil, err
+		}
+		timeline := older.InboxTimeline
+		if users == nil {
+			users = make(map[string]legacyUser)
+		}
+		for id, user := range timeline.Users {
+			users[id] = user
+		}
+		if conversations == nil {
+			conversations = make(map[string]dmConversation)
+		}
+		for id, conversation := range timeline.Conversations {
+			conversations[id] = conversation
+		}
+		if timeline.MinEntryID == cursor {
+			break
+		}
+		status, cursor = timeline.Status, timeline.MinEntryID
+	}
+
+	inbox := make([]*DMConversation, 0, len(conversations))
+	for _, conversation := range conversations {
+		inbox = append(inbox, conversation.parse(users))
+	}
+	sort.Slice(inbox, func(i, j int) bool {
+		if !inbox[i].LastActivity.Equal(inbox[j].LastActivity) {
+			return inbox[i].LastActivity.After(inbox[j].LastActivity)
+		}
+		return compareIDs(inbox[i].ID, inbox[j].ID) > 0
+	})
+	return inbox, nil
+}
+
+// GetDMConversation returns up to maxMessagesNbr direct messages of
+// conversation, the newest first.
+func (s *Scraper) GetDMConversation(ctx context.Context, conversationID string, maxMessagesNbr int) ([]*DirectMessage, error) {
+	var messages []*DirectMessage
+	var cursor string
+	for len(messages) < maxMessagesNbr {
+		page, next, err := s.FetchDMConversation(ctx, conversationID, cursor)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, page...)
+		if len(page) == 0 || next == "" || next == cursor {
+			break
+		}
+		cursor = next
+	}
+	if len(messages) > maxMessagesNbr {
+		messages = messages[:maxMessagesNbr]
+	}
+	return messages, nil
+}
+
+// FetchDMConversation gets page of direct messages of conversation, the
+// newest first, and cursor of older messages, empty at the beginning of
+// conversation.
+func (s *Scraper) FetchDMConversation(ctx context.Context, conversationID string, cursor string) ([]*DirectMessage, string, error) {
+	var response struct {
+		ConversationTimeline dmTimeline `json:"conversation_timeline"`
+	}
+	if err := s.requestDM(ctx, "https://twitter.com/i/api/1.1/dm/conversation/"+url.PathEscape(conversationID)+".json", cursor, &response); err != nil {
+		return nil, "", err
+	}
+
+	timeline := response.ConversationTimeline
+	var messages []*DirectMessage
+	for _, entry := range timeline.Entries {
+		if entry.Message == nil {
+			continue
+		}
+		message := entry.Message.MessageData.parse()
+		message.ConversationID = entry.Message.ConversationID
+		message.Time = parseMillis(entry.Message.Time)
+		messages = append(messages, message)
+	}
+	sort.SliceStable(messages, func(i, j int) bool { return compareIDs(messages[i].ID, messages[j].ID) > 0 })
+
+	var nextCursor string
+	if timeline.Status == "HAS_MORE" {
+		nextCursor = timeline.MinEntryID
+	}
+	return messages, nextCursor, nil
+}
+
+// requestDM requests DM endpoint, cursor is ID of entry older messages are
+// requested below.
+func (s *Scraper) requestDM(ctx context.Context, endpoint, cursor string, target interface{}) error {
+	req, err := s.newRequest(ctx, "GET", endpoint)
+	if err != nil {
+		return err
+	}
+	q := req.URL.Query()
+	q.Set("include_groups", "true")
+	q.Set("include_inbox_timelines", "true")
+	q.Set("include_ext_media_color", "true")
+	q.Set("supports_reactions", "true")
+	q.Set("dm_users", "true")
+	q.Set("tweet_mode", "extended")
+	if cursor != "" {
+		q.Set("max_id", cursor)
+		q.Set("context", "FETCH_DM_CONVERSATION_HISTORY")
+	}
+	req.URL.RawQuery = q.Encode()
+	return s.RequestAPI(req, target)
+}
+
+func (conversation *dmConversation) parse(users map[string]legacyUser) *DMConversation {
+	result := &DMConversation{
+		ID:           conversation.ConversationID,
+		Type:         conversation.Type,
+		Name:         conversation.Name,
+		LastActivity: parseMillis(conversation.SortTimestamp),
+	}
+	for _, participant := range conversation.Participants {
+		user, ok := users[participant.UserID]
+		if !ok {
+			user = legacyUser{IDStr: participant.UserID}
+		}
+		result.Participants = append(result.Participants, parseProfile(user))
+	}
+	return result
+}
+
+// parse extracts media and links of message the same way as of tweet.
+func (data *dmMessageData) parse() *DirectMessage {
+	message := &DirectMessage{
+		ID:          data.ID,
+		SenderID:    data.SenderID,
+		RecipientID: data.RecipientID,
+		Text:        data.Text,
+	}
+
+	tweet := legacyTweet{IDStr: data.ID}
+	if len(data.Entities) > 0 {
+		json.Unmarshal(data.Entities, &tweet.Entities)
+	}
+	for _, raw := range []json.RawMessage{data.Attachment.Photo, data.Attachment.Video, data.Attachment.AnimatedGIF} {
+		if len(raw) == 0 {
+			continue
+		}
+		media := tweet.ExtendedEntities.Media[:0:0]
+		if err := json.Unmarshal(append(append([]byte("["), raw...), ']'), &media); err == nil {
+			tweet.ExtendedEntities.Media = append(tweet.ExtendedEntities.Media, media...)
+		}
+	}
+	if tw := parseLegacyTweet(&legacyUser{}, &tweet); tw != nil {
+		message.Photos = tw.Photos
+		message.Videos = tw.Videos
+		message.GIFs = tw.GIFs
+		message.URLs = tw.URLs
+	}
+	return message
+}
+
+// parseMillis parses unix time in milliseconds, zero time if invalid.
+func parseMillis(value string) time.Time {
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || ms <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(ms/1000, ms%1000*int64(time.Millisecond))
+}
@@ -0,0 +1,43 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetDMConversation(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	inbox, err := testScraper.GetDMInbox(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inbox) == 0 {
+		t.Skip("Skipping test, account has no direct messages")
+	}
+	for i, conversation := range inbox {
+		if conversation.ID == "" || len(conversation.Participants) == 0 {
+			t.Errorf("Unexpected conversation %+v", conversation)
+		}
+		if i > 0 && conversation.LastActivity.After(inbox[i-1].LastActivity) {
+			t.Error("Expected conversations ordered by last activity")
+		}
+	}
+
+	messages, err := testScraper.GetDMConversation(context.Background(), inbox[0].ID, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) == 0 || len(messages) > 30 {
+		t.Fatalf("Expected 1 to 30 messages, got %d", len(messages))
+	}
+	for i, message := range messages {
+		if message.ConversationID != inbox[0].ID || message.SenderID == "" || message.Time.IsZero() {
+			t.Errorf("Unexpected message %+v", message)
+		}
+		if i > 0 && message.Time.After(messages[i-1].Time) {
+			t.Error("Expected messages from newest")
+		}
+	}
+}
@@ -0,0 +1,348 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// download statuses
+const (
+	downloaded downloadStatus = iota
+	skipped
+	resumed
+)
+
+// errMediaForbidden is returned for 403 response of media, usually expired URL.
+var errMediaForbidden = errors.New("response status 403 Forbidden")
+
+type (
+	// MediaDownloader downloads photos, videos and GIFs of tweets by own workers,
+	// so slow downloads don't hold timeline pagination. Downloads go through
+	// proxy bound to pool account which fetched tweet, or to the account used
+	// next, otherwise through transport of scraper sharing its proxy, but not
+	// its client timeout, as large videos take longer. Expired media URLs are
+	// refreshed by getting tweet again.
+	MediaDownloader struct {
+		scraper *Scraper
+		ctx     context.Context
+		dir     string
+		queue   chan mediaDownload
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		result  DownloadResult
+		files   []MediaFile
+		// metadata is embedded into downloaded files
+		metadata bool
+		// known files of manifest, read only
+		known map[string]MediaFile
+	}
+
+	// DownloadResult of MediaDownloader.
+	DownloadResult struct {
+		Downloaded int
+		// Skipped files already exist in directory and match manifest.
+		Skipped int
+		// Resumed downloads continued partial file of interrupted one, they
+		// are counted in Downloaded too.
+		Resumed int
+		Failed  int
+		// Errors by media URL.
+		Errors map[string]error
+	}
+
+	downloadStatus int
+
+	mediaDownload struct {
+		tweetID  string
+		mediaID  string
+		url      string
+		filename string
+		runID    string
+		// transport bound to pool account, nil for transport of scraper
+		transport http.RoundTripper
+		// metadata embedded into file, if enabled
+		metadata *mediaMetadata
+	}
+)
+
+// NewMediaDownloader starts workers downloading media to dir, queueSize tweets
+// media can wait in queue before WriteTweet blocks. Files are named
+// <tweet ID>_<media ID> with extension of media URL. Downloader is a Sink, so
+// it can be passed to Session.Run next to other sinks, Close must be called
+// after the last tweet.
+func (s *Scraper) NewMediaDownloader(ctx context.Context, dir string, workers, queueSize int) *MediaDownloader {
+	if workers < 1 {
+		workers = 1
+	}
+	d := &MediaDownloader{
+		scraper: s,
+		ctx:     ctx,
+		dir:     dir,
+		queue:   make(chan mediaDownload, queueSize),
+		known:   make(map[string]MediaFile),
+		result:  DownloadResult{Errors: make(map[string]error)},
+	}
+	// unreadable manifest only means files are not checked before skipping
+	if manifest, err := ReadMediaManifest(dir); err == nil {
+		for _, file := range manifest.Files {
+			d.known[file.Name] = file
+		}
+	}
+	d.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go d.work()
+	}
+	return d
+}
+
+// WithMetadata embeds author, tweet URL, date and text excerpt as XMP into
+// downloaded JPEG and PNG images and MP4 videos, so files stay self-describing
+// outside of manifest. It must be set before the first WriteTweet.
+func (d *MediaDownloader) WithMetadata(b bool) *MediaDownloader {
+	d.metadata = b
+	return d
+}
+
+// WriteTweet queues media of tweet.
+func (d *MediaDownloader) WriteTweet(tweet *Tweet) error {
+	for _, photo := range tweet.Photos {
+		if err := d.enqueue(tweet, photo.ID, photo.URL); err != nil {
+			return err
+		}
+	}
+	for _, video := range tweet.Videos {
+		if err := d.enqueue(tweet, video.ID, video.URL); err != nil {
+			return err
+		}
+	}
+	for _, gif := range tweet.GIFs {
+		if err := d.enqueue(tweet, gif.ID, gif.URL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close waits for queued downloads, adds downloaded files to manifest of
+// directory and returns result. Manifest is locked while it's updated,
+// LockError is returned if another run is updating it.
+func (d *MediaDownloader) Close() (DownloadResult, error) {
+	close(d.queue)
+	d.wg.Wait()
+	if len(d.files) == 0 {
+		return d.result, nil
+	}
+
+	lock, err := LockFile(filepath.Join(d.dir, MediaManifestFile))
+	if err != nil {
+		return d.result, err
+	}
+	defer lock.Unlock()
+
+	manifest, err := ReadMediaManifest(d.dir)
+	if err != nil {
+		return d.result, err
+	}
+	manifest.add(d.files)
+	return d.result, manifest.write(d.dir)
+}
+
+func (d *MediaDownloader) enqueue(tweet *Tweet, mediaID, mediaURL string) error {
+	if mediaURL == "" {
+		return nil
+	}
+	u, err := url.Parse(mediaURL)
+	if err != nil {
+		return err
+	}
+	download := mediaDownload{
+		tweetID:  tweet.ID,
+		mediaID:  mediaID,
+		url:      mediaURL,
+		filename: filepath.Join(d.dir, tweet.ID+"_"+mediaID+path.Ext(u.Path)),
+		runID:    d.scraper.runID,
+	}
+	var account string
+	if tweet.Provenance != nil {
+		account = tweet.Provenance.Account
+	}
+	download.transport = d.scraper.pool.transport(account)
+	if d.metadata {
+		download.metadata = newMediaMetadata(tweet)
+	}
+
+	select {
+	case d.queue <- download:
+		return nil
+	case <-d.ctx.Done():
+		return d.ctx.Err()
+	}
+}
+
+func (d *MediaDownloader) work() {
+	defer d.wg.Done()
+	for download := range d.queue {
+		if d.ctx.Err() != nil {
+			d.report(download, nil, 0, d.ctx.Err())
+			continue
+		}
+		if _, err := os.Stat(download.filename); err == nil {
+			file, err := newMediaFile(download, download.url)
+			if err != nil || d.intact(file) {
+				d.report(download, file, skipped, err)
+				continue
+			}
+			// changed since it was downloaded
+			if err := os.Remove(download.filename); err != nil {
+				d.report(download, nil, 0, err)
+				continue
+			}
+		}
+
+		mediaURL := download.url
+		status, err := d.download(download, mediaURL)
+		if errors.Is(err, errMediaForbidden) {
+			// signed video URLs expire, tweet has fresh ones
+			if mediaURL, err = d.refreshURL(download); err == nil {
+				status, err = d.download(download, mediaURL)
+			}
+		}
+		var file *MediaFile
+		if err == nil {
+			file, err = newMediaFile(download, mediaURL)
+		}
+		d.report(download, file, status, err)
+	}
+}
+
+// refreshURL gets tweet again and returns current URL of media.
+func (d *MediaDownloader) refreshURL(download mediaDownload) (string, error) {
+	tweet, err := d.scraper.GetTweet(d.ctx, download.tweetID)
+	if err != nil {
+		return "", fmt.Errorf("error refreshing expired URL: %w", err)
+	}
+	for _, photo := range tweet.Photos {
+		if photo.ID == download.mediaID {
+			return photo.URL, nil
+		}
+	}
+	for _, video := range tweet.Videos {
+		if video.ID == download.mediaID {
+			return video.URL, nil
+		}
+	}
+	for _, gif := range tweet.GIFs {
+		if gif.ID == download.mediaID {
+			return gif.URL, nil
+		}
+	}
+	return "", fmt.Errorf("error refreshing expired URL: media %s not found in tweet %s", download.mediaID, download.tweetID)
+}
+
+// intact tells if file has size and checksum of manifest, files downloaded
+// before manifest are trusted by name.
+func (d *MediaDownloader) intact(file *MediaFile) bool {
+	known, ok := d.known[file.Name]
+	return !ok || (known.Size == file.Size && known.SHA256 == file.SHA256)
+}
+
+// download writes media to .part file and renames it to filename when
+// complete. Partial file of interrupted download is continued with Range
+// request, unless server sends the whole file again.
+func (d *MediaDownloader) download(download mediaDownload, mediaURL string) (downloadStatus, error) {
+	tmp := download.filename + ".part"
+	var offset int64
+	if info, err := os.Stat(tmp); err == nil {
+		offset = info.Size()
+	}
+
+	req, err := http.NewRequestWithContext(d.ctx, "GET", mediaURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", d.scraper.userAgent)
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	client := &http.Client{Transport: d.scraper.client.Transport}
+	if download.transport != nil {
+		client.Transport = download.transport
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	status := downloaded
+	flag := os.O_TRUNC
+	switch {
+	case resp.StatusCode == http.StatusForbidden:
+		return 0, errMediaForbidden
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		// partial file is already complete
+		return resumed, d.complete(download, tmp)
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			os.Remove(tmp)
+			return 0, fmt.Errorf("unexpected content range %q", resp.Header.Get("Content-Range"))
+		}
+		status, flag = resumed, os.O_APPEND
+	case resp.StatusCode != http.StatusOK:
+		return 0, fmt.Errorf("response status %s", resp.Status)
+	}
+
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|flag, 0644)
+	if err != nil {
+		return 0, err
+	}
+	// partial file is kept for resume
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		return 0, err
+	}
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+	return status, d.complete(download, tmp)
+}
+
+// complete embeds metadata into downloaded file and gives it final name.
+func (d *MediaDownloader) complete(download mediaDownload, tmp string) error {
+	if download.metadata != nil {
+		if err := embedMetadata(tmp, download.metadata); err != nil {
+			return fmt.Errorf("error embedding metadata: %w", err)
+		}
+	}
+	return os.Rename(tmp, download.filename)
+}
+
+func (d *MediaDownloader) report(download mediaDownload, file *MediaFile, status downloadStatus, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if file != nil {
+		d.files = append(d.files, *file)
+	}
+	switch {
+	case err != nil:
+		d.result.Failed++
+		d.result.Errors[download.url] = err
+	case status == skipped:
+		d.result.Skipped++
+	case status == resumed:
+		d.result.Resumed++
+		d.result.Downloaded++
+	default:
+		d.result.Downloaded++
+	}
+}
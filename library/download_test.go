@@ -0,0 +1,241 @@
+package twitterscraper_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestMediaDownloader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.jpg" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "1_existing.jpg"), []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	downloader := twitterscraper.New().NewMediaDownloader(context.Background(), dir, 2, 1)
+	tweets := []*twitterscraper.Tweet{
+		{
+			ID:     "1",
+			Photos: []twitterscraper.Photo{{ID: "photo", URL: server.URL + "/photo.jpg"}, {ID: "existing", URL: server.URL + "/existing.jpg"}},
+			Videos: []twitterscraper.Video{{ID: "video", URL: server.URL + "/video.mp4?tag=12"}},
+		},
+		{
+			ID:     "2",
+			Photos: []twitterscraper.Photo{{ID: "missing", URL: server.URL + "/missing.jpg"}},
+			GIFs:   []twitterscraper.GIF{{ID: "gif", URL: server.URL + "/gif.mp4"}},
+		},
+	}
+	for _, tweet := range tweets {
+		if err := downloader.WriteTweet(tweet); err != nil {
+			t.Fatal(err)
+		}
+	}
+	result, err := downloader.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Downloaded != 3 || result.Skipped != 1 || result.Failed != 1 {
+		t.Errorf("Expected 3 downloaded, 1 skipped and 1 failed, got %+v", result)
+	}
+	if result.Errors[server.URL+"/missing.jpg"] == nil {
+		t.Errorf("Expected error of missing media, got %v", result.Errors)
+	}
+	for filename, content := range map[string]string{
+		"1_photo.jpg":    "/photo.jpg",
+		"1_existing.jpg": "old",
+		"1_video.mp4":    "/video.mp4",
+		"2_gif.mp4":      "/gif.mp4",
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, filename))
+		if err != nil || string(data) != content {
+			t.Errorf("Expected %s with %q, got %q %v", filename, content, data, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "2_missing.jpg")); err == nil {
+		t.Error("Expected no file for failed download")
+	}
+
+	manifest, err := twitterscraper.ReadMediaManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Files) != 4 || manifest.Files[0].Name != "1_existing.jpg" || manifest.Files[0].Size != 3 {
+		t.Errorf("Expected 4 files in manifest from 1_existing.jpg, got %+v", manifest.Files)
+	}
+	if issues, err := twitterscraper.VerifyMedia(dir); err != nil || len(issues) != 0 {
+		t.Errorf("Expected valid media, got %v %v", issues, err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "1_photo.jpg"), []byte("/photo.png"), 0644)
+	os.Remove(filepath.Join(dir, "2_gif.mp4"))
+	issues, err := twitterscraper.VerifyMedia(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[0].Name != "1_photo.jpg" || !os.IsNotExist(issues[1].Err) {
+		t.Errorf("Expected changed 1_photo.jpg and missing 2_gif.mp4, got %v", issues)
+	}
+}
+
+func TestMediaDownloaderResume(t *testing.T) {
+	content := strings.Repeat("video", 100)
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "", time.Time{}, strings.NewReader(content))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	tweet := &twitterscraper.Tweet{
+		ID:     "1",
+		Photos: []twitterscraper.Photo{{ID: "photo", URL: server.URL + "/photo.jpg"}},
+		Videos: []twitterscraper.Video{{ID: "video", URL: server.URL + "/video.mp4"}},
+	}
+	downloader := twitterscraper.New().NewMediaDownloader(context.Background(), dir, 1, 2)
+	if err := downloader.WriteTweet(tweet); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := downloader.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// interrupted download of video and changed photo
+	os.Rename(filepath.Join(dir, "1_video.mp4"), filepath.Join(dir, "1_video.mp4.part"))
+	os.Truncate(filepath.Join(dir, "1_video.mp4.part"), 200)
+	os.WriteFile(filepath.Join(dir, "1_photo.jpg"), []byte("changed"), 0644)
+	ranges = nil
+
+	downloader = twitterscraper.New().NewMediaDownloader(context.Background(), dir, 1, 2)
+	if err := downloader.WriteTweet(tweet); err != nil {
+		t.Fatal(err)
+	}
+	result, err := downloader.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Downloaded != 2 || result.Resumed != 1 || result.Skipped != 0 {
+		t.Errorf("Expected changed photo downloaded again and video resumed, got %+v", result)
+	}
+	if len(ranges) != 2 || ranges[0] != "" || ranges[1] != "bytes=200-" {
+		t.Errorf("Expected range request for video only, got %q", ranges)
+	}
+	for _, filename := range []string{"1_photo.jpg", "1_video.mp4"} {
+		if data, _ := os.ReadFile(filepath.Join(dir, filename)); string(data) != content {
+			t.Errorf("Expected full content of %s, got %d bytes", filename, len(data))
+		}
+	}
+}
+
+func TestMediaDownloaderMetadata(t *testing.T) {
+	var jpegData, pngData bytes.Buffer
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	jpeg.Encode(&jpegData, img, nil)
+	png.Encode(&pngData, img)
+	mp4Data := "\x00\x00\x00\x10ftypisom\x00\x00\x00\x00"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/photo.jpg":
+			w.Write(jpegData.Bytes())
+		case "/photo.png":
+			w.Write(pngData.Bytes())
+		default:
+			w.Write([]byte(mp4Data))
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	downloader := twitterscraper.New().NewMediaDownloader(context.Background(), dir, 2, 3).WithMetadata(true)
+	err := downloader.WriteTweet(&twitterscraper.Tweet{
+		ID:           "1",
+		Name:         "Tom & Jerry",
+		Username:     "tom",
+		PermanentURL: "https://twitter.com/tom/status/1",
+		Text:         "<cheese>",
+		Timestamp:    1700000000,
+		Photos:       []twitterscraper.Photo{{ID: "jpg", URL: server.URL + "/photo.jpg"}, {ID: "png", URL: server.URL + "/photo.png"}},
+		Videos:       []twitterscraper.Video{{ID: "video", URL: server.URL + "/video.mp4"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := downloader.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, filename := range []string{"1_jpg.jpg", "1_png.png", "1_video.mp4"} {
+		data, err := os.ReadFile(filepath.Join(dir, filename))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{"Tom &amp; Jerry (@tom)", "&lt;cheese&gt;", "https://twitter.com/tom/status/1", "2023-11-14T22:13:20Z"} {
+			if !bytes.Contains(data, []byte(expected)) {
+				t.Errorf("Expected %s to contain %q", filename, expected)
+			}
+		}
+		if filename != "1_video.mp4" {
+			if _, _, err := image.Decode(bytes.NewReader(data)); err != nil {
+				t.Errorf("Expected valid image %s, got %v", filename, err)
+			}
+		}
+	}
+	// manifest has checksums of files with metadata
+	if issues, err := twitterscraper.VerifyMedia(dir); err != nil || len(issues) != 0 {
+		t.Errorf("Expected valid media, got %v %v", issues, err)
+	}
+}
+
+func TestMediaDownloaderAccountProxy(t *testing.T) {
+	proxied := 0
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied++
+		w.Write([]byte(r.URL.Host))
+	}))
+	defer proxy.Close()
+
+	account := twitterscraper.AuthToken{Token: "token", CSRFToken: "csrf"}
+	scraper := twitterscraper.New().AddAccount(account)
+	if err := scraper.BindAccountToProxy(account, proxy.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	// media host doesn't resolve, so only bound proxy can serve it
+	downloader := scraper.NewMediaDownloader(context.Background(), t.TempDir(), 1, 1)
+	tweet := &twitterscraper.Tweet{
+		ID:         "1",
+		Photos:     []twitterscraper.Photo{{ID: "photo", URL: "http://media.invalid/photo.jpg"}},
+		Provenance: &twitterscraper.Provenance{Account: "account-1"},
+	}
+	if err := downloader.WriteTweet(tweet); err != nil {
+		t.Fatal(err)
+	}
+	result, err := downloader.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Downloaded != 1 || proxied != 1 {
+		t.Errorf("Expected download through proxy of account, got %+v", result)
+	}
+}
@@ -0,0 +1,161 @@
+package twitterscraper
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DuplicateGroup is a group of identical or near-identical tweets posted by different users.
+type DuplicateGroup struct {
+	Tweets []*Tweet
+	// Users who posted tweets of the group, sorted.
+	Users []string
+	// Exact is true if texts of all tweets are the same
+	// ignoring case, punctuation, links and mentions.
+	Exact bool
+}
+
+// FindDuplicates groups tweets with the same or similar texts posted by at least two
+// different users. Similarity is measured as hamming distance between 64-bit simhashes
+// of texts, maxDistance 0 finds only exact copies, around 10 finds near-duplicates of short texts.
+// Retweets are skipped. Every tweet is compared with every other, so it's intended
+// for already scraped datasets of moderate size.
+func FindDuplicates(tweets []*Tweet, maxDistance int) []DuplicateGroup {
+	var (
+		candidates []*Tweet
+		texts      []string
+		hashes     []uint64
+	)
+	for _, tweet := range tweets {
+		if tweet == nil || tweet.IsRetweet {
+			continue
+		}
+		words := duplicateWords(tweet.Text)
+		if len(words) == 0 {
+			continue
+		}
+		candidates = append(candidates, tweet)
+		texts = append(texts, strings.Join(words, " "))
+		hashes = append(hashes, simhash(words))
+	}
+
+	parent := make([]int, len(candidates))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := range candidates {
+		for j := i + 1; j < len(candidates); j++ {
+			if texts[i] == texts[j] || bits.OnesCount64(hashes[i]^hashes[j]) <= maxDistance {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	var roots []int
+	for i := range candidates {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], i)
+	}
+
+	groups := []DuplicateGroup{}
+	for _, root := range roots {
+		users := make(map[string]bool)
+		group := DuplicateGroup{Exact: true}
+		for _, i := range members[root] {
+			group.Tweets = append(group.Tweets, candidates[i])
+			if !users[candidates[i].Username] {
+				users[candidates[i].Username] = true
+				group.Users = append(group.Users, candidates[i].Username)
+			}
+			if texts[i] != texts[root] {
+				group.Exact = false
+			}
+		}
+		if len(group.Users) < 2 {
+			continue
+		}
+		sort.Strings(group.Users)
+		groups = append(groups, group)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i].Users) > len(groups[j].Users)
+	})
+
+	return groups
+}
+
+// duplicateWords normalizes text so copies with changed links, mentions or punctuation are equal.
+func duplicateWords(text string) []string {
+	var words []string
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		if strings.HasPrefix(word, "http://") || strings.HasPrefix(word, "https://") || strings.HasPrefix(word, "@") {
+			continue
+		}
+		word = strings.TrimFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// simhash of word 3-shingles, or of single words for shorter texts.
+func simhash(words []string) uint64 {
+	shingles := words
+	if len(words) >= 3 {
+		shingles = make([]string, 0, len(words)-2)
+		for i := 0; i+3 <= len(words); i++ {
+			shingles = append(shingles, strings.Join(words[i:i+3], " "))
+		}
+	}
+
+	var weights [64]int
+	for _, shingle := range shingles {
+		h := fnv.New64a()
+		h.Write([]byte(shingle))
+		sum := mix64(h.Sum64())
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<uint(bit)) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var hash uint64
+	for bit := 0; bit < 64; bit++ {
+		if weights[bit] > 0 {
+			hash |= 1 << uint(bit)
+		}
+	}
+	return hash
+}
+
+// mix64 is splitmix64 finalizer, fnv alone is poorly distributed for short similar strings.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
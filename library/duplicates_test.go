@@ -0,0 +1,29 @@
+package twitterscraper_test
+
+import (
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	copypasta := "I have been using this product for a month and it completely changed my life, highly recommend to everyone"
+	tweets := []*twitterscraper.Tweet{
+		{ID: "1", Username: "bot1", Text: copypasta + " https://t.co/a"},
+		{ID: "2", Username: "bot2", Text: "@someone " + copypasta + "!!! https://t.co/b"},
+		{ID: "3", Username: "bot3", Text: copypasta + " so much"},
+		{ID: "4", Username: "bot1", Text: "Completely unrelated tweet about weather today"},
+		{ID: "5", Username: "bot1", Text: "Completely unrelated tweet about weather today"},
+		{ID: "6", Username: "user", Text: copypasta, IsRetweet: true},
+	}
+
+	exact := twitterscraper.FindDuplicates(tweets, 0)
+	if len(exact) != 1 || !exact[0].Exact || len(exact[0].Tweets) != 2 {
+		t.Fatalf("Expected one exact group of 2 tweets, got %+v", exact)
+	}
+
+	similar := twitterscraper.FindDuplicates(tweets, 10)
+	if len(similar) != 1 || similar[0].Exact || len(similar[0].Users) != 3 {
+		t.Fatalf("Expected one near-duplicate group of 3 users, got %+v", similar)
+	}
+}
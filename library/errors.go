@@ -0,0 +1,188 @@
+package twitterscraper
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrProtected is returned when timeline of protected account is requested,
+// but the scraper is not logged in with an account that follows it.
+var ErrProtected = errors.New("account is protected and not followed")
+
+// ErrAgeRestricted is returned when tweet is hidden as adult content,
+// it can be viewed only when logged in with an age-verified account.
+var ErrAgeRestricted = errors.New("tweet is age-restricted")
+
+// ErrUserNotFound is returned when requested user doesn't exist.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrUserSuspended is returned when requested user is suspended.
+var ErrUserSuspended = errors.New("user is suspended")
+
+// ErrTweetNotFound is returned when requested tweet doesn't exist or was deleted.
+var ErrTweetNotFound = errors.New("tweet not found")
+
+// ErrAuthExpired matches APIError with 401 status or error code 32 or 89,
+// when cookies or tokens are invalid or expired.
+var ErrAuthExpired = errors.New("authentication expired")
+
+// ErrRateLimited matches APIError with 429 status or error code 88.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// ErrAccountLocked matches APIError with error code 326.
+var ErrAccountLocked = errors.New("account is temporarily locked")
+
+// ErrAccountSuspended matches APIError with error code 64.
+var ErrAccountSuspended = errors.New("account is suspended")
+
+// API error codes returned in errors array of response
+const (
+	errCodeAuth         = 32
+	errCodeSuspended    = 64
+	errCodeRateLimited  = 88
+	errCodeInvalidToken = 89
+	errCodeLocked       = 326
+)
+
+// APIError is returned when API responds with non 200 status,
+// or with 200 status and errors array with one of known error codes.
+// Use errors.Is with ErrRateLimited, ErrAccountLocked or ErrAccountSuspended to check the reason.
+type APIError struct {
+	// RequestID identifies request in request hooks, HAR and provenance.
+	RequestID  string
+	StatusCode int
+	Status     string
+	// Code from errors array of response, if any.
+	Code   int
+	Header http.Header
+	Body   []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("response status %s: %s", e.Status, e.Body)
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests || e.Code == errCodeRateLimited
+	case ErrAccountLocked:
+		return e.Code == errCodeLocked
+	case ErrAccountSuspended:
+		return e.Code == errCodeSuspended
+	case ErrAuthExpired:
+		return e.StatusCode == http.StatusUnauthorized || e.Code == errCodeAuth || e.Code == errCodeInvalidToken
+	}
+	return false
+}
+
+// ResetAt returns time when rate limit resets from X-Rate-Limit-Reset header,
+// zero time if header is missing.
+func (e *APIError) ResetAt() time.Time {
+	if e.Header.Get("X-Rate-Limit-Reset") == "" {
+		return time.Time{}
+	}
+	return rateLimitReset(e.Header)
+}
+
+// responseErrorCode returns error code from errors array of response body.
+// Codes of rate limit and account errors take precedence over other codes.
+func responseErrorCode(body []byte) int {
+	if !bytes.Contains(body, []byte(`"errors"`)) {
+		return 0
+	}
+
+	var response struct {
+		Errors []struct {
+			Code int `json:"code"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(body, &response) != nil {
+		return 0
+	}
+
+	code := 0
+	for _, e := range response.Errors {
+		if isAccountErrorCode(e.Code) {
+			return e.Code
+		}
+		if code == 0 {
+			code = e.Code
+		}
+	}
+	return code
+}
+
+// isAccountErrorCode reports if code means the whole request failed,
+// not a single item in response.
+func isAccountErrorCode(code int) bool {
+	return code == errCodeSuspended || code == errCodeRateLimited || code == errCodeLocked
+}
+
+// RequestError is returned when request failed without response, e.g. on timeout.
+type RequestError struct {
+	// RequestID identifies request in request hooks and HAR.
+	RequestID string
+	Err       error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("request %s: %v", e.RequestID, e.Err)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// newRequestID returns random ID of API request.
+func newRequestID() string {
+	id := make([]byte, 8)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// checkProtected replaces 403 error or empty first page of user timeline
+// with ErrProtected, if the user is protected and not followed.
+func (s *Scraper) checkProtected(ctx context.Context, userID string, err error) error {
+	var apiErr *APIError
+	if err != nil && !(errors.As(err, &apiErr) && apiErr.StatusCode == 403) {
+		return err
+	}
+
+	profile, profileErr := s.GetProfileByID(ctx, userID)
+	if profileErr == nil && profile.IsPrivate && !profile.Following {
+		return ErrProtected
+	}
+	return err
+}
+
+// IsRetryable reports if request failed with error that may pass on retry:
+// rate limit, 5xx status, network failure or empty response. Not found,
+// suspended, protected, expired authentication, parse errors and cancelled
+// context are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if isRateLimit(err) || errors.Is(err, ErrEmptyResponse) {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	var requestErr *RequestError
+	return errors.As(err, &requestErr)
+}
+
+func isRateLimit(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
@@ -0,0 +1,120 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors":[{"code":200,"message":"Forbidden."}]}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	scraper := twitterscraper.New()
+	scraper.WithOpenAccount(twitterscraper.OpenAccount{OAuthToken: "token", OAuthTokenSecret: "secret"})
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = scraper.RequestAPI(req, nil)
+
+	var apiErr *twitterscraper.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d", apiErr.StatusCode)
+	}
+	expected := "response status 403 Forbidden: {\"errors\":[{\"code\":200,\"message\":\"Forbidden.\"}]}\n"
+	if err.Error() != expected {
+		t.Errorf("Expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func TestErrorsArrayWithOKStatus(t *testing.T) {
+	code := 88
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"errors":[{"code":%d,"message":"Error."}]}`, code)
+	}))
+	defer server.Close()
+
+	for _, test := range []struct {
+		code    int
+		target  error
+		healthy bool
+	}{
+		{88, twitterscraper.ErrRateLimited, true},
+		{326, twitterscraper.ErrAccountLocked, false},
+		{64, twitterscraper.ErrAccountSuspended, false},
+	} {
+		code = test.code
+		scraper := twitterscraper.New()
+		scraper.AddAccount(twitterscraper.AuthToken{Token: "token", CSRFToken: "csrf"})
+
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		var target map[string]interface{}
+		err := scraper.RequestAPI(req, &target)
+		if !errors.Is(err, test.target) {
+			t.Errorf("Expected %v for code %d, got %v", test.target, test.code, err)
+		}
+		if account := scraper.Accounts()[0]; account.Healthy != test.healthy {
+			t.Errorf("Expected healthy %v for code %d, got %+v", test.healthy, test.code, account)
+		}
+	}
+
+	// errors of single items don't fail request
+	code = 144
+	scraper := twitterscraper.New()
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "token", CSRFToken: "csrf"})
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	if err := scraper.RequestAPI(req, nil); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+	rateLimited := &twitterscraper.APIError{StatusCode: 429, Header: http.Header{"X-Rate-Limit-Reset": {strconv.FormatInt(reset.Unix(), 10)}}}
+	if !rateLimited.ResetAt().Equal(reset) {
+		t.Errorf("Expected reset at %v, got %v", reset, rateLimited.ResetAt())
+	}
+
+	for _, test := range []struct {
+		err       error
+		retryable bool
+	}{
+		{nil, false},
+		{rateLimited, true},
+		{&twitterscraper.APIError{StatusCode: 200, Code: 88}, true},
+		{&twitterscraper.APIError{StatusCode: 503}, true},
+		{&twitterscraper.RequestError{Err: errors.New("connection reset")}, true},
+		{&twitterscraper.RequestError{Err: context.Canceled}, false},
+		{&twitterscraper.APIError{StatusCode: 401}, false},
+		{&twitterscraper.APIError{StatusCode: 404}, false},
+		{twitterscraper.ErrTweetNotFound, false},
+		{twitterscraper.ErrUserSuspended, false},
+		{twitterscraper.ErrProtected, false},
+		{&twitterscraper.ParseError{Type: "tweet", Field: "ID"}, false},
+	} {
+		if twitterscraper.IsRetryable(test.err) != test.retryable {
+			t.Errorf("Expected IsRetryable(%v) = %v", test.err, test.retryable)
+		}
+	}
+
+	if !errors.Is(&twitterscraper.APIError{StatusCode: 401}, twitterscraper.ErrAuthExpired) {
+		t.Error("Expected 401 to match ErrAuthExpired")
+	}
+	if !errors.Is(&twitterscraper.APIError{StatusCode: 403, Code: 89}, twitterscraper.ErrAuthExpired) {
+		t.Error("Expected code 89 to match ErrAuthExpired")
+	}
+}
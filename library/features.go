@@ -0,0 +1,45 @@
+package twitterscraper
+
+// SetFeature overrides GraphQL feature flag sent with every request,
+// for example when Twitter adds a new required flag. Nil value removes the flag.
+func (s *Scraper) SetFeature(name string, value interface{}) *Scraper {
+	if s.features == nil {
+		s.features = make(map[string]interface{})
+	}
+	s.features[name] = value
+	return s
+}
+
+// WithFeatures overrides several GraphQL feature flags, see SetFeature.
+func (s *Scraper) WithFeatures(features map[string]interface{}) *Scraper {
+	for name, value := range features {
+		s.SetFeature(name, value)
+	}
+	return s
+}
+
+// withFeatures returns default features of request with scraper overrides.
+func (s *Scraper) withFeatures(defaults map[string]interface{}) map[string]interface{} {
+	if len(s.features) == 0 {
+		return defaults
+	}
+	return mergeFeatures(defaults, s.features)
+}
+
+// mergeFeatures returns copy of base with overrides applied, nil values are removed.
+func mergeFeatures(base map[string]interface{}, overrides map[string]interface{}) map[string]interface{} {
+	features := make(map[string]interface{}, len(base)+len(overrides))
+	for name, value := range base {
+		if value != nil {
+			features[name] = value
+		}
+	}
+	for name, value := range overrides {
+		if value == nil {
+			delete(features, name)
+		} else {
+			features[name] = value
+		}
+	}
+	return features
+}
@@ -0,0 +1,56 @@
+package twitterscraper_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+func TestSetFeature(t *testing.T) {
+	scraper := newTestScraper(true)
+	scraper.WithHAR(0)
+	scraper.SetFeature("scraper_test_enabled", true)
+	scraper.SetFeature("verified_phone_label_enabled", nil)
+
+	if _, err := scraper.GetProfile(context.Background(), "nomadic_ua"); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := scraper.WriteHAR(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var har struct {
+		Log struct {
+			Entries []struct {
+				Request struct {
+					URL string `json:"url"`
+				} `json:"request"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &har); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entry := range har.Log.Entries {
+		u, err := url.Parse(entry.Request.URL)
+		if err != nil || u.Query().Get("features") == "" {
+			continue
+		}
+		var features map[string]interface{}
+		if err := json.Unmarshal([]byte(u.Query().Get("features")), &features); err != nil {
+			t.Fatal(err)
+		}
+		if features["scraper_test_enabled"] != true {
+			t.Error("Expected overridden feature in request")
+		}
+		if _, ok := features["verified_phone_label_enabled"]; ok {
+			t.Error("Expected removed feature not in request")
+		}
+		return
+	}
+	t.Error("Expected request with features")
+}
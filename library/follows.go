@@ -1,27 +1,40 @@
 package twitterscraper
 
 import (
+	"context"
 	"net/url"
 	"strings"
 )
 
+// GetFollowing returns channel with profiles followed by user. Scrape stopped
+// before the end of list resumes from the last page on the next call, also
+// after LoadSession of saved session, ClearCursors starts it over.
+func (s *Scraper) GetFollowing(ctx context.Context, user string, maxUsersNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, user, maxUsersNbr, s.trackProfiles(cursorKey("following", user), s.FetchFollowing))
+}
+
+// GetFollowers returns channel with followers of user, resumes as GetFollowing.
+func (s *Scraper) GetFollowers(ctx context.Context, user string, maxUsersNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, user, maxUsersNbr, s.trackProfiles(cursorKey("followers", user), s.FetchFollowers))
+}
+
 // FetchFollowing gets following profiles list for a given user, via the Twitter frontend GraphQL API.
-func (s *Scraper) FetchFollowing(user string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
-	userID, err := s.GetUserIDByScreenName(user)
+func (s *Scraper) FetchFollowing(ctx context.Context, user string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
+	userID, err := s.GetUserIDByScreenName(ctx, user)
 	if err != nil {
 		return nil, "", err
 	}
 
-	return s.FetchFollowingByUserID(userID, maxUsersNbr, cursor)
+	return s.FetchFollowingByUserID(ctx, userID, maxUsersNbr, cursor)
 }
 
 // FetchFollowingByUserID gets following profiles list for a given userID, via the Twitter frontend GraphQL API.
-func (s *Scraper) FetchFollowingByUserID(userID string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
+func (s *Scraper) FetchFollowingByUserID(ctx context.Context, userID string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
 	if maxUsersNbr > 200 {
 		maxUsersNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/g5P4cbXR4ta4oCeE7y2vLQ/Following")
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/g5P4cbXR4ta4oCeE7y2vLQ/Following")
 	if err != nil {
 		return nil, "", err
 	}
@@ -60,7 +73,7 @@ func (s *Scraper) FetchFollowingByUserID(userID string, maxUsersNbr int, cursor
 
 	query := url.Values{}
 	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
 	req.URL.RawQuery = query.Encode()
 
 	var timeline timelineV2
@@ -70,6 +83,9 @@ func (s *Scraper) FetchFollowingByUserID(userID string, maxUsersNbr int, cursor
 	}
 
 	users, nextCursor := timeline.parseUsers()
+	if users, err = s.filterProfiles(users); err != nil {
+		return nil, "", err
+	}
 
 	if strings.HasPrefix(nextCursor, "0|") {
 		nextCursor = ""
@@ -79,22 +95,22 @@ func (s *Scraper) FetchFollowingByUserID(userID string, maxUsersNbr int, cursor
 }
 
 // FetchFollowers gets following profiles list for a given user, via the Twitter frontend GraphQL API.
-func (s *Scraper) FetchFollowers(user string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
-	userID, err := s.GetUserIDByScreenName(user)
+func (s *Scraper) FetchFollowers(ctx context.Context, user string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
+	userID, err := s.GetUserIDByScreenName(ctx, user)
 	if err != nil {
 		return nil, "", err
 	}
 
-	return s.FetchFollowersByUserID(userID, maxUsersNbr, cursor)
+	return s.FetchFollowersByUserID(ctx, userID, maxUsersNbr, cursor)
 }
 
 // FetchFollowersByUserID gets followers profiles list for a given userID, via the Twitter frontend GraphQL API.
-func (s *Scraper) FetchFollowersByUserID(userID string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
+func (s *Scraper) FetchFollowersByUserID(ctx context.Context, userID string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
 	if maxUsersNbr > 200 {
 		maxUsersNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/jwbfbSzn0FRL_AMZGsYDag/Followers")
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/jwbfbSzn0FRL_AMZGsYDag/Followers")
 	if err != nil {
 		return nil, "", err
 	}
@@ -133,7 +149,7 @@ func (s *Scraper) FetchFollowersByUserID(userID string, maxUsersNbr int, cursor
 
 	query := url.Values{}
 	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
 	req.URL.RawQuery = query.Encode()
 
 	var timeline timelineV2
@@ -143,6 +159,9 @@ func (s *Scraper) FetchFollowersByUserID(userID string, maxUsersNbr int, cursor
 	}
 
 	users, nextCursor := timeline.parseUsers()
+	if users, err = s.filterProfiles(users); err != nil {
+		return nil, "", err
+	}
 
 	if strings.HasPrefix(nextCursor, "0|") {
 		nextCursor = ""
@@ -1,14 +1,18 @@
 package twitterscraper_test
 
 import (
+	"bytes"
+	"context"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestFetchFollowing(t *testing.T) {
 	if skipAuthTest {
 		t.Skip("Skipping test due to environment variable")
 	}
-	users, _, err := testScraper.FetchFollowing("Support", 20, "")
+	users, _, err := testScraper.FetchFollowing(context.Background(), "Support", 20, "")
 	if err != nil {
 		t.Error(err)
 	}
@@ -21,7 +25,7 @@ func TestFetchFollowers(t *testing.T) {
 	if skipAuthTest {
 		t.Skip("Skipping test due to environment variable")
 	}
-	users, _, err := testScraper.FetchFollowers("Support", 20, "")
+	users, _, err := testScraper.FetchFollowers(context.Background(), "Support", 20, "")
 	if err != nil {
 		t.Error(err)
 	}
@@ -29,3 +33,62 @@ func TestFetchFollowers(t *testing.T) {
 		t.Error("error FetchFollowing() No users found")
 	}
 }
+
+func TestGetFollowersResume(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	testScraper.ClearCursors()
+	defer testScraper.ClearCursors()
+	count := 0
+	for profile := range testScraper.GetFollowers(context.Background(), "Support", 30) {
+		if profile.Error != nil {
+			t.Fatal(profile.Error)
+		}
+		count++
+	}
+	if count != 30 {
+		t.Errorf("Expected 30 followers, got %d", count)
+	}
+
+	// cursor of interrupted scrape is saved with session
+	var session bytes.Buffer
+	if err := testScraper.WriteSession(&session); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(session.String(), `"followers/support"`) {
+		t.Errorf("Expected followers cursor in session, got %s", session.String())
+	}
+}
+
+func TestExportOwnGraph(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	graph, err := testScraper.ExportOwnGraph(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range graph {
+		if r.Profile.UserID == "" || (!r.Follower && !r.Following) || r.FollowedSince.IsZero() {
+			t.Errorf("Unexpected relationship %+v", r)
+		}
+		if r.Follower != (r.FollowerPosition >= 0) || r.Following != (r.FollowingPosition >= 0) {
+			t.Errorf("Expected positions to match relationship, got %+v", r)
+		}
+	}
+
+	again, err := testScraper.ExportOwnGraph(context.Background(), graph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	since := make(map[string]time.Time)
+	for _, r := range graph {
+		since[r.Profile.UserID] = r.FollowedSince
+	}
+	for _, r := range again {
+		if previous, ok := since[r.Profile.UserID]; ok && !r.FollowedSince.Equal(previous) {
+			t.Errorf("Expected FollowedSince of @%s to be kept, got %v instead of %v", r.Profile.Username, r.FollowedSince, previous)
+		}
+	}
+}
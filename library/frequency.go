@@ -0,0 +1,156 @@
+package twitterscraper
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type (
+	// TokenCount is a number of occurrences of word in tweets text.
+	TokenCount struct {
+		Token string `json:"token"`
+		Count int    `json:"count"`
+	}
+
+	// CooccurrenceEdge is a number of tweets where both hashtags are used.
+	CooccurrenceEdge struct {
+		Source string `json:"source"`
+		Target string `json:"target"`
+		Weight int    `json:"weight"`
+	}
+
+	// TextFrequency contains frequency tables of scraped tweets text.
+	TextFrequency struct {
+		Tokens        []TokenCount       `json:"tokens"`
+		Hashtags      []HashtagCount     `json:"hashtags"`
+		Cooccurrences []CooccurrenceEdge `json:"cooccurrences"`
+	}
+)
+
+// NewTextFrequency counts words and hashtags of tweets and hashtags used together.
+// Words are lowercased, links, mentions and hashtags are not counted as words.
+func NewTextFrequency(tweets []*Tweet) *TextFrequency {
+	tokens := make(map[string]int)
+	hashtags := make(map[string]int)
+	edges := make(map[[2]string]int)
+
+	for _, tweet := range tweets {
+		if tweet == nil {
+			continue
+		}
+
+		for _, token := range tokenize(tweet.Text) {
+			tokens[token]++
+		}
+
+		seen := make(map[string]bool)
+		var used []string
+		for _, hashtag := range tweet.Hashtags {
+			hashtag = strings.ToLower(hashtag)
+			if seen[hashtag] {
+				continue
+			}
+			seen[hashtag] = true
+			hashtags[hashtag]++
+			used = append(used, hashtag)
+		}
+
+		sort.Strings(used)
+		for i := range used {
+			for j := i + 1; j < len(used); j++ {
+				edges[[2]string{used[i], used[j]}]++
+			}
+		}
+	}
+
+	frequency := &TextFrequency{
+		Tokens:        []TokenCount{},
+		Hashtags:      []HashtagCount{},
+		Cooccurrences: []CooccurrenceEdge{},
+	}
+
+	for token, count := range tokens {
+		frequency.Tokens = append(frequency.Tokens, TokenCount{Token: token, Count: count})
+	}
+	sort.Slice(frequency.Tokens, func(i, j int) bool {
+		if frequency.Tokens[i].Count == frequency.Tokens[j].Count {
+			return frequency.Tokens[i].Token < frequency.Tokens[j].Token
+		}
+		return frequency.Tokens[i].Count > frequency.Tokens[j].Count
+	})
+
+	for hashtag, count := range hashtags {
+		frequency.Hashtags = append(frequency.Hashtags, HashtagCount{Hashtag: hashtag, Count: count})
+	}
+	sort.Slice(frequency.Hashtags, func(i, j int) bool {
+		if frequency.Hashtags[i].Count == frequency.Hashtags[j].Count {
+			return frequency.Hashtags[i].Hashtag < frequency.Hashtags[j].Hashtag
+		}
+		return frequency.Hashtags[i].Count > frequency.Hashtags[j].Count
+	})
+
+	for pair, weight := range edges {
+		frequency.Cooccurrences = append(frequency.Cooccurrences, CooccurrenceEdge{Source: pair[0], Target: pair[1], Weight: weight})
+	}
+	sort.Slice(frequency.Cooccurrences, func(i, j int) bool {
+		a, b := frequency.Cooccurrences[i], frequency.Cooccurrences[j]
+		if a.Weight != b.Weight {
+			return a.Weight > b.Weight
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Target < b.Target
+	})
+
+	return frequency
+}
+
+// WriteTokensCSV writes words frequency table with token,count columns.
+func (frequency *TextFrequency) WriteTokensCSV(w io.Writer) error {
+	rows := [][]string{{"token", "count"}}
+	for _, token := range frequency.Tokens {
+		rows = append(rows, []string{token.Token, strconv.Itoa(token.Count)})
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+// WriteHashtagsCSV writes hashtags frequency table with hashtag,count columns.
+func (frequency *TextFrequency) WriteHashtagsCSV(w io.Writer) error {
+	rows := [][]string{{"hashtag", "count"}}
+	for _, hashtag := range frequency.Hashtags {
+		rows = append(rows, []string{hashtag.Hashtag, strconv.Itoa(hashtag.Count)})
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+// WriteCooccurrencesCSV writes hashtags co-occurrence edges with source,target,weight
+// columns, which can be imported as undirected graph into Gephi and similar tools.
+func (frequency *TextFrequency) WriteCooccurrencesCSV(w io.Writer) error {
+	rows := [][]string{{"source", "target", "weight"}}
+	for _, edge := range frequency.Cooccurrences {
+		rows = append(rows, []string{edge.Source, edge.Target, strconv.Itoa(edge.Weight)})
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+func tokenize(text string) []string {
+	var tokens []string
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		if strings.HasPrefix(word, "http://") || strings.HasPrefix(word, "https://") ||
+			strings.HasPrefix(word, "#") || strings.HasPrefix(word, "@") {
+			continue
+		}
+		token := strings.TrimFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,36 @@
+package twitterscraper_test
+
+import (
+	"bytes"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestNewTextFrequency(t *testing.T) {
+	tweets := []*twitterscraper.Tweet{
+		{Text: "Hello, world! #Go #News https://t.co/abc", Hashtags: []string{"Go", "News"}},
+		{Text: "hello @user #go #news #dev", Hashtags: []string{"go", "news", "dev"}},
+	}
+
+	frequency := twitterscraper.NewTextFrequency(tweets)
+
+	if len(frequency.Tokens) != 2 || frequency.Tokens[0] != (twitterscraper.TokenCount{Token: "hello", Count: 2}) {
+		t.Errorf("Unexpected tokens %v", frequency.Tokens)
+	}
+	if len(frequency.Hashtags) != 3 || frequency.Hashtags[0] != (twitterscraper.HashtagCount{Hashtag: "go", Count: 2}) {
+		t.Errorf("Unexpected hashtags %v", frequency.Hashtags)
+	}
+	if len(frequency.Cooccurrences) != 3 || frequency.Cooccurrences[0] != (twitterscraper.CooccurrenceEdge{Source: "go", Target: "news", Weight: 2}) {
+		t.Errorf("Unexpected co-occurrences %v", frequency.Cooccurrences)
+	}
+
+	var b bytes.Buffer
+	if err := frequency.WriteCooccurrencesCSV(&b); err != nil {
+		t.Fatal(err)
+	}
+	expected := "source,target,weight\ngo,news,2\ndev,go,1\ndev,news,1\n"
+	if b.String() != expected {
+		t.Errorf("Expected CSV\n%s\ngot\n%s", expected, b.String())
+	}
+}
@@ -0,0 +1,151 @@
+package twitterscraper
+
+import (
+	"context"
+	"time"
+)
+
+// maxGraphPages limits pages of every list loaded by ExportOwnGraph.
+const maxGraphPages = 500
+
+// Relationship of the logged in account with another user.
+type Relationship struct {
+	Profile Profile
+	// Follower is true if user follows the account.
+	Follower bool
+	// Following is true if the account follows user.
+	Following bool
+	// FollowerPosition and FollowingPosition are positions in lists of
+	// followers and following, 0 is the most recent follow, -1 if user isn't
+	// in list. Lists are ordered by time of follow, so positions order
+	// relationships in time.
+	FollowerPosition  int
+	FollowingPosition int
+	// FollowedSince is when relationship was first exported. API doesn't
+	// expose time of follow, so it's carried over from previous export: with
+	// regular exports it approximates follow time to their interval.
+	FollowedSince time.Time
+}
+
+// ExportOwnGraph returns followers and following of the logged in account
+// merged by user, the most recent follows first. Relationships of previous
+// export keep their FollowedSince, new ones get the current time.
+func (s *Scraper) ExportOwnGraph(ctx context.Context, previous []Relationship) ([]Relationship, error) {
+	settings, err := s.GetAccountSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := s.GetUserIDByScreenName(ctx, settings.ScreenName)
+	if err != nil {
+		return nil, err
+	}
+
+	followers, err := s.loadGraphList(ctx, userID, s.FetchFollowersByUserID)
+	if err != nil {
+		return nil, err
+	}
+	following, err := s.loadGraphList(ctx, userID, s.FetchFollowingByUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	since := make(map[string]time.Time, len(previous))
+	for _, r := range previous {
+		since[r.Profile.UserID] = r.FollowedSince
+	}
+	now := time.Now()
+
+	var graph []Relationship
+	byID := make(map[string]int)
+	add := func(profile *Profile) *Relationship {
+		if i, ok := byID[profile.UserID]; ok {
+			return &graph[i]
+		}
+		followedSince, ok := since[profile.UserID]
+		if !ok || followedSince.IsZero() {
+			followedSince = now
+		}
+		byID[profile.UserID] = len(graph)
+		graph = append(graph, Relationship{Profile: *profile, FollowerPosition: -1, FollowingPosition: -1, FollowedSince: followedSince})
+		return &graph[len(graph)-1]
+	}
+	for i, profile := range followers {
+		r := add(profile)
+		r.Follower = true
+		r.FollowerPosition = i
+	}
+	for i, profile := range following {
+		r := add(profile)
+		r.Following = true
+		r.FollowingPosition = i
+	}
+	return graph, nil
+}
+
+// loadGraphList loads all pages of followers or following of userID.
+func (s *Scraper) loadGraphList(ctx context.Context, userID string, fetch func(ctx context.Context, userID string, maxUsersNbr int, cursor string) ([]*Profile, string, error)) ([]*Profile, error) {
+	var profiles []*Profile
+	seen := make(map[string]bool)
+	var cursor string
+	for page := 0; page < maxGraphPages; page++ {
+		batch, next, err := fetch(ctx, userID, 200, cursor)
+		if err != nil {
+			return nil, err
+		}
+		for _, profile := range batch {
+			if !seen[profile.UserID] {
+				seen[profile.UserID] = true
+				profiles = append(profiles, profile)
+			}
+		}
+		if len(batch) == 0 || next == "" || next == cursor {
+			break
+		}
+		cursor = next
+	}
+	return profiles, nil
+}
+
+// Types of FollowerEvent.
+const (
+	FollowerGained = "follow"
+	FollowerLost   = "unfollow"
+)
+
+// FollowerEvent is change of followers between two exports of own graph.
+type FollowerEvent struct {
+	// Type is FollowerGained or FollowerLost.
+	Type    string
+	Profile Profile
+	// Time of export found the change.
+	Time time.Time
+}
+
+// DiffFollowers compares followers of two exports of ExportOwnGraph, lost
+// followers are reported with profile from previous export. Events are in
+// order of current export, then of previous one.
+func DiffFollowers(previous, current []Relationship, at time.Time) []FollowerEvent {
+	before := make(map[string]bool, len(previous))
+	for _, r := range previous {
+		if r.Follower {
+			before[r.Profile.UserID] = true
+		}
+	}
+	now := make(map[string]bool, len(current))
+	var events []FollowerEvent
+	for _, r := range current {
+		if !r.Follower {
+			continue
+		}
+		now[r.Profile.UserID] = true
+		if !before[r.Profile.UserID] {
+			events = append(events, FollowerEvent{Type: FollowerGained, Profile: r.Profile, Time: at})
+		}
+	}
+	for _, r := range previous {
+		if r.Follower && !now[r.Profile.UserID] {
+			events = append(events, FollowerEvent{Type: FollowerLost, Profile: r.Profile, Time: at})
+		}
+	}
+	return events
+}
@@ -0,0 +1,36 @@
+package twitterscraper_test
+
+import (
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestDiffFollowers(t *testing.T) {
+	relationship := func(userID string, follower bool) twitterscraper.Relationship {
+		return twitterscraper.Relationship{Profile: twitterscraper.Profile{UserID: userID}, Follower: follower, Following: !follower}
+	}
+	previous := []twitterscraper.Relationship{relationship("1", true), relationship("2", true), relationship("3", false)}
+	current := []twitterscraper.Relationship{relationship("4", true), relationship("1", true), relationship("3", true), relationship("2", false)}
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	events := twitterscraper.DiffFollowers(previous, current, at)
+	expected := []struct{ typ, userID string }{
+		{twitterscraper.FollowerGained, "4"},
+		{twitterscraper.FollowerGained, "3"},
+		{twitterscraper.FollowerLost, "2"},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %+v", len(expected), events)
+	}
+	for i, e := range expected {
+		if events[i].Type != e.typ || events[i].Profile.UserID != e.userID || !events[i].Time.Equal(at) {
+			t.Errorf("Expected %s of %s, got %+v", e.typ, e.userID, events[i])
+		}
+	}
+
+	if events := twitterscraper.DiffFollowers(current, current, at); len(events) != 0 {
+		t.Errorf("Expected no events for the same export, got %+v", events)
+	}
+}
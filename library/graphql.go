@@ -0,0 +1,75 @@
+package twitterscraper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+const graphQLURL = "https://twitter.com/i/api/graphql/"
+
+// DoGraphQL requests not yet wrapped GraphQL query, with the same authentication,
+// delay and proxy as built-in methods, and decodes response JSON to target.
+// Features passed to request take precedence over scraper overrides set with SetFeature.
+// Query is set as query ID and operation name, copied from browser devtools:
+//
+//	err := scraper.DoGraphQL("VWFGPVAGkZMGRKGe3GFFnA/TweetDetail", variables, features, &response)
+func (s *Scraper) DoGraphQL(ctx context.Context, query string, variables map[string]interface{}, features map[string]interface{}, target interface{}) error {
+	if err := checkGraphQLQuery(query); err != nil {
+		return err
+	}
+
+	req, err := s.newRequest(ctx, "GET", graphQLURL+query)
+	if err != nil {
+		return err
+	}
+
+	values := url.Values{}
+	values.Set("variables", mapToJSONString(variables))
+	if features != nil || len(s.features) > 0 {
+		values.Set("features", mapToJSONString(mergeFeatures(s.features, features)))
+	}
+	req.URL.RawQuery = values.Encode()
+
+	return s.RequestAPI(req, target)
+}
+
+// DoGraphQLMutation same as DoGraphQL, but sends query as POST request with JSON body,
+// as it's done for actions like FavoriteTweet or CreateTweet.
+func (s *Scraper) DoGraphQLMutation(ctx context.Context, query string, variables map[string]interface{}, features map[string]interface{}, target interface{}) error {
+	if err := checkGraphQLQuery(query); err != nil {
+		return err
+	}
+
+	req, err := s.newRequest(ctx, "POST", graphQLURL+query)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("content-type", "application/json")
+	body := map[string]interface{}{
+		"variables": variables,
+		"queryId":   strings.SplitN(query, "/", 2)[0],
+	}
+	if features != nil || len(s.features) > 0 {
+		body["features"] = mergeFeatures(s.features, features)
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	setRequestBody(req, b)
+
+	return s.RequestAPI(req, target)
+}
+
+func checkGraphQLQuery(query string) error {
+	parts := strings.Split(query, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return errors.New("graphql query must be in the format `queryId/OperationName`")
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDoGraphQL(t *testing.T) {
+	variables := map[string]interface{}{
+		"userId":                   "1221221876849995777",
+		"withSafetyModeUserFields": true,
+	}
+	features := map[string]interface{}{
+		"hidden_profile_subscriptions_enabled":                              true,
+		"responsive_web_graphql_exclude_directive_enabled":                  true,
+		"verified_phone_label_enabled":                                      false,
+		"highlights_tweets_tab_ui_enabled":                                  true,
+		"creator_subscriptions_tweet_preview_api_enabled":                   true,
+		"responsive_web_graphql_skip_user_profile_image_extensions_enabled": false,
+		"responsive_web_graphql_timeline_navigation_enabled":                true,
+	}
+
+	var response struct {
+		Data struct {
+			User struct {
+				Result struct {
+					RestID string `json:"rest_id"`
+				} `json:"result"`
+			} `json:"user"`
+		} `json:"data"`
+	}
+	err := testScraper.DoGraphQL(context.Background(), "Qw77dDjp9xCpUY-AXwt-yQ/UserByRestId", variables, features, &response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Data.User.Result.RestID != "1221221876849995777" {
+		t.Errorf("Expected user 1221221876849995777, got '%s'", response.Data.User.Result.RestID)
+	}
+
+	if err := testScraper.DoGraphQL(context.Background(), "UserByRestId", variables, features, &response); err == nil {
+		t.Error("Expected error for query without ID")
+	}
+}
@@ -0,0 +1,55 @@
+package twitterscraper
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// DefaultGuestTokenTTL is age of guest token after which it's refetched.
+const DefaultGuestTokenTTL = 3 * time.Hour
+
+// SetGuestTokenTTL set age of guest token after which it's refetched before
+// the next guest request, DefaultGuestTokenTTL if ttl isn't positive. Token
+// rejected with 403 is refetched regardless of its age.
+func (s *Scraper) SetGuestTokenTTL(ttl time.Duration) *Scraper {
+	if ttl <= 0 {
+		ttl = DefaultGuestTokenTTL
+	}
+	s.guestMu.Lock()
+	s.guestTTL = ttl
+	s.guestMu.Unlock()
+	return s
+}
+
+func (s *Scraper) currentGuestToken() string {
+	s.guestMu.Lock()
+	defer s.guestMu.Unlock()
+	return s.guestToken
+}
+
+// invalidateGuestToken clears guest token req was sent with, unless it's
+// already replaced by concurrent request.
+func (s *Scraper) invalidateGuestToken(req *http.Request) bool {
+	token := req.Header.Get("X-Guest-Token")
+	if token == "" {
+		return false
+	}
+	s.guestMu.Lock()
+	if s.guestToken == token {
+		s.guestToken = ""
+		s.guestCreatedAt = time.Time{}
+	}
+	s.guestMu.Unlock()
+	return true
+}
+
+// isGuestTokenRejected checks if guest request failed with 403 and clears
+// its token, so retry gets new one.
+func (s *Scraper) isGuestTokenRejected(req *http.Request, err error) bool {
+	var apiErr *APIError
+	if s.isLogged || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		return false
+	}
+	return s.invalidateGuestToken(req)
+}
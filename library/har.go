@@ -0,0 +1,235 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const harRedacted = "[REDACTED]"
+
+// secretHeaders carry credentials or identify client and are redacted
+// wherever requests are recorded, in HAR files and logs.
+var secretHeaders = map[string]bool{
+	"authorization":    true,
+	"cookie":           true,
+	"set-cookie":       true,
+	"x-csrf-token":     true,
+	"x-guest-token":    true,
+	"x-client-uuid":    true,
+	"x-client-tx-id":   true,
+	"x-transaction-id": true,
+	// sent by web client with every API request
+	"x-client-transaction-id": true,
+}
+
+type (
+	harLog struct {
+		Log struct {
+			Version string     `json:"version"`
+			Creator harCreator `json:"creator"`
+			Entries []harEntry `json:"entries"`
+		} `json:"log"`
+	}
+
+	harCreator struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+
+	harNameValue struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	harPostData struct {
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+	}
+
+	harContent struct {
+		Size     int    `json:"size"`
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+	}
+
+	harRequest struct {
+		Method      string         `json:"method"`
+		URL         string         `json:"url"`
+		HTTPVersion string         `json:"httpVersion"`
+		Headers     []harNameValue `json:"headers"`
+		QueryString []harNameValue `json:"queryString"`
+		Cookies     []harNameValue `json:"cookies"`
+		PostData    *harPostData   `json:"postData,omitempty"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int            `json:"bodySize"`
+	}
+
+	harResponse struct {
+		Status      int            `json:"status"`
+		StatusText  string         `json:"statusText"`
+		HTTPVersion string         `json:"httpVersion"`
+		Headers     []harNameValue `json:"headers"`
+		Cookies     []harNameValue `json:"cookies"`
+		Content     harContent     `json:"content"`
+		RedirectURL string         `json:"redirectURL"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int            `json:"bodySize"`
+	}
+
+	harTimings struct {
+		Send    int `json:"send"`
+		Wait    int `json:"wait"`
+		Receive int `json:"receive"`
+	}
+
+	harEntry struct {
+		StartedDateTime string      `json:"startedDateTime"`
+		Time            int         `json:"time"`
+		Request         harRequest  `json:"request"`
+		Response        harResponse `json:"response"`
+		Cache           struct{}    `json:"cache"`
+		Timings         harTimings  `json:"timings"`
+		Comment         string      `json:"comment,omitempty"`
+	}
+
+	harRecorder struct {
+		mu          sync.Mutex
+		maxBodySize int
+		entries     []harEntry
+	}
+)
+
+// WithHAR start recording all API requests and responses in HAR format.
+// Bodies longer than maxBodySize bytes are truncated, 0 means no limit.
+// Credentials in headers are always redacted.
+func (s *Scraper) WithHAR(maxBodySize int) *Scraper {
+	s.har = &harRecorder{maxBodySize: maxBodySize}
+	return s
+}
+
+// WriteHAR writes recorded traffic as HAR to w.
+func (s *Scraper) WriteHAR(w io.Writer) error {
+	var har harLog
+	har.Log.Version = "1.2"
+	har.Log.Creator = harCreator{Name: "twitter-scraper", Version: "1"}
+	har.Log.Entries = []harEntry{}
+
+	if s.har != nil {
+		s.har.mu.Lock()
+		har.Log.Entries = append(har.Log.Entries, s.har.entries...)
+		s.har.mu.Unlock()
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(har)
+}
+
+// SaveHAR writes recorded traffic as HAR to file.
+func (s *Scraper) SaveHAR(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := s.WriteHAR(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (r *harRecorder) truncate(body []byte) string {
+	if r.maxBodySize > 0 && len(body) > r.maxBodySize {
+		return string(body[:r.maxBodySize])
+	}
+	return string(body)
+}
+
+func (r *harRecorder) record(requestID string, req *http.Request, reqBody []byte, resp *http.Response, respBody []byte, started time.Time) {
+	elapsed := int(time.Since(started).Milliseconds())
+
+	entry := harEntry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		Time:            elapsed,
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         req.URL.String(),
+			HTTPVersion: req.Proto,
+			Headers:     harHeaders(req.Header),
+			QueryString: []harNameValue{},
+			Cookies:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Response: harResponse{
+			Status:      resp.StatusCode,
+			StatusText:  http.StatusText(resp.StatusCode),
+			HTTPVersion: resp.Proto,
+			Headers:     harHeaders(resp.Header),
+			Cookies:     []harNameValue{},
+			Content: harContent{
+				Size:     len(respBody),
+				MimeType: resp.Header.Get("Content-Type"),
+				Text:     r.truncate(respBody),
+			},
+			HeadersSize: -1,
+			BodySize:    len(respBody),
+		},
+		Timings: harTimings{Send: 0, Wait: elapsed, Receive: 0},
+		Comment: "request " + requestID,
+	}
+
+	query := req.URL.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range query[name] {
+			entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: name, Value: value})
+		}
+	}
+
+	if len(reqBody) > 0 {
+		entry.Request.PostData = &harPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     r.truncate(reqBody),
+		}
+	}
+
+	r.mu.Lock()
+	r.entries = append(r.entries, entry)
+	r.mu.Unlock()
+}
+
+// isSecretHeader checks if header must be redacted, name is case-insensitive.
+func isSecretHeader(name string) bool {
+	return secretHeaders[strings.ToLower(name)]
+}
+
+func harHeaders(header http.Header) []harNameValue {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	headers := []harNameValue{}
+	for _, name := range names {
+		for _, value := range header[name] {
+			if isSecretHeader(name) {
+				value = harRedacted
+			}
+			headers = append(headers, harNameValue{Name: name, Value: value})
+		}
+	}
+	return headers
+}
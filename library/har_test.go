@@ -0,0 +1,58 @@
+package twitterscraper_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteHAR(t *testing.T) {
+	scraper := newTestScraper(true)
+	scraper.WithHAR(1024)
+
+	if _, err := scraper.GetProfile(context.Background(), "nomadic_ua"); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := scraper.WriteHAR(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var har struct {
+		Log struct {
+			Entries []struct {
+				Request struct {
+					URL     string `json:"url"`
+					Headers []struct {
+						Name  string `json:"name"`
+						Value string `json:"value"`
+					} `json:"headers"`
+				} `json:"request"`
+				Response struct {
+					Content struct {
+						Text string `json:"text"`
+					} `json:"content"`
+				} `json:"response"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &har); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(har.Log.Entries) == 0 {
+		t.Fatal("Expected at least one HAR entry")
+	}
+	for _, entry := range har.Log.Entries {
+		if len(entry.Response.Content.Text) > 1024 {
+			t.Errorf("Expected response body truncated to 1024 bytes, got %d", len(entry.Response.Content.Text))
+		}
+		for _, header := range entry.Request.Headers {
+			if header.Name == "Authorization" && header.Value != "[REDACTED]" {
+				t.Error("Expected Authorization header to be redacted")
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package twitterscraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// maxHydrateBatch is maximum number of tweet IDs in one TweetResultsByRestIds request.
+const maxHydrateBatch = 100
+
+type tweetResults struct {
+	Data struct {
+		TweetResult []struct {
+			Result result `json:"result"`
+		} `json:"tweetResult"`
+	} `json:"data"`
+}
+
+// GetTweetsByIDs returns channel with tweets for the given IDs, for example read with ReadIDsFile.
+// Tweets are requested in batches of up to 100 IDs, if batch endpoint is refused tweets of batch are requested one by one.
+// Deleted, protected or otherwise unavailable tweets are sent as errors with tweet ID and don't stop hydration.
+func (s *Scraper) GetTweetsByIDs(ctx context.Context, ids []string) <-chan *TweetResult {
+	channel := make(chan *TweetResult)
+	go func() {
+		defer close(channel)
+		for start := 0; start < len(ids); start += maxHydrateBatch {
+			end := start + maxHydrateBatch
+			if end > len(ids) {
+				end = len(ids)
+			}
+			batch := ids[start:end]
+
+			select {
+			case <-ctx.Done():
+				channel <- &TweetResult{Error: ctx.Err()}
+				return
+			default:
+			}
+
+			tweets, errs, err := s.fetchTweetsByIDs(ctx, batch)
+			if isEndpointBlocked(err) {
+				s.logWarn("twitterscraper: batch endpoint blocked, requesting tweets one by one", "error", err)
+				tweets, errs, err = s.getTweetsOneByOne(ctx, batch)
+			}
+			if ctx.Err() != nil {
+				channel <- &TweetResult{Error: ctx.Err()}
+				return
+			}
+			for _, id := range batch {
+				if err != nil {
+					channel <- &TweetResult{Error: fmt.Errorf("tweet %s: %w", id, err)}
+				} else if tweet, ok := tweets[id]; ok {
+					channel <- &TweetResult{Tweet: *tweet}
+				} else {
+					channel <- &TweetResult{Error: fmt.Errorf("tweet %s: %w", id, errs[id])}
+				}
+			}
+		}
+	}()
+	return channel
+}
+
+// fetchTweetsByIDs gets batch of tweets via TweetResultsByRestIds, errors of
+// unavailable tweets are returned by ID, error of request fails whole batch.
+// Age-restricted tweets are requested again as by GetTweet.
+func (s *Scraper) fetchTweetsByIDs(ctx context.Context, ids []string) (map[string]*Tweet, map[string]error, error) {
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/PTN9HhBAlpoCTHfspDgqLA/TweetResultsByRestIds")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variables := map[string]interface{}{
+		"tweetIds":               ids,
+		"withCommunity":          false,
+		"includePromotedContent": false,
+		"withVoice":              false,
+	}
+	features := map[string]interface{}{
+		"creator_subscriptions_tweet_preview_api_enabled":                         true,
+		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
+		"tweetypie_unmention_optimization_enabled":                                true,
+		"responsive_web_edit_tweet_api_enabled":                                   true,
+		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+		"view_counts_everywhere_api_enabled":                                      true,
+		"longform_notetweets_consumption_enabled":                                 true,
+		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+		"tweet_awards_web_tipping_enabled":                                        false,
+		"freedom_of_speech_not_reach_fetch_enabled":                               true,
+		"standardized_nudges_misinfo":                                             true,
+		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+		"rweb_video_timestamps_enabled":                                           true,
+		"longform_notetweets_rich_text_read_enabled":                              true,
+		"longform_notetweets_inline_media_enabled":                                true,
+		"responsive_web_graphql_exclude_directive_enabled":                        true,
+		"verified_phone_label_enabled":                                            false,
+		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+		"responsive_web_graphql_timeline_navigation_enabled":                      true,
+		"responsive_web_enhance_cards_enabled":                                    false,
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
+	req.URL.RawQuery = query.Encode()
+
+	var results tweetResults
+	meta, err := s.requestAPIMeta(req, &results)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tweets := make(map[string]*Tweet)
+	errs := make(map[string]error)
+	for i, item := range results.Data.TweetResult {
+		if tweet := item.Result.parse(); tweet != nil {
+			s.setProvenance([]*Tweet{tweet}, meta, EndpointTweetDetail, "")
+			if err := s.validateTweets([]*Tweet{tweet}); err != nil {
+				errs[tweet.ID] = err
+				continue
+			}
+			tweets[tweet.ID] = tweet
+		} else if item.Result.isAgeRestricted() && len(results.Data.TweetResult) == len(ids) {
+			// results of unavailable tweets have no ID, but keep order of requested IDs
+			errs[ids[i]] = ErrAgeRestricted
+		}
+	}
+	for _, id := range ids {
+		if _, ok := tweets[id]; !ok && errs[id] == nil {
+			errs[id] = ErrTweetNotFound
+		}
+		if errs[id] == ErrAgeRestricted {
+			tweet, err := s.getAgeRestrictedTweet(ctx, id)
+			if err != nil {
+				errs[id] = err
+				continue
+			}
+			delete(errs, id)
+			tweets[id] = tweet
+		}
+	}
+	return tweets, errs, nil
+}
+
+// getTweetsOneByOne gets batch of tweets with GetTweet, like fetchTweetsByIDs.
+func (s *Scraper) getTweetsOneByOne(ctx context.Context, ids []string) (map[string]*Tweet, map[string]error, error) {
+	tweets := make(map[string]*Tweet)
+	errs := make(map[string]error)
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		tweet, err := s.GetTweet(ctx, id)
+		if err != nil {
+			errs[id] = err
+			continue
+		}
+		tweets[id] = tweet
+	}
+	return tweets, errs, nil
+}
+
+// GetProfilesByIDs returns channel with profiles for the given user IDs, for example read with ReadIDsFile.
+// Suspended or not found users are sent as errors with user ID and don't stop hydration.
+func (s *Scraper) GetProfilesByIDs(ctx context.Context, ids []string) <-chan *ProfileResult {
+	channel := make(chan *ProfileResult)
+	go func() {
+		defer close(channel)
+		for _, id := range ids {
+			select {
+			case <-ctx.Done():
+				channel <- &ProfileResult{Error: ctx.Err()}
+				return
+			default:
+			}
+
+			profile, err := s.GetProfileByID(ctx, id)
+			if err != nil {
+				channel <- &ProfileResult{Error: fmt.Errorf("user %s: %w", id, err)}
+				continue
+			}
+			channel <- &ProfileResult{Profile: profile}
+		}
+	}()
+	return channel
+}
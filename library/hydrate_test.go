@@ -0,0 +1,123 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestGetTweetsByIDs(t *testing.T) {
+	ids := []string{"1665602315745673217", "1554522888904101890"}
+
+	var found []string
+	for tweet := range testScraper.GetTweetsByIDs(context.Background(), ids) {
+		if tweet.Error != nil {
+			t.Error(tweet.Error)
+			continue
+		}
+		found = append(found, tweet.ID)
+	}
+
+	if len(found) != len(ids) {
+		t.Fatalf("Expected %d tweets, got %d", len(ids), len(found))
+	}
+	for i, id := range ids {
+		if found[i] != id {
+			t.Errorf("Expected tweet %s, got %s", id, found[i])
+		}
+	}
+}
+
+func TestGetTweetsByIDsBatches(t *testing.T) {
+	// tweets with odd IDs are deleted
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if !strings.HasSuffix(r.URL.Path, "/TweetResultsByRestIds") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var variables struct {
+			TweetIDs []string `json:"tweetIds"`
+		}
+		if err := json.Unmarshal([]byte(r.URL.Query().Get("variables")), &variables); err != nil || len(variables.TweetIDs) > 100 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var results []string
+		for _, id := range variables.TweetIDs {
+			if n, _ := strconv.Atoi(id); n%2 == 1 {
+				results = append(results, `{}`)
+				continue
+			}
+			results = append(results, fmt.Sprintf(`{"result":{"__typename":"Tweet","legacy":{"id_str":"%[1]s","full_text":"%[1]s"}}}`, id))
+		}
+		fmt.Fprintf(w, `{"data":{"tweetResult":[%s]}}`, strings.Join(results, ","))
+	}))
+	defer ts.Close()
+	target, _ := url.Parse(ts.URL)
+
+	scraper := twitterscraper.New()
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "token", CSRFToken: "csrf"})
+	scraper.BeforeRequest(func(req *http.Request) {
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+	})
+
+	var ids []string
+	for i := 1; i <= 150; i++ {
+		ids = append(ids, strconv.Itoa(i))
+	}
+	var found, missing, i int
+	for tweet := range scraper.GetTweetsByIDs(context.Background(), ids) {
+		switch {
+		case tweet.Error != nil:
+			if !errors.Is(tweet.Error, twitterscraper.ErrTweetNotFound) || !strings.Contains(tweet.Error.Error(), ids[i]) {
+				t.Errorf("Expected not found error of tweet %s, got %v", ids[i], tweet.Error)
+			}
+			missing++
+		case tweet.ID != ids[i]:
+			t.Errorf("Expected tweet %s, got %s", ids[i], tweet.ID)
+		default:
+			found++
+		}
+		i++
+	}
+
+	if found != 75 || missing != 75 {
+		t.Errorf("Expected 75 tweets and 75 errors, got %d and %d", found, missing)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 batch requests, got %d", requests)
+	}
+}
+
+func TestGetProfilesByIDs(t *testing.T) {
+	ids := []string{"1221221876849995777", "0"}
+
+	var profiles, errors int
+	for profile := range testScraper.GetProfilesByIDs(context.Background(), ids) {
+		if profile.Error != nil {
+			errors++
+			continue
+		}
+		profiles++
+		if profile.Username != "tomdumont" {
+			t.Errorf("Expected username 'tomdumont', got '%s'", profile.Username)
+		}
+	}
+
+	if profiles != 1 || errors != 1 {
+		t.Errorf("Expected 1 profile and 1 error, got %d and %d", profiles, errors)
+	}
+}
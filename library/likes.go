@@ -0,0 +1,158 @@
+package twitterscraper
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// ownLike is tweet of favorites list with embedded author.
+type ownLike struct {
+	legacyTweet
+	User legacyUser `json:"user"`
+}
+
+// GetLikedTweets returns channel with tweets liked by user. Likes are private,
+// so only the logged in account gets them. Scrape stopped before the end of
+// list resumes from the last page on the next call, as GetFollowers.
+func (s *Scraper) GetLikedTweets(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
+	return s.getTweetTimeline(ctx, user, maxTweetsNbr, s.trackTweets(cursorKey("likes", user), s.FetchLikedTweets))
+}
+
+// FetchLikedTweets gets tweets liked by user, via the Twitter frontend API.
+func (s *Scraper) FetchLikedTweets(ctx context.Context, user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	userID, err := s.GetUserIDByScreenName(ctx, user)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return s.FetchLikedTweetsByUserID(ctx, userID, maxTweetsNbr, cursor)
+}
+
+// FetchLikedTweetsByUserID gets tweets liked by userID, via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchLikedTweetsByUserID(ctx context.Context, userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	if maxTweetsNbr > 200 {
+		maxTweetsNbr = 200
+	}
+
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/eSSNbhECHHWWALkkQq-YTA/Likes")
+	if err != nil {
+		return nil, "", err
+	}
+
+	variables := map[string]interface{}{
+		"userId":                 userID,
+		"count":                  maxTweetsNbr,
+		"includePromotedContent": false,
+		"withClientEventToken":   false,
+		"withBirdwatchNotes":     false,
+		"withVoice":              true,
+		"withV2Timeline":         true,
+	}
+	features := map[string]interface{}{
+		"responsive_web_graphql_exclude_directive_enabled":                        true,
+		"verified_phone_label_enabled":                                            false,
+		"creator_subscriptions_tweet_preview_api_enabled":                         true,
+		"responsive_web_graphql_timeline_navigation_enabled":                      true,
+		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
+		"tweetypie_unmention_optimization_enabled":                                true,
+		"responsive_web_edit_tweet_api_enabled":                                   true,
+		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+		"view_counts_everywhere_api_enabled":                                      true,
+		"longform_notetweets_consumption_enabled":                                 true,
+		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+		"tweet_awards_web_tipping_enabled":                                        false,
+		"freedom_of_speech_not_reach_fetch_enabled":                               true,
+		"standardized_nudges_misinfo":                                             true,
+		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+		"rweb_video_timestamps_enabled":                                           true,
+		"longform_notetweets_rich_text_read_enabled":                              true,
+		"longform_notetweets_inline_media_enabled":                                true,
+		"responsive_web_media_download_video_enabled":                             false,
+		"responsive_web_enhance_cards_enabled":                                    false,
+	}
+
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
+	req.URL.RawQuery = query.Encode()
+
+	var timeline timelineV2
+	meta, err := s.requestAPIMeta(req, &timeline)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tweets, nextCursor := timeline.parseTweets()
+	s.setProvenance(tweets, meta, EndpointLikes, cursor)
+	if tweets, err = s.filterTweets(tweets); err != nil {
+		return nil, "", err
+	}
+	return tweets, nextCursor, nil
+}
+
+// GetOwnLikes returns channel with tweets liked by the logged in account, from
+// the most recent like, for exporting own data. It uses favorites list of
+// REST API, which goes further back in history than likes of GetLikedTweets.
+func (s *Scraper) GetOwnLikes(ctx context.Context, maxTweetsNbr int) <-chan *TweetResult {
+	return s.getTweetTimeline(ctx, "", maxTweetsNbr, s.trackTweets("own-likes", func(ctx context.Context, unused string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		return s.FetchOwnLikes(ctx, maxTweetsNbr, cursor)
+	}))
+}
+
+// FetchOwnLikes gets tweets liked by the logged in account, via the Twitter
+// REST API. Cursor is ID below which the next page starts.
+func (s *Scraper) FetchOwnLikes(ctx context.Context, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	if maxTweetsNbr > 200 {
+		maxTweetsNbr = 200
+	}
+
+	req, err := s.newRequest(ctx, "GET", "https://api.twitter.com/1.1/favorites/list.json")
+	if err != nil {
+		return nil, "", err
+	}
+	q := req.URL.Query()
+	q.Set("count", strconv.Itoa(maxTweetsNbr))
+	if cursor != "" {
+		q.Set("max_id", cursor)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	var likes []ownLike
+	meta, err := s.requestAPIMeta(req, &likes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var tweets []*Tweet
+	var oldest uint64
+	for i := range likes {
+		like := &likes[i]
+		if like.UserIDStr == "" {
+			like.UserIDStr = like.User.IDStr
+		}
+		tweet := parseLegacyTweet(&like.User, &like.legacyTweet)
+		if tweet == nil {
+			continue
+		}
+		tweets = append(tweets, tweet)
+		if id, err := strconv.ParseUint(tweet.ID, 10, 64); err == nil && (oldest == 0 || id < oldest) {
+			oldest = id
+		}
+	}
+
+	var nextCursor string
+	if oldest > 1 {
+		nextCursor = strconv.FormatUint(oldest-1, 10)
+	}
+	s.setProvenance(tweets, meta, EndpointLikes, cursor)
+	if tweets, err = s.filterTweets(tweets); err != nil {
+		return nil, "", err
+	}
+	return tweets, nextCursor, nil
+}
@@ -0,0 +1,45 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetLikedTweets(t *testing.T) {
+	// likes are private, only account of scraper can get them
+	if skipAuthTest || username == "" {
+		t.Skip("Skipping test due to environment variable")
+	}
+	count := 0
+	for tweet := range testScraper.GetLikedTweets(context.Background(), username, 20) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		if tweet.ID == "" || tweet.Provenance == nil || tweet.Provenance.Endpoint != "likes" {
+			t.Errorf("Expected liked tweet with provenance, got %+v", tweet.Tweet)
+		}
+		count++
+	}
+	if count > 20 {
+		t.Errorf("Expected up to 20 tweets, got %d", count)
+	}
+}
+
+func TestGetOwnLikes(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	count := 0
+	for tweet := range testScraper.GetOwnLikes(context.Background(), 20) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		if tweet.ID == "" || tweet.Username == "" || tweet.Provenance == nil || tweet.Provenance.Endpoint != "likes" {
+			t.Errorf("Expected liked tweet with author and provenance, got %+v", tweet.Tweet)
+		}
+		count++
+	}
+	if count > 20 {
+		t.Errorf("Expected up to 20 tweets, got %d", count)
+	}
+}
@@ -0,0 +1,270 @@
+package twitterscraper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+)
+
+// List of twitter users.
+type List struct {
+	ID              string
+	Name            string
+	Description     string
+	MemberCount     int
+	SubscriberCount int
+	IsPrivate       bool
+	OwnerID         string
+	OwnerUsername   string
+}
+
+// UserLists of twitter user.
+type UserLists struct {
+	// Owned lists created by the user.
+	Owned []*List
+	// Memberships lists where the user is a member.
+	Memberships []*List
+}
+
+type listResult struct {
+	IDStr           string `json:"id_str"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	MemberCount     int    `json:"member_count"`
+	SubscriberCount int    `json:"subscriber_count"`
+	Mode            string `json:"mode"`
+	UserResults     struct {
+		Result struct {
+			RestID string `json:"rest_id"`
+			Legacy struct {
+				ScreenName string `json:"screen_name"`
+			} `json:"legacy"`
+		} `json:"result"`
+	} `json:"user_results"`
+}
+
+func (list *listResult) parse() *List {
+	return &List{
+		ID:              list.IDStr,
+		Name:            list.Name,
+		Description:     list.Description,
+		MemberCount:     list.MemberCount,
+		SubscriberCount: list.SubscriberCount,
+		IsPrivate:       list.Mode == "Private",
+		OwnerID:         list.UserResults.Result.RestID,
+		OwnerUsername:   list.UserResults.Result.Legacy.ScreenName,
+	}
+}
+
+type listsTimeline struct {
+	Data struct {
+		User struct {
+			Result struct {
+				Timeline struct {
+					Timeline struct {
+						Instructions []struct {
+							Entries []struct {
+								Content struct {
+									CursorType  string `json:"cursorType"`
+									Value       string `json:"value"`
+									ItemContent struct {
+										List listResult `json:"list"`
+									} `json:"itemContent"`
+								} `json:"content"`
+							} `json:"entries"`
+						} `json:"instructions"`
+					} `json:"timeline"`
+				} `json:"timeline"`
+			} `json:"result"`
+		} `json:"user"`
+	} `json:"data"`
+}
+
+func (timeline *listsTimeline) parseLists() ([]*List, string) {
+	var cursor string
+	var lists []*List
+	for _, instruction := range timeline.Data.User.Result.Timeline.Timeline.Instructions {
+		for _, entry := range instruction.Entries {
+			if entry.Content.CursorType == "Bottom" {
+				cursor = entry.Content.Value
+				continue
+			}
+			if entry.Content.ItemContent.List.IDStr != "" {
+				lists = append(lists, entry.Content.ItemContent.List.parse())
+			}
+		}
+	}
+	return lists, cursor
+}
+
+// GetUserLists returns all lists the user owns and is a member of.
+func (s *Scraper) GetUserLists(ctx context.Context, username string) (*UserLists, error) {
+	userID, err := s.GetUserIDByScreenName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	userLists := &UserLists{Owned: []*List{}, Memberships: []*List{}}
+
+	var cursor string
+	for {
+		lists, next, err := s.FetchCombinedLists(ctx, userID, 100, cursor)
+		if err != nil {
+			return nil, err
+		}
+		for _, list := range lists {
+			if list.OwnerID == userID {
+				userLists.Owned = append(userLists.Owned, list)
+			}
+		}
+		if len(lists) == 0 || next == "" || next == cursor {
+			break
+		}
+		cursor = next
+	}
+
+	cursor = ""
+	for {
+		lists, next, err := s.FetchListMemberships(ctx, userID, 100, cursor)
+		if err != nil {
+			return nil, err
+		}
+		userLists.Memberships = append(userLists.Memberships, lists...)
+		if len(lists) == 0 || next == "" || next == cursor {
+			break
+		}
+		cursor = next
+	}
+
+	return userLists, nil
+}
+
+// FetchCombinedLists gets lists owned and subscribed by a given userID, via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchCombinedLists(ctx context.Context, userID string, maxListsNbr int, cursor string) ([]*List, string, error) {
+	return s.fetchLists(ctx, "https://twitter.com/i/api/graphql/ZgOPpTUVlI8K0S2Fjy9d6w/CombinedLists", userID, maxListsNbr, cursor)
+}
+
+// FetchListMemberships gets lists where a given userID is a member, via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchListMemberships(ctx context.Context, userID string, maxListsNbr int, cursor string) ([]*List, string, error) {
+	return s.fetchLists(ctx, "https://twitter.com/i/api/graphql/BlEXXdARdSeL_0KyKHHvvg/ListMemberships", userID, maxListsNbr, cursor)
+}
+
+func (s *Scraper) fetchLists(ctx context.Context, endpoint string, userID string, maxListsNbr int, cursor string) ([]*List, string, error) {
+	if maxListsNbr > 100 {
+		maxListsNbr = 100
+	}
+
+	req, err := s.newRequest(ctx, "GET", endpoint)
+	if err != nil {
+		return nil, "", err
+	}
+
+	variables := map[string]interface{}{
+		"userId": userID,
+		"count":  maxListsNbr,
+	}
+	features := map[string]interface{}{
+		"responsive_web_graphql_exclude_directive_enabled":                  true,
+		"verified_phone_label_enabled":                                      false,
+		"creator_subscriptions_tweet_preview_api_enabled":                   true,
+		"responsive_web_graphql_timeline_navigation_enabled":                true,
+		"responsive_web_graphql_skip_user_profile_image_extensions_enabled": false,
+	}
+
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
+	req.URL.RawQuery = query.Encode()
+
+	var timeline listsTimeline
+	err = s.RequestAPI(req, &timeline)
+	if err != nil {
+		return nil, "", err
+	}
+
+	lists, nextCursor := timeline.parseLists()
+	return lists, nextCursor, nil
+}
+
+// listFeatures required by list mutations
+var listFeatures = map[string]interface{}{
+	"responsive_web_graphql_exclude_directive_enabled":                  true,
+	"verified_phone_label_enabled":                                      false,
+	"responsive_web_graphql_skip_user_profile_image_extensions_enabled": false,
+	"responsive_web_graphql_timeline_navigation_enabled":                true,
+}
+
+type listMutationResponse struct {
+	Data struct {
+		List listResult `json:"list"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+	} `json:"errors"`
+}
+
+func (s *Scraper) listMutation(ctx context.Context, queryID, operation string, variables map[string]interface{}) (*List, error) {
+	req, err := s.newRequest(ctx, "POST", "https://twitter.com/i/api/graphql/"+queryID+"/"+operation)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("content-type", "application/json")
+	body := map[string]interface{}{
+		"variables": variables,
+		"features":  s.withFeatures(listFeatures),
+		"queryId":   queryID,
+	}
+
+	b, _ := json.Marshal(body)
+	setRequestBody(req, b)
+
+	var response listMutationResponse
+	err = s.RequestAPI(req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Errors) > 0 {
+		return nil, errors.New(response.Errors[0].Message)
+	}
+
+	if response.Data.List.IDStr == "" {
+		return nil, errors.New("unknown error")
+	}
+
+	return response.Data.List.parse(), nil
+}
+
+// CreateList creates a new list owned by the authenticated account.
+func (s *Scraper) CreateList(ctx context.Context, name string, description string, isPrivate bool) (*List, error) {
+	return s.listMutation(ctx, "EYg7JZU3A1eJ-wr2eygPHQ", "CreateList", map[string]interface{}{
+		"isPrivate":   isPrivate,
+		"name":        name,
+		"description": description,
+	})
+}
+
+// AddListMember adds user to the list owned by the authenticated account.
+func (s *Scraper) AddListMember(ctx context.Context, listID string, userID string) error {
+	_, err := s.listMutation(ctx, "P8tyfv2_0HzofrB5f6_ugw", "ListAddMember", map[string]interface{}{
+		"listId": listID,
+		"userId": userID,
+	})
+	return err
+}
+
+// RemoveListMember removes user from the list owned by the authenticated account.
+func (s *Scraper) RemoveListMember(ctx context.Context, listID string, userID string) error {
+	_, err := s.listMutation(ctx, "DBZowzFN492FFkBPBptCwg", "ListRemoveMember", map[string]interface{}{
+		"listId": listID,
+		"userId": userID,
+	})
+	return err
+}
@@ -0,0 +1,46 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetUserLists(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	lists, err := testScraper.GetUserLists(context.Background(), "Twitter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, list := range append(lists.Owned, lists.Memberships...) {
+		if list.ID == "" || list.Name == "" {
+			t.Errorf("Expected list ID and name, got %+v", list)
+		}
+	}
+	for _, list := range lists.Owned {
+		if list.OwnerUsername != "Twitter" {
+			t.Errorf("Expected owned list of Twitter, got owner %s", list.OwnerUsername)
+		}
+	}
+}
+
+func TestCreateListAndMembers(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	list, err := testScraper.CreateList(context.Background(), "scraper test", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.ID == "" || !list.IsPrivate {
+		t.Errorf("Expected private list with ID, got %+v", list)
+	}
+
+	if err := testScraper.AddListMember(context.Background(), list.ID, "783214"); err != nil {
+		t.Error(err)
+	}
+	if err := testScraper.RemoveListMember(context.Background(), list.ID, "783214"); err != nil {
+		t.Error(err)
+	}
+}
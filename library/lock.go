@@ -0,0 +1,80 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrLocked matches LockError.
+var ErrLocked = errors.New("locked by another run")
+
+type (
+	// FileLock is advisory lock of file shared by runs, such as session file,
+	// store or media manifest. It's a <file>.lock file with process ID, so it
+	// works on every platform, but lock of crashed run must be removed by hand.
+	FileLock struct {
+		path string
+	}
+
+	// LockError is returned when another run holds lock of file.
+	LockError struct {
+		Path string
+		// PID and Since of run holding lock, zero if lock file is unreadable.
+		PID   int
+		Since time.Time
+	}
+
+	lockInfo struct {
+		PID   int       `json:"pid"`
+		Since time.Time `json:"since"`
+	}
+)
+
+func (e *LockError) Error() string {
+	return fmt.Sprintf("%s is locked by process %d since %s, remove %s if no other run is active",
+		strings.TrimSuffix(e.Path, ".lock"), e.PID, e.Since.Format(time.RFC3339), e.Path)
+}
+
+// Is makes errors.Is(err, ErrLocked) true.
+func (e *LockError) Is(target error) bool {
+	return target == ErrLocked
+}
+
+// LockFile locks filename for this process until Unlock, LockError is returned
+// if another run holds it.
+func LockFile(filename string) (*FileLock, error) {
+	path := filename + ".lock"
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if os.IsExist(err) {
+		lockErr := &LockError{Path: path}
+		if data, err := os.ReadFile(path); err == nil {
+			var info lockInfo
+			if json.Unmarshal(data, &info) == nil {
+				lockErr.PID, lockErr.Since = info.PID, info.Since
+			}
+		}
+		return nil, lockErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.NewEncoder(f).Encode(lockInfo{PID: os.Getpid(), Since: time.Now().UTC()})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+	return &FileLock{path: path}, nil
+}
+
+// Unlock removes lock file.
+func (l *FileLock) Unlock() error {
+	return os.Remove(l.path)
+}
@@ -0,0 +1,48 @@
+package twitterscraper_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestLockFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "session.json")
+	lock, err := twitterscraper.LockFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = twitterscraper.LockFile(filename)
+	var lockErr *twitterscraper.LockError
+	if !errors.Is(err, twitterscraper.ErrLocked) || !errors.As(err, &lockErr) {
+		t.Fatalf("Expected ErrLocked, got %v", err)
+	}
+	if lockErr.PID != os.Getpid() || lockErr.Since.IsZero() {
+		t.Errorf("Expected lock of this process, got %d since %s", lockErr.PID, lockErr.Since)
+	}
+
+	if err := lock.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	lock, err = twitterscraper.LockFile(filename)
+	if err != nil {
+		t.Fatalf("Expected lock after unlock, got %v", err)
+	}
+	lock.Unlock()
+}
+
+func TestJSONLStoreLocked(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "tweets.ndjson")
+	store, err := twitterscraper.OpenJSONLStore(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := twitterscraper.OpenJSONLStore(filename); !errors.Is(err, twitterscraper.ErrLocked) {
+		t.Errorf("Expected ErrLocked, got %v", err)
+	}
+	store.Close()
+}
@@ -0,0 +1,72 @@
+package twitterscraper
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// logger receives log events of scraper, see WithLogger.
+type logger interface {
+	debug(msg string, args ...interface{})
+	warn(msg string, args ...interface{})
+}
+
+func (s *Scraper) logDebug(msg string, args ...interface{}) {
+	if s.logger != nil {
+		s.logger.debug(msg, args...)
+	}
+}
+
+func (s *Scraper) logWarn(msg string, args ...interface{}) {
+	if s.logger != nil {
+		s.logger.warn(msg, args...)
+	}
+}
+
+// logAlert is logWarn for warnings which must never be silent: without
+// WithLogger they are written to standard log.
+func (s *Scraper) logAlert(msg string, args ...interface{}) {
+	if s.logger != nil {
+		s.logger.warn(msg, args...)
+		return
+	}
+	for i := 0; i+1 < len(args); i += 2 {
+		msg += fmt.Sprintf(" %v=%v", args[i], args[i+1])
+	}
+	log.Println(msg)
+}
+
+// logRequest logs API request and its response at debug level.
+func (s *Scraper) logRequest(requestID string, req *http.Request, resp *http.Response, duration time.Duration, err error) {
+	if s.logger == nil {
+		return
+	}
+	args := []interface{}{
+		"request_id", requestID,
+		"method", req.Method,
+		"endpoint", endpointName(req.URL),
+		"url", req.URL.Redacted(),
+		"headers", redactHeader(req.Header),
+		"duration", duration,
+	}
+	if resp != nil {
+		args = append(args, "status", resp.StatusCode, "response_headers", redactHeader(resp.Header))
+	}
+	if err != nil {
+		args = append(args, "error", err)
+	}
+	s.logger.debug("twitterscraper: request", args...)
+}
+
+// redactHeader returns copy of header without credentials, see secretHeaders.
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for name := range redacted {
+		if isSecretHeader(name) {
+			redacted[name] = []string{"REDACTED"}
+		}
+	}
+	return redacted
+}
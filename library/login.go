@@ -0,0 +1,175 @@
+package twitterscraper
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maximum subtasks answered by LoginWithOptions before it gives up
+const maxLoginSubtasks = 20
+
+type (
+	// LoginOption answers challenges of login flow in LoginWithOptions.
+	LoginOption func(*loginOptions)
+
+	// LoginCodeFunc returns code for challenge, LoginAcid for code sent by
+	// email or LoginTwoFactorAuthChallenge for code of two-factor
+	// authentication.
+	LoginCodeFunc func(ctx context.Context, challenge string) (string, error)
+
+	loginOptions struct {
+		email      string
+		totpSecret string
+		code       LoginCodeFunc
+	}
+)
+
+// WithLoginEmail option answers email or alternate identifier confirmation
+// with email of account.
+func WithLoginEmail(email string) LoginOption {
+	return func(o *loginOptions) {
+		o.email = email
+	}
+}
+
+// WithLoginTOTP option answers two-factor authentication with code generated
+// from base32 secret of authenticator app.
+func WithLoginTOTP(secret string) LoginOption {
+	return func(o *loginOptions) {
+		o.totpSecret = secret
+	}
+}
+
+// WithLoginCode option answers challenges requiring code, e.g. code sent by
+// email, which is read from mailbox or asked from user by fn.
+func WithLoginCode(fn LoginCodeFunc) LoginOption {
+	return func(o *loginOptions) {
+		o.code = fn
+	}
+}
+
+// LoginWithOptions to Twitter with username and password, following login
+// flow and answering its email confirmation and two-factor authentication
+// challenges with options.
+func (s *Scraper) LoginWithOptions(ctx context.Context, username, password string, opts ...LoginOption) error {
+	var options loginOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	s.setBearerToken(bearerToken2)
+
+	err := s.GetGuestToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	randomDelay()
+
+	// flow start
+	info, err := s.getFlow(ctx, map[string]interface{}{
+		"flow_name": "login",
+		"input_flow_data": map[string]interface{}{
+			"flow_context": map[string]interface{}{
+				"debug_overrides": map[string]interface{}{},
+				"start_location":  map[string]interface{}{"location": "splash_screen"},
+			},
+		},
+	})
+	for i := 0; ; i++ {
+		if err != nil {
+			return err
+		}
+		if len(info.Errors) > 0 {
+			return fmt.Errorf("auth error (%d): %v", info.Errors[0].Code, info.Errors[0].Message)
+		}
+		if len(info.Subtasks) == 0 || info.Subtasks[0].SubtaskID == "LoginSuccessSubtask" {
+			break
+		}
+		if i == maxLoginSubtasks {
+			return fmt.Errorf("auth error: login flow not finished after %d subtasks", maxLoginSubtasks)
+		}
+
+		subtask := info.Subtasks[0]
+		input := map[string]interface{}{"subtask_id": subtask.SubtaskID}
+		switch subtask.SubtaskID {
+		case "LoginJsInstrumentationSubtask":
+			input["js_instrumentation"] = map[string]interface{}{"response": "{}", "link": "next_link"}
+		case "LoginEnterUserIdentifierSSO":
+			input["settings_list"] = map[string]interface{}{
+				"setting_responses": []map[string]interface{}{
+					{
+						"key":           "user_identifier",
+						"response_data": map[string]interface{}{"text_data": map[string]interface{}{"result": username}},
+					},
+				},
+				"link": "next_link",
+			}
+		case "LoginEnterPassword":
+			input["enter_password"] = map[string]interface{}{"password": password, "link": "next_link"}
+		case "AccountDuplicationCheck":
+			input["check_logged_in_account"] = map[string]interface{}{"link": "AccountDuplicationCheck_false"}
+		case "LoginEnterAlternateIdentifierSubtask", "LoginAcid", "LoginTwoFactorAuthChallenge":
+			text, err := options.answer(ctx, subtask.SubtaskID, subtask.EnterText.KeyboardType)
+			if err != nil {
+				return err
+			}
+			input["enter_text"] = map[string]interface{}{"text": text, "link": "next_link"}
+		default:
+			return fmt.Errorf("auth error: %v", subtask.SubtaskID)
+		}
+
+		randomDelay()
+
+		info, err = s.getFlow(ctx, map[string]interface{}{
+			"flow_token":     info.FlowToken,
+			"subtask_inputs": []map[string]interface{}{input},
+		})
+	}
+
+	s.isLogged = true
+	s.isOpenAccount = false
+	return nil
+}
+
+// answer returns text for confirmation challenge of login flow, keyboard is
+// email when challenge asks for email rather than code.
+func (o *loginOptions) answer(ctx context.Context, challenge, keyboard string) (string, error) {
+	if challenge == "LoginTwoFactorAuthChallenge" && o.totpSecret != "" {
+		return TOTP(o.totpSecret, time.Now())
+	}
+	alternateIdentifier := challenge == "LoginEnterAlternateIdentifierSubtask"
+	if challenge != "LoginTwoFactorAuthChallenge" && o.email != "" && (alternateIdentifier || keyboard == "email" || o.code == nil) {
+		return o.email, nil
+	}
+	if alternateIdentifier || o.code == nil {
+		return "", fmt.Errorf("confirmation data required for %v", challenge)
+	}
+	return o.code(ctx, challenge)
+}
+
+// TOTP returns 6-digit time-based one-time password of authenticator app for
+// base32 secret at time t.
+func TOTP(secret string, t time.Time) (string, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil {
+		return "", fmt.Errorf("invalid TOTP secret: %w", err)
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(t.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", code%1000000), nil
+}
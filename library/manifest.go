@@ -0,0 +1,145 @@
+package twitterscraper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// MediaManifestFile is name of manifest in media directory.
+const MediaManifestFile = "manifest.json"
+
+type (
+	// MediaManifest lists files of media directory with their size and
+	// checksum, so archive can be verified later.
+	MediaManifest struct {
+		Files []MediaFile `json:"files"`
+	}
+
+	// MediaFile of manifest, Name is relative to media directory.
+	MediaFile struct {
+		Name    string `json:"name"`
+		TweetID string `json:"tweet_id"`
+		MediaID string `json:"media_id"`
+		URL     string `json:"url"`
+		Size    int64  `json:"size"`
+		SHA256  string `json:"sha256"`
+		// RunID of scraper downloaded file.
+		RunID string `json:"run_id,omitempty"`
+	}
+
+	// MediaIssue is a file of manifest failed verification.
+	MediaIssue struct {
+		Name string
+		Err  error
+	}
+)
+
+// ReadMediaManifest reads manifest of media directory, directory without
+// manifest has empty one.
+func ReadMediaManifest(dir string) (*MediaManifest, error) {
+	manifest := &MediaManifest{}
+	data, err := os.ReadFile(filepath.Join(dir, MediaManifestFile))
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", MediaManifestFile, err)
+	}
+	return manifest, nil
+}
+
+// VerifyMedia checks that every file of manifest exists and has the same
+// size and checksum.
+func VerifyMedia(dir string) ([]MediaIssue, error) {
+	manifest, err := ReadMediaManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []MediaIssue
+	for _, file := range manifest.Files {
+		size, sum, err := hashFile(filepath.Join(dir, file.Name))
+		switch {
+		case err != nil:
+			issues = append(issues, MediaIssue{Name: file.Name, Err: err})
+		case size != file.Size:
+			issues = append(issues, MediaIssue{Name: file.Name, Err: fmt.Errorf("size %d, expected %d", size, file.Size)})
+		case sum != file.SHA256:
+			issues = append(issues, MediaIssue{Name: file.Name, Err: fmt.Errorf("sha256 %s, expected %s", sum, file.SHA256)})
+		}
+	}
+	return issues, nil
+}
+
+// add replaces entries of files with the same name, files are kept sorted.
+func (manifest *MediaManifest) add(files []MediaFile) {
+	byName := make(map[string]MediaFile, len(manifest.Files)+len(files))
+	for _, file := range manifest.Files {
+		byName[file.Name] = file
+	}
+	for _, file := range files {
+		byName[file.Name] = file
+	}
+
+	manifest.Files = manifest.Files[:0]
+	for _, file := range byName {
+		manifest.Files = append(manifest.Files, file)
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		return manifest.Files[i].Name < manifest.Files[j].Name
+	})
+}
+
+// write replaces manifest of directory, reader never sees it half written.
+func (manifest *MediaManifest) write(dir string) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	filename := filepath.Join(dir, MediaManifestFile)
+	if err := os.WriteFile(filename+".part", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(filename+".part", filename)
+}
+
+func newMediaFile(download mediaDownload, mediaURL string) (*MediaFile, error) {
+	size, sum, err := hashFile(download.filename)
+	if err != nil {
+		return nil, err
+	}
+	return &MediaFile{
+		Name:    filepath.Base(download.filename),
+		TweetID: download.tweetID,
+		MediaID: download.mediaID,
+		URL:     mediaURL,
+		Size:    size,
+		SHA256:  sum,
+		RunID:   download.runID,
+	}, nil
+}
+
+// hashFile returns size and hex sha256 of file.
+func hashFile(filename string) (int64, string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
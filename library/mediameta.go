@@ -0,0 +1,175 @@
+package twitterscraper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"strings"
+	"time"
+)
+
+// maxExcerptRunes limits tweet text embedded into media.
+const maxExcerptRunes = 200
+
+var (
+	jpegXMPNamespace = []byte("http://ns.adobe.com/xap/1.0/\x00")
+	pngSignature     = []byte("\x89PNG\r\n\x1a\n")
+	pngXMPKeyword    = "XML:com.adobe.xmp"
+	// mp4XMPUUID is type of uuid box with XMP in ISO base media files.
+	mp4XMPUUID = []byte{0xbe, 0x7a, 0xcf, 0xcb, 0x97, 0xa9, 0x42, 0xe8, 0x9c, 0x71, 0x99, 0x94, 0x91, 0xe3, 0xaf, 0xac}
+)
+
+// mediaMetadata of tweet embedded into downloaded media.
+type mediaMetadata struct {
+	Author  string
+	URL     string
+	Date    time.Time
+	Excerpt string
+}
+
+func newMediaMetadata(tweet *Tweet) *mediaMetadata {
+	excerpt := []rune(tweet.Text)
+	if len(excerpt) > maxExcerptRunes {
+		excerpt = excerpt[:maxExcerptRunes]
+	}
+	author := "@" + tweet.Username
+	if tweet.Name != "" {
+		author = tweet.Name + " (" + author + ")"
+	}
+	return &mediaMetadata{
+		Author:  author,
+		URL:     tweet.PermanentURL,
+		Date:    time.Unix(tweet.Timestamp, 0).UTC(),
+		Excerpt: string(excerpt),
+	}
+}
+
+// xmp returns XMP packet with Dublin Core creator, description and source.
+func (meta *mediaMetadata) xmp() []byte {
+	escape := func(s string) string {
+		var b strings.Builder
+		xml.EscapeText(&b, []byte(s))
+		return b.String()
+	}
+	return []byte(`<?xpacket begin="` + "\ufeff" + `" id="W5M0MpCehiHzreSzNTczkc9d"?>` +
+		`<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` +
+		`<rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:xmp="http://ns.adobe.com/xap/1.0/">` +
+		`<dc:creator><rdf:Seq><rdf:li>` + escape(meta.Author) + `</rdf:li></rdf:Seq></dc:creator>` +
+		`<dc:description><rdf:Alt><rdf:li xml:lang="x-default">` + escape(meta.Excerpt) + `</rdf:li></rdf:Alt></dc:description>` +
+		`<dc:source>` + escape(meta.URL) + `</dc:source>` +
+		`<xmp:CreateDate>` + meta.Date.Format(time.RFC3339) + `</xmp:CreateDate>` +
+		`</rdf:Description></rdf:RDF></x:xmpmeta><?xpacket end="w"?>`)
+}
+
+// embedMetadata writes XMP into JPEG, PNG or MP4 file, other formats and
+// images already having XMP are left as is.
+func embedMetadata(filename string, meta *mediaMetadata) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	header := make([]byte, 12)
+	n, _ := f.Read(header)
+	f.Close()
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, []byte{0xff, 0xd8}):
+		return rewriteFile(filename, func(data []byte) ([]byte, error) { return embedJPEG(data, meta.xmp()) })
+	case bytes.HasPrefix(header, pngSignature):
+		return rewriteFile(filename, func(data []byte) ([]byte, error) { return embedPNG(data, meta.xmp()) })
+	case len(header) >= 8 && string(header[4:8]) == "ftyp":
+		return embedMP4(filename, meta.xmp())
+	}
+	return nil
+}
+
+// embedJPEG inserts APP1 XMP segment after JFIF and Exif segments.
+func embedJPEG(data, xmp []byte) ([]byte, error) {
+	if bytes.Contains(data, jpegXMPNamespace) {
+		return data, nil
+	}
+	payload := append(append([]byte{}, jpegXMPNamespace...), xmp...)
+	if len(payload)+2 > 0xffff {
+		return nil, fmt.Errorf("XMP of %d bytes doesn't fit JPEG segment", len(payload))
+	}
+
+	pos := 2
+	for pos+4 <= len(data) && data[pos] == 0xff && (data[pos+1] == 0xe0 || data[pos+1] == 0xe1) {
+		pos += 2 + int(binary.BigEndian.Uint16(data[pos+2:]))
+	}
+	if pos > len(data) {
+		return nil, fmt.Errorf("invalid JPEG segment")
+	}
+
+	segment := make([]byte, 4, 4+len(payload))
+	segment[0], segment[1] = 0xff, 0xe1
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := make([]byte, 0, len(data)+len(segment))
+	out = append(out, data[:pos]...)
+	out = append(out, segment...)
+	return append(out, data[pos:]...), nil
+}
+
+// embedPNG inserts iTXt XMP chunk after IHDR chunk.
+func embedPNG(data, xmp []byte) ([]byte, error) {
+	if bytes.Contains(data, []byte(pngXMPKeyword)) {
+		return data, nil
+	}
+	// signature and IHDR of 13 bytes with length, type and CRC
+	pos := len(pngSignature) + 8 + 13 + 4
+	if len(data) < pos || string(data[len(pngSignature)+4:len(pngSignature)+8]) != "IHDR" {
+		return nil, fmt.Errorf("invalid PNG header")
+	}
+
+	// keyword, compression flag and method, empty language and translated keyword
+	chunkData := append([]byte(pngXMPKeyword), 0, 0, 0, 0, 0)
+	chunkData = append(chunkData, xmp...)
+	chunk := make([]byte, 8, 12+len(chunkData))
+	binary.BigEndian.PutUint32(chunk, uint32(len(chunkData)))
+	copy(chunk[4:], "iTXt")
+	chunk = append(chunk, chunkData...)
+	crc := make([]byte, 4)
+	binary.BigEndian.PutUint32(crc, crc32.ChecksumIEEE(chunk[4:]))
+	chunk = append(chunk, crc...)
+
+	out := make([]byte, 0, len(data)+len(chunk))
+	out = append(out, data[:pos]...)
+	out = append(out, chunk...)
+	return append(out, data[pos:]...), nil
+}
+
+// embedMP4 appends top level XMP uuid box, offsets of other boxes don't change.
+func embedMP4(filename string, xmp []byte) error {
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return err
+	}
+	box := make([]byte, 8, 24+len(xmp))
+	binary.BigEndian.PutUint32(box, uint32(24+len(xmp)))
+	copy(box[4:], "uuid")
+	box = append(box, mp4XMPUUID...)
+	box = append(box, xmp...)
+	if _, err := f.Write(box); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func rewriteFile(filename string, rewrite func(data []byte) ([]byte, error)) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	data, err = rewrite(data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
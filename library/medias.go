@@ -7,26 +7,26 @@ import (
 
 // GetTweets returns channel with tweets for a given user.
 func (s *Scraper) GetMediaTweets(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchMediaTweets)
+	return s.getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchMediaTweets)
 }
 
 // FetchMediaTweets gets tweets with medias for a given user, via the Twitter frontend API.
-func (s *Scraper) FetchMediaTweets(user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-	userID, err := s.GetUserIDByScreenName(user)
+func (s *Scraper) FetchMediaTweets(ctx context.Context, user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	userID, err := s.GetUserIDByScreenName(ctx, user)
 	if err != nil {
 		return nil, "", err
 	}
 
-	return s.FetchMediaTweetsByUserID(userID, maxTweetsNbr, cursor)
+	return s.FetchMediaTweetsByUserID(ctx, userID, maxTweetsNbr, cursor)
 }
 
 // FetchMediaTweetsByUserID gets tweets with medias for a given userID, via the Twitter frontend GraphQL API.
-func (s *Scraper) FetchMediaTweetsByUserID(userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+func (s *Scraper) FetchMediaTweetsByUserID(ctx context.Context, userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxTweetsNbr > 200 {
 		maxTweetsNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/2tLOJWwGuCTytDrGBg8VwQ/UserMedia")
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/2tLOJWwGuCTytDrGBg8VwQ/UserMedia")
 	if err != nil {
 		return nil, "", err
 	}
@@ -70,15 +70,24 @@ func (s *Scraper) FetchMediaTweetsByUserID(userID string, maxTweetsNbr int, curs
 
 	query := url.Values{}
 	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
 	req.URL.RawQuery = query.Encode()
 
 	var timeline timelineV2
-	err = s.RequestAPI(req, &timeline)
+	meta, err := s.requestAPIMeta(req, &timeline)
 	if err != nil {
-		return nil, "", err
+		return nil, "", s.checkProtected(ctx, userID, err)
 	}
 
 	tweets, nextCursor := timeline.parseTweets()
+	s.setProvenance(tweets, meta, EndpointMedia, cursor)
+	if tweets, err = s.filterTweets(tweets); err != nil {
+		return nil, "", err
+	}
+	if cursor == "" && len(tweets) == 0 {
+		if err := s.checkProtected(ctx, userID, nil); err != nil {
+			return nil, "", err
+		}
+	}
 	return tweets, nextCursor, nil
 }
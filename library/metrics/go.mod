@@ -0,0 +1,10 @@
+module github.com/imperatrona/twitter-scraper/metrics
+
+go 1.16
+
+require (
+	github.com/imperatrona/twitter-scraper v0.0.14
+	github.com/prometheus/client_golang v1.12.2
+)
+
+replace github.com/imperatrona/twitter-scraper => ../
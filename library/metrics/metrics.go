@@ -0,0 +1,100 @@
+// Package metrics exports requests of twitterscraper.Scraper as Prometheus
+// metrics. It's a separate module, so scraper doesn't depend on Prometheus
+// client.
+package metrics
+
+import (
+	"strconv"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "twitter_scraper"
+
+// Collector counts requests of scraper by endpoint, account and status,
+// measures their duration and keeps rate limit remaining per endpoint. Error
+// rate is summed rate of errors_total divided by summed rate of requests_total.
+type Collector struct {
+	requests  *prometheus.CounterVec
+	errors    *prometheus.CounterVec
+	retries   *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+	remaining *prometheus.GaugeVec
+}
+
+// NewCollector creates collector of requests made by scraper from now on.
+func NewCollector(scraper *twitterscraper.Scraper) *Collector {
+	c := &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "API requests by endpoint, account and status code, 0 for requests failed without response.",
+		}, []string{"endpoint", "account", "status"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "errors_total",
+			Help:      "Failed API requests by endpoint and account.",
+		}, []string{"endpoint", "account"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Retries of failed API requests by endpoint.",
+		}, []string{"endpoint"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of API requests by endpoint.",
+			Buckets:   []float64{.1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"endpoint"}),
+		remaining: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_remaining",
+			Help:      "Requests remaining in rate limit window by endpoint, as of the last response.",
+		}, []string{"endpoint"}),
+	}
+
+	scraper.OnRequest(c.observe)
+	scraper.OnRetry(func(info twitterscraper.RetryInfo) {
+		c.retries.WithLabelValues(info.Endpoint).Inc()
+	})
+	return c
+}
+
+// Register creates collector of scraper and registers it.
+func Register(scraper *twitterscraper.Scraper, registerer prometheus.Registerer) (*Collector, error) {
+	c := NewCollector(scraper)
+	if err := registerer.Register(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Collector) observe(info twitterscraper.RequestInfo) {
+	c.requests.WithLabelValues(info.Endpoint, info.Account, strconv.Itoa(info.StatusCode)).Inc()
+	if info.Error != nil {
+		c.errors.WithLabelValues(info.Endpoint, info.Account).Inc()
+	}
+	c.duration.WithLabelValues(info.Endpoint).Observe(info.Duration.Seconds())
+	if info.RateLimitRemaining >= 0 {
+		c.remaining.WithLabelValues(info.Endpoint).Set(float64(info.RateLimitRemaining))
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.errors.Describe(ch)
+	c.retries.Describe(ch)
+	c.duration.Describe(ch)
+	c.remaining.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.errors.Collect(ch)
+	c.retries.Collect(ch)
+	c.duration.Collect(ch)
+	c.remaining.Collect(ch)
+}
@@ -0,0 +1,55 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+	"github.com/imperatrona/twitter-scraper/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/UserByScreenName") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-Rate-Limit-Remaining", "42")
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	scraper := twitterscraper.New()
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "token", CSRFToken: "ct0"})
+	registry := prometheus.NewRegistry()
+	if _, err := metrics.Register(scraper, registry); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"/i/api/graphql/abc/UserTweets", "/i/api/graphql/abc/UserTweets", "/i/api/graphql/def/UserByScreenName"} {
+		req, _ := http.NewRequest("GET", ts.URL+path, nil)
+		scraper.RequestAPI(req, nil)
+	}
+
+	expected := `
+# HELP twitter_scraper_errors_total Failed API requests by endpoint and account.
+# TYPE twitter_scraper_errors_total counter
+twitter_scraper_errors_total{account="account-1",endpoint="UserByScreenName"} 1
+# HELP twitter_scraper_rate_limit_remaining Requests remaining in rate limit window by endpoint, as of the last response.
+# TYPE twitter_scraper_rate_limit_remaining gauge
+twitter_scraper_rate_limit_remaining{endpoint="UserTweets"} 42
+# HELP twitter_scraper_requests_total API requests by endpoint, account and status code, 0 for requests failed without response.
+# TYPE twitter_scraper_requests_total counter
+twitter_scraper_requests_total{account="account-1",endpoint="UserByScreenName",status="404"} 1
+twitter_scraper_requests_total{account="account-1",endpoint="UserTweets",status="200"} 2
+`
+	err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"twitter_scraper_errors_total", "twitter_scraper_rate_limit_remaining", "twitter_scraper_requests_total")
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,117 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ModerationAction applied to users by ModerateUsers.
+type ModerationAction int
+
+const (
+	// ActionBlock blocks user
+	ActionBlock ModerationAction = iota
+	// ActionUnblock unblocks user
+	ActionUnblock
+	// ActionMute mutes user
+	ActionMute
+	// ActionUnmute unmutes user
+	ActionUnmute
+)
+
+var moderationEndpoints = map[ModerationAction]string{
+	ActionBlock:   "https://twitter.com/i/api/1.1/blocks/create.json",
+	ActionUnblock: "https://twitter.com/i/api/1.1/blocks/destroy.json",
+	ActionMute:    "https://twitter.com/i/api/1.1/mutes/users/create.json",
+	ActionUnmute:  "https://twitter.com/i/api/1.1/mutes/users/destroy.json",
+}
+
+// ModerationInterval is a minimal pause between requests of ModerateUsers,
+// to stay under write endpoints rate limits.
+var ModerationInterval = 2 * time.Second
+
+// ModerationResult of action for one user.
+type ModerationResult struct {
+	UserID string
+	Error  error
+}
+
+// BlockUser blocks user by ID.
+func (s *Scraper) BlockUser(ctx context.Context, userID string) error {
+	return s.moderateUser(ctx, ActionBlock, userID)
+}
+
+// UnblockUser unblocks user by ID.
+func (s *Scraper) UnblockUser(ctx context.Context, userID string) error {
+	return s.moderateUser(ctx, ActionUnblock, userID)
+}
+
+// MuteUser mutes user by ID.
+func (s *Scraper) MuteUser(ctx context.Context, userID string) error {
+	return s.moderateUser(ctx, ActionMute, userID)
+}
+
+// UnmuteUser unmutes user by ID.
+func (s *Scraper) UnmuteUser(ctx context.Context, userID string) error {
+	return s.moderateUser(ctx, ActionUnmute, userID)
+}
+
+// ModerateUsers applies action to every user ID, for example read with ReadIDsFile.
+// Requests are sent one by one with ModerationInterval pause. Errors of single users
+// don't stop processing, but it's aborted when rate limit is reached, so the rest
+// of IDs can be processed later.
+func (s *Scraper) ModerateUsers(ctx context.Context, action ModerationAction, userIDs []string) <-chan *ModerationResult {
+	channel := make(chan *ModerationResult)
+	go func() {
+		defer close(channel)
+		for i, userID := range userIDs {
+			if i > 0 {
+				select {
+				case <-ctx.Done():
+					channel <- &ModerationResult{UserID: userID, Error: ctx.Err()}
+					return
+				case <-time.After(ModerationInterval):
+				}
+			}
+
+			err := s.moderateUser(ctx, action, userID)
+			channel <- &ModerationResult{UserID: userID, Error: err}
+
+			if isRateLimit(err) {
+				return
+			}
+		}
+	}()
+	return channel
+}
+
+func (s *Scraper) moderateUser(ctx context.Context, action ModerationAction, userID string) error {
+	endpoint, ok := moderationEndpoints[action]
+	if !ok {
+		return fmt.Errorf("unknown moderation action %d", action)
+	}
+
+	form := url.Values{}
+	form.Set("user_id", userID)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var user legacyUser
+	err = s.RequestAPI(req, &user)
+	if err != nil {
+		return err
+	}
+
+	if user.IDStr != userID {
+		return errors.New("unknown error")
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestMuteAndUnmuteUser(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	if err := testScraper.MuteUser(context.Background(), "783214"); err != nil {
+		t.Fatal(err)
+	}
+	if err := testScraper.UnmuteUser(context.Background(), "783214"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestModerateUsers(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	ids := []string{"783214", "17874544"}
+	for _, action := range []twitterscraper.ModerationAction{twitterscraper.ActionMute, twitterscraper.ActionUnmute} {
+		results := 0
+		for result := range testScraper.ModerateUsers(context.Background(), action, ids) {
+			if result.Error != nil {
+				t.Errorf("User %s: %v", result.UserID, result.Error)
+			}
+			results++
+		}
+		if results != len(ids) {
+			t.Errorf("Expected %d results, got %d", len(ids), results)
+		}
+	}
+}
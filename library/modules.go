@@ -0,0 +1,149 @@
+package twitterscraper
+
+import "strings"
+
+// Kinds of OtherModule.
+const (
+	ModuleAISummary = "ai-summary"
+)
+
+// aiModuleMarkers are parts of entry IDs and item types of AI generated
+// summaries, e.g. Grok summary of trend or search results.
+var aiModuleMarkers = []string{"grok", "aisummary", "ai-summary", "ai_summary"}
+
+type (
+	// OtherModule is timeline module without tweets the scraper returns,
+	// e.g. AI summary of search results. Its content is skipped, so it never
+	// fails parsing of page.
+	OtherModule struct {
+		// Kind is ModuleAISummary.
+		Kind    string
+		EntryID string
+		// Type is item type of module as reported by API.
+		Type       string
+		Title      string
+		Provenance *Provenance
+	}
+
+	moduleHeader struct {
+		Text string `json:"text"`
+	}
+
+	// pageModules are modules of page reported to hooks.
+	pageModules struct {
+		recommendations []*Recommendation
+		others          []*OtherModule
+	}
+)
+
+// OnOtherModule adds hook called with every AI summary module skipped on
+// pages of home timelines, user timelines and search. Hooks are called
+// synchronously, so they should be fast.
+func (s *Scraper) OnOtherModule(hook func(*OtherModule)) *Scraper {
+	s.stats.mu.Lock()
+	s.stats.moduleHooks = append(s.stats.moduleHooks, hook)
+	s.stats.mu.Unlock()
+	return s
+}
+
+// reportModules calls recommendation and other module hooks with modules of
+// page.
+func (s *Scraper) reportModules(modules pageModules, meta requestMeta, endpoint, cursor string) {
+	s.stats.mu.Lock()
+	recommendHooks, moduleHooks := s.stats.recommendHooks, s.stats.moduleHooks
+	s.stats.mu.Unlock()
+	if len(recommendHooks) == 0 && len(moduleHooks) == 0 {
+		return
+	}
+
+	provenance := s.newProvenance(meta, endpoint, cursor)
+	for _, recommendation := range modules.recommendations {
+		p := provenance
+		recommendation.Provenance = &p
+		for _, hook := range recommendHooks {
+			hook(recommendation)
+		}
+	}
+	for _, module := range modules.others {
+		p := provenance
+		module.Provenance = &p
+		for _, hook := range moduleHooks {
+			hook(module)
+		}
+	}
+}
+
+func (modules *pageModules) add(entryID, itemType string, header moduleHeader, items []item) {
+	if aiType, ok := aiModuleType(entryID, itemType, items); ok {
+		modules.others = append(modules.others, &OtherModule{
+			Kind:    ModuleAISummary,
+			EntryID: entryID,
+			Type:    aiType,
+			Title:   header.Text,
+		})
+		return
+	}
+	if recommendation := parseRecommendation(entryID, header, items); recommendation != nil {
+		modules.recommendations = append(modules.recommendations, recommendation)
+	}
+}
+
+// aiModuleType returns item type of AI summary entry, ok is false for other
+// entries.
+func aiModuleType(entryID, itemType string, items []item) (string, bool) {
+	if isAIMarked(itemType) {
+		return itemType, true
+	}
+	for _, item := range items {
+		if isAIMarked(item.Item.ItemContent.ItemType) {
+			return item.Item.ItemContent.ItemType, true
+		}
+	}
+	return itemType, isAIMarked(entryID)
+}
+
+// isAIModule checks if entry is AI summary, which tweets are not parsed of.
+func isAIModule(entryID, itemType string, items []item) bool {
+	_, ok := aiModuleType(entryID, itemType, items)
+	return ok
+}
+
+func isAIMarked(value string) bool {
+	value = strings.ToLower(value)
+	for _, marker := range aiModuleMarkers {
+		if strings.Contains(value, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func (timeline *timelineV2) modules() pageModules {
+	var modules pageModules
+	for _, instruction := range timeline.Data.User.Result.TimelineV2.Timeline.Instructions {
+		for _, entry := range instruction.Entries {
+			modules.add(entry.EntryID, entry.Content.ItemContent.ItemType, entry.Content.Header, entry.Content.Items)
+		}
+	}
+	return modules
+}
+
+func (timeline *homeTimeline) modules() pageModules {
+	var modules pageModules
+	for _, instruction := range timeline.Data.Home.HomeTimeline.Instructions {
+		for _, entry := range instruction.Entries {
+			modules.add(entry.EntryId, entry.Content.ItemContent.ItemType, entry.Content.Header, entry.Content.Items)
+		}
+	}
+	return modules
+}
+
+func (timeline *searchTimeline) modules() pageModules {
+	var modules pageModules
+	for _, instruction := range timeline.Data.SearchByRawQuery.SearchTimeline.Timeline.Instructions {
+		for _, entry := range instruction.Entries {
+			modules.add(entry.EntryID, entry.Content.ItemContent.ItemType, entry.Content.Header, entry.Content.Items)
+		}
+	}
+	return modules
+}
@@ -0,0 +1,44 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestOnOtherModule(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	var mu sync.Mutex
+	var modules []*twitterscraper.OtherModule
+	testScraper.OnOtherModule(func(m *twitterscraper.OtherModule) {
+		mu.Lock()
+		modules = append(modules, m)
+		mu.Unlock()
+	})
+
+	// AI summaries are shown on top results of trending queries
+	tweets, _, err := testScraper.FetchSearchTweets(context.Background(), "news", 20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tweet := range tweets {
+		if tweet.ID == "" {
+			t.Error("Expected tweet ID")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, m := range modules {
+		if m.Kind != twitterscraper.ModuleAISummary || m.EntryID == "" {
+			t.Errorf("Unexpected module %+v", m)
+		}
+		if m.Provenance == nil || m.Provenance.Endpoint != twitterscraper.EndpointSearch {
+			t.Errorf("Expected search provenance of module %s", m.EntryID)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package twitterscraper
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// maxMonitorPages limits pages of timeline fetched by one poll.
+const maxMonitorPages = 5
+
+// MonitorUser polls timeline of user every interval and sends only tweets
+// newer than the last poll, oldest first, up to 5 pages per poll. The first
+// poll only remembers the newest tweet, if timeline is empty every later tweet
+// is sent. Since ID is kept by scraper, so monitor started again for the same
+// user continues where the previous one stopped. Errors are sent and polling
+// goes on, channel is closed when context is done.
+func (s *Scraper) MonitorUser(ctx context.Context, username string, interval time.Duration) <-chan *TweetResult {
+	channel := make(chan *TweetResult)
+	go func() {
+		defer close(channel)
+		key := strings.ToLower(username)
+		for {
+			since, _ := s.monitorSince.Load(key)
+			sinceID, _ := since.(string)
+			tweets, newest, err := s.pollTimeline(ctx, username, sinceID)
+			if newest != "" {
+				s.monitorSince.Store(key, newest)
+			}
+			if err == nil {
+				s.monitorPolled.Store(key, time.Now())
+			}
+
+			for i := len(tweets) - 1; i >= 0; i-- {
+				select {
+				case channel <- &TweetResult{Tweet: *tweets[i]}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil && ctx.Err() == nil {
+				select {
+				case channel <- &TweetResult{Error: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if err := sleepContext(ctx, interval); err != nil {
+				return
+			}
+		}
+	}()
+	return channel
+}
+
+// LastMonitorPoll returns time of the last successful poll of MonitorUser for
+// user, zero if there was none.
+func (s *Scraper) LastMonitorPoll(username string) time.Time {
+	polled, _ := s.monitorPolled.Load(strings.ToLower(username))
+	t, _ := polled.(time.Time)
+	return t
+}
+
+// pollTimeline returns tweets newer than sinceID from the newest and ID of
+// the newest one, without sinceID only ID of the newest tweet is returned,
+// "0" for empty timeline.
+// Pinned tweet is skipped as it is not in order of timeline.
+func (s *Scraper) pollTimeline(ctx context.Context, username, sinceID string) ([]*Tweet, string, error) {
+	var tweets []*Tweet
+	newest := sinceID
+	var cursor string
+	for page := 0; page < maxMonitorPages; page++ {
+		batch, next, err := s.FetchTweets(ctx, username, 20, cursor)
+		if err != nil {
+			// since ID is kept, so the next poll gets missed tweets again
+			return nil, sinceID, err
+		}
+		for _, tweet := range batch {
+			if tweet.IsPin {
+				continue
+			}
+			if sinceID == "" {
+				// the first poll only needs the newest tweet
+				return nil, tweet.ID, nil
+			}
+			if compareIDs(tweet.ID, sinceID) <= 0 {
+				return tweets, newest, nil
+			}
+			tweets = append(tweets, tweet)
+			if compareIDs(tweet.ID, newest) > 0 {
+				newest = tweet.ID
+			}
+		}
+		if len(batch) == 0 || next == "" || next == cursor {
+			break
+		}
+		cursor = next
+	}
+	if sinceID == "" {
+		// empty timeline is the baseline, so its first tweet is sent
+		return nil, "0", nil
+	}
+	return tweets, newest, nil
+}
@@ -0,0 +1,65 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestMonitorUser(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the first poll only remembers the newest tweet, old tweets are not sent
+	for tweet := range testScraper.MonitorUser(ctx, "x", time.Second) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		if time.Since(time.Unix(tweet.Timestamp, 0)) > time.Minute {
+			t.Errorf("Expected only new tweets, got %s from %v", tweet.ID, tweet.TimeParsed)
+		}
+	}
+}
+
+func TestMonitorUserEmptyTimeline(t *testing.T) {
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/UserByScreenName"):
+			w.Write([]byte(`{"data":{"user":{"result":{"rest_id":"200","legacy":{"screen_name":"newcomer"}}}}}`))
+		case strings.HasSuffix(r.URL.Path, "/UserTweets"):
+			polls++
+			entries := ""
+			if polls > 1 {
+				entries = `{"entryId":"tweet-1","content":{"itemContent":{"tweet_results":{"result":{"__typename":"Tweet",
+					"legacy":{"id_str":"1","user_id_str":"200","full_text":"first"}}}}}}`
+			}
+			w.Write([]byte(`{"data":{"user":{"result":{"timeline_v2":{"timeline":{"instructions":[{"entries":[` + entries + `]}]}}}}}}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer ts.Close()
+	target, _ := url.Parse(ts.URL)
+
+	scraper := twitterscraper.New()
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "token", CSRFToken: "csrf"})
+	scraper.BeforeRequest(func(req *http.Request) {
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+	})
+
+	// the first tweet of user without tweets at the first poll is sent
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tweet := <-scraper.MonitorUser(ctx, "newcomer", 10*time.Millisecond)
+	if tweet == nil || tweet.Error != nil || tweet.ID != "1" {
+		t.Errorf("Expected the first tweet, got %+v", tweet)
+	}
+}
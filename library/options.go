@@ -0,0 +1,240 @@
+package twitterscraper
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures Scraper in New and NewWithOptions.
+// Options are applied in order, so set client timeout before proxy.
+type Option func(*Scraper) error
+
+// WithProxy option, see SetProxy.
+func WithProxy(proxyAddr string) Option {
+	return func(s *Scraper) error {
+		return s.SetProxy(proxyAddr)
+	}
+}
+
+// WithAccounts option adds accounts to pool, see AddAccount.
+func WithAccounts(tokens ...AuthToken) Option {
+	return func(s *Scraper) error {
+		for _, token := range tokens {
+			s.AddAccount(token)
+		}
+		return nil
+	}
+}
+
+// WithCursorStore option, see SetCursorStore.
+func WithCursorStore(store CursorStore) Option {
+	return func(s *Scraper) error {
+		return s.SetCursorStore(store)
+	}
+}
+
+// WithDelay option add delay between API requests (in seconds).
+func WithDelay(seconds int64) Option {
+	return func(s *Scraper) error {
+		s.WithDelay(seconds)
+		return nil
+	}
+}
+
+// WithReplies option enable/disable load timeline with tweet replies.
+func WithReplies(b bool) Option {
+	return func(s *Scraper) error {
+		s.WithReplies(b)
+		return nil
+	}
+}
+
+// WithClientTimeout option set http client timeout.
+func WithClientTimeout(timeout time.Duration) Option {
+	return func(s *Scraper) error {
+		s.WithClientTimeout(timeout)
+		return nil
+	}
+}
+
+// WithUserAgent option set User-Agent header of all requests.
+func WithUserAgent(userAgent string) Option {
+	return func(s *Scraper) error {
+		s.SetUserAgent(userAgent)
+		return nil
+	}
+}
+
+// WithSearchMode option set search mode.
+func WithSearchMode(mode SearchMode) Option {
+	return func(s *Scraper) error {
+		s.SetSearchMode(mode)
+		return nil
+	}
+}
+
+// WithPinnedMode option set handling of pinned tweet.
+func WithPinnedMode(mode PinnedMode) Option {
+	return func(s *Scraper) error {
+		s.SetPinnedMode(mode)
+		return nil
+	}
+}
+
+// WithAdsMode option set handling of promoted tweets.
+func WithAdsMode(mode AdsMode) Option {
+	return func(s *Scraper) error {
+		s.SetAdsMode(mode)
+		return nil
+	}
+}
+
+// WithPoliteness option, see SetPoliteness.
+func WithPoliteness(p Politeness) Option {
+	return func(s *Scraper) error {
+		s.SetPoliteness(p)
+		return nil
+	}
+}
+
+// WithRunID option set ID of run, see SetRunID.
+func WithRunID(id string) Option {
+	return func(s *Scraper) error {
+		s.SetRunID(id)
+		return nil
+	}
+}
+
+// WithRetry option, see SetRetry.
+func WithRetry(maxAttempts int, baseDelay, maxDelay time.Duration) Option {
+	return func(s *Scraper) error {
+		s.SetRetry(maxAttempts, baseDelay, maxDelay)
+		return nil
+	}
+}
+
+// WithCookies option restore session from cookies.
+func WithCookies(cookies []*http.Cookie) Option {
+	return func(s *Scraper) error {
+		s.SetCookies(cookies)
+		return nil
+	}
+}
+
+// WithAuthToken option authenticate with auth_token and ct0 cookies.
+func WithAuthToken(token AuthToken) Option {
+	return func(s *Scraper) error {
+		s.SetAuthToken(token)
+		return nil
+	}
+}
+
+// WithFeatures option overrides GraphQL feature flags, see SetFeature.
+func WithFeatures(features map[string]interface{}) Option {
+	return func(s *Scraper) error {
+		s.WithFeatures(features)
+		return nil
+	}
+}
+
+// WithStrictParsing option enable/disable strict parsing mode.
+func WithStrictParsing(b bool) Option {
+	return func(s *Scraper) error {
+		s.WithStrictParsing(b)
+		return nil
+	}
+}
+
+// WithFailOnParseError option fail the whole page on ParseError.
+func WithFailOnParseError(b bool) Option {
+	return func(s *Scraper) error {
+		s.WithFailOnParseError(b)
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify option, see SetInsecureSkipVerify.
+func WithInsecureSkipVerify(b bool) Option {
+	return func(s *Scraper) error {
+		return s.SetInsecureSkipVerify(b)
+	}
+}
+
+// WithRootCA option, see SetRootCA.
+func WithRootCA(certFile string) Option {
+	return func(s *Scraper) error {
+		return s.SetRootCA(certFile)
+	}
+}
+
+// WithTweetTransform option, see AddTweetTransform.
+func WithTweetTransform(transform TweetTransform) Option {
+	return func(s *Scraper) error {
+		s.AddTweetTransform(transform)
+		return nil
+	}
+}
+
+// WithRateLimitStrategy option, see RateLimitStrategy.
+func WithRateLimitStrategy(strategy RateLimitStrategy) Option {
+	return func(s *Scraper) error {
+		s.WithRateLimitStrategy(strategy)
+		return nil
+	}
+}
+
+// WithEndpointTimeout option, see SetEndpointTimeout.
+func WithEndpointTimeout(endpoint string, timeout time.Duration) Option {
+	return func(s *Scraper) error {
+		s.SetEndpointTimeout(endpoint, timeout)
+		return nil
+	}
+}
+
+// WithRequestHook option, see OnRequest.
+func WithRequestHook(hook func(RequestInfo)) Option {
+	return func(s *Scraper) error {
+		s.OnRequest(hook)
+		return nil
+	}
+}
+
+// WithBeforeRequest option, see BeforeRequest.
+func WithBeforeRequest(hook func(*http.Request)) Option {
+	return func(s *Scraper) error {
+		s.BeforeRequest(hook)
+		return nil
+	}
+}
+
+// WithResponseHook option, see OnResponse.
+func WithResponseHook(hook func(*http.Response, time.Duration)) Option {
+	return func(s *Scraper) error {
+		s.OnResponse(hook)
+		return nil
+	}
+}
+
+// WithRecommendationHook option, see OnRecommendation.
+func WithRecommendationHook(hook func(*Recommendation)) Option {
+	return func(s *Scraper) error {
+		s.OnRecommendation(hook)
+		return nil
+	}
+}
+
+// WithGuestTokenTTL option, see SetGuestTokenTTL.
+func WithGuestTokenTTL(ttl time.Duration) Option {
+	return func(s *Scraper) error {
+		s.SetGuestTokenTTL(ttl)
+		return nil
+	}
+}
+
+// WithOtherModuleHook option, see OnOtherModule.
+func WithOtherModuleHook(hook func(*OtherModule)) Option {
+	return func(s *Scraper) error {
+		s.OnOtherModule(hook)
+		return nil
+	}
+}
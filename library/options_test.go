@@ -0,0 +1,120 @@
+package twitterscraper_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	scraper, err := twitterscraper.NewWithOptions(
+		twitterscraper.WithClientTimeout(time.Second),
+		twitterscraper.WithUserAgent("test"),
+		twitterscraper.WithProxy("http://127.0.0.1:8080"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scraper.GetUserAgent() != "test" {
+		t.Errorf("Expected user agent 'test', got '%s'", scraper.GetUserAgent())
+	}
+
+	_, err = twitterscraper.NewWithOptions(twitterscraper.WithProxy("ftp://127.0.0.1"))
+	if err == nil {
+		t.Error("Expected error for unsupported proxy")
+	}
+
+	// New skips failed option instead of panicking
+	scraper = twitterscraper.New(twitterscraper.WithProxy("ftp://127.0.0.1"), twitterscraper.WithUserAgent("test"))
+	if scraper.GetUserAgent() != "test" {
+		t.Error("Expected options after failed one to be applied")
+	}
+}
+
+func TestWithAccounts(t *testing.T) {
+	scraper := twitterscraper.New(twitterscraper.WithAccounts(
+		twitterscraper.AuthToken{Token: "token1", CSRFToken: "ct0_1"},
+		twitterscraper.AuthToken{Token: "token2", CSRFToken: "ct0_2"},
+	))
+	if accounts := scraper.Accounts(); len(accounts) != 2 || accounts[1].Label != "account-2" {
+		t.Errorf("Expected 2 accounts in pool, got %+v", accounts)
+	}
+}
+
+func TestWithCursorStore(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "cursors.json")
+	if err := os.WriteFile(filename, []byte(`{"followers/x":"cursor"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scraper, err := twitterscraper.NewWithOptions(twitterscraper.WithCursorStore(twitterscraper.NewFileCursorStore(filename)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var session bytes.Buffer
+	if err := scraper.WriteSession(&session); err != nil {
+		t.Fatal(err)
+	}
+	var saved struct{ Cursors map[string]string }
+	json.Unmarshal(session.Bytes(), &saved)
+	if saved.Cursors["followers/x"] != "cursor" {
+		t.Errorf("Expected cursor loaded from store, got %v", saved.Cursors)
+	}
+
+	// cleared cursors are removed from store
+	scraper.ClearCursors()
+	if data, _ := os.ReadFile(filename); string(data) != "{}" {
+		t.Errorf("Expected empty store, got %s", data)
+	}
+
+	if _, err := twitterscraper.NewWithOptions(twitterscraper.WithCursorStore(twitterscraper.NewFileCursorStore(t.TempDir()))); err == nil {
+		t.Error("Expected error for directory as store")
+	}
+}
+
+func TestWithInsecureSkipVerify(t *testing.T) {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 {
+			w.WriteHeader(http.StatusHTTPVersionNotSupported)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	defer ts.Close()
+
+	scraper, err := twitterscraper.NewWithOptions(twitterscraper.WithInsecureSkipVerify(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "token", CSRFToken: "csrf"})
+
+	// self-signed certificate is accepted and HTTP/2 is kept without proxy
+	req, _ := http.NewRequest("GET", ts.URL, nil)
+	if err := scraper.RequestAPI(req, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInsecureSkipVerifyWarnsWithoutLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	if _, err := twitterscraper.NewWithOptions(twitterscraper.WithInsecureSkipVerify(true)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "TLS certificate verification is disabled") {
+		t.Errorf("Expected warning in standard log, got %q", buf.String())
+	}
+}
@@ -0,0 +1,39 @@
+package twitterscraper
+
+import "sort"
+
+// SortTweets sorts tweets from newest to oldest, tweets with the same time are
+// ordered by ID. Use it to get the same order of tweets between runs, as order of
+// timelines depends on pinned tweets and ranking.
+func SortTweets(tweets []*Tweet) {
+	sort.SliceStable(tweets, func(i, j int) bool {
+		if tweets[i].Timestamp != tweets[j].Timestamp {
+			return tweets[i].Timestamp > tweets[j].Timestamp
+		}
+		return compareIDs(tweets[i].ID, tweets[j].ID) > 0
+	})
+}
+
+// SortProfiles sorts profiles by user ID in ascending order.
+func SortProfiles(profiles []*Profile) {
+	sort.SliceStable(profiles, func(i, j int) bool {
+		return compareIDs(profiles[i].UserID, profiles[j].UserID) < 0
+	})
+}
+
+// compareIDs compares numeric IDs without parsing, so they can't overflow.
+func compareIDs(a, b string) int {
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,36 @@
+package twitterscraper_test
+
+import (
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestSortTweets(t *testing.T) {
+	tweets := []*twitterscraper.Tweet{
+		{ID: "99", Timestamp: 100},
+		{ID: "1000", Timestamp: 100},
+		{ID: "5", Timestamp: 300},
+		{ID: "7", Timestamp: 200},
+	}
+	twitterscraper.SortTweets(tweets)
+
+	expected := []string{"5", "7", "1000", "99"}
+	for i, tweet := range tweets {
+		if tweet.ID != expected[i] {
+			t.Fatalf("Expected order %v, got %s at %d", expected, tweet.ID, i)
+		}
+	}
+}
+
+func TestSortProfiles(t *testing.T) {
+	profiles := []*twitterscraper.Profile{{UserID: "200"}, {UserID: "30"}, {UserID: "1000"}}
+	twitterscraper.SortProfiles(profiles)
+
+	expected := []string{"30", "200", "1000"}
+	for i, profile := range profiles {
+		if profile.UserID != expected[i] {
+			t.Fatalf("Expected order %v, got %s at %d", expected, profile.UserID, i)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package twitterscraper
+
+// PinnedMode is handling of pinned tweet in user timelines.
+type PinnedMode int
+
+const (
+	// PinnedInclude keeps pinned tweet at its place in timeline flagged with
+	// IsPin, default.
+	PinnedInclude PinnedMode = iota
+	// PinnedExclude removes pinned tweet from timeline.
+	PinnedExclude
+	// PinnedSeparate moves pinned tweet to the first result of timeline,
+	// regardless of its age, and removes it from its place.
+	PinnedSeparate
+)
+
+// SetPinnedMode set handling of pinned tweet in GetTweets and
+// GetTweetsAndReplies, legacy timeline is not affected.
+func (s *Scraper) SetPinnedMode(mode PinnedMode) *Scraper {
+	s.pinnedMode = mode
+	return s
+}
+
+// pinnedTweet returns tweet of pin entry, which is in the first page only.
+func (timeline *timelineV2) pinnedTweet() *Tweet {
+	for _, instruction := range timeline.Data.User.Result.TimelineV2.Timeline.Instructions {
+		if instruction.Type == "TimelinePinEntry" {
+			if tweet := instruction.Entry.Content.ItemContent.TweetResults.Result.parse(); tweet != nil {
+				tweet.IsPin = true
+				return tweet
+			}
+		}
+	}
+	return nil
+}
+
+// placePinned applies pinned mode to page of timeline, pin is tweet of pin entry.
+func (s *Scraper) placePinned(tweets []*Tweet, pin *Tweet) []*Tweet {
+	if s.pinnedMode == PinnedInclude {
+		return tweets
+	}
+
+	placed := make([]*Tweet, 0, len(tweets)+1)
+	if pin != nil && s.pinnedMode == PinnedSeparate {
+		placed = append(placed, pin)
+	}
+	for _, tweet := range tweets {
+		if !tweet.IsPin {
+			placed = append(placed, tweet)
+		}
+	}
+	return placed
+}
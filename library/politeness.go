@@ -0,0 +1,80 @@
+package twitterscraper
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Politeness bundles pacing of API requests, so scraper can be tuned by
+// picking one of profiles instead of setting every knob.
+type Politeness struct {
+	Name string
+	// Delay after every API request is random between MinDelay and MaxDelay,
+	// so requests don't come at regular intervals.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// Concurrency limits API requests in flight at once, 0 means no limit.
+	Concurrency int
+	// PageSize is number of tweets requested per timeline page, 0 means
+	// requested limit up to maximum of endpoint.
+	PageSize int
+}
+
+// Politeness profiles from the fastest to the least noticeable.
+var (
+	PolitenessAggressive = Politeness{Name: "aggressive", Concurrency: 8}
+	PolitenessNormal     = Politeness{Name: "normal", MinDelay: time.Second, MaxDelay: 3 * time.Second, Concurrency: 2, PageSize: 40}
+	PolitenessStealth    = Politeness{Name: "stealth", MinDelay: 5 * time.Second, MaxDelay: 20 * time.Second, Concurrency: 1, PageSize: 20}
+)
+
+// politenessKey is context key of politeness of session target.
+type politenessKey struct{}
+
+// PolitenessProfile returns profile by name: aggressive, normal or stealth.
+func PolitenessProfile(name string) (Politeness, error) {
+	for _, p := range []Politeness{PolitenessAggressive, PolitenessNormal, PolitenessStealth} {
+		if strings.EqualFold(p.Name, name) {
+			return p, nil
+		}
+	}
+	return Politeness{}, fmt.Errorf("unknown politeness profile %q", name)
+}
+
+// SetPoliteness paces all API requests of scraper by profile, delay of
+// profile replaces delay set with WithDelay.
+func (s *Scraper) SetPoliteness(p Politeness) *Scraper {
+	s.politeness = p
+	s.requestSlots = nil
+	if p.Concurrency > 0 {
+		s.requestSlots = make(chan struct{}, p.Concurrency)
+	}
+	return s
+}
+
+// politenessOf returns profile of session target running in ctx or profile
+// of scraper.
+func (s *Scraper) politenessOf(ctx context.Context) Politeness {
+	if p, ok := ctx.Value(politenessKey{}).(Politeness); ok {
+		return p
+	}
+	return s.politeness
+}
+
+// pageSize returns number of items requested per page for limit.
+func (s *Scraper) pageSize(ctx context.Context, limit int) int {
+	if size := s.politenessOf(ctx).PageSize; size > 0 && size < limit {
+		return size
+	}
+	return limit
+}
+
+// requestDelay returns random delay after request.
+func (p Politeness) requestDelay() time.Duration {
+	if p.MaxDelay <= p.MinDelay {
+		return p.MinDelay
+	}
+	return p.MinDelay + time.Duration(rand.Int63n(int64(p.MaxDelay-p.MinDelay)+1))
+}
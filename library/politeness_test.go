@@ -0,0 +1,45 @@
+package twitterscraper_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestPolitenessProfile(t *testing.T) {
+	p, err := twitterscraper.PolitenessProfile("Stealth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p != twitterscraper.PolitenessStealth {
+		t.Errorf("Expected stealth profile, got %+v", p)
+	}
+	if _, err := twitterscraper.PolitenessProfile("reckless"); err == nil {
+		t.Error("Expected error for unknown profile")
+	}
+}
+
+func TestSetPoliteness(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	delay := 50 * time.Millisecond
+	scraper := twitterscraper.New(twitterscraper.WithPoliteness(twitterscraper.Politeness{MinDelay: delay, MaxDelay: delay, Concurrency: 1}))
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "token", CSRFToken: "ct0"})
+
+	started := time.Now()
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", ts.URL+"/i/api/graphql/abc/UserTweets", nil)
+		if err := scraper.RequestAPI(req, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(started); elapsed < delay {
+		t.Errorf("Expected second request to wait %v, took %v", delay, elapsed)
+	}
+}
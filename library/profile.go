@@ -1,6 +1,7 @@
 package twitterscraper
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -55,9 +56,9 @@ type user struct {
 }
 
 // GetProfile return parsed user profile.
-func (s *Scraper) GetProfile(username string) (Profile, error) {
+func (s *Scraper) GetProfile(ctx context.Context, username string) (Profile, error) {
 	var jsn user
-	req, err := http.NewRequest("GET", "https://api.twitter.com/graphql/Yka-W8dz7RaEuQNkroPkYw/UserByScreenName", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.twitter.com/graphql/Yka-W8dz7RaEuQNkroPkYw/UserByScreenName", nil)
 	if err != nil {
 		return Profile{}, err
 	}
@@ -84,7 +85,7 @@ func (s *Scraper) GetProfile(username string) (Profile, error) {
 
 	query := url.Values{}
 	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
 	req.URL.RawQuery = query.Encode()
 
 	err = s.RequestAPI(req, &jsn)
@@ -94,16 +95,16 @@ func (s *Scraper) GetProfile(username string) (Profile, error) {
 
 	if len(jsn.Errors) > 0 && jsn.Data.User.Result.RestID == "" {
 		if strings.Contains(jsn.Errors[0].Message, "Missing LdapGroup(visibility-custom-suspension)") {
-			return Profile{}, fmt.Errorf("user is suspended")
+			return Profile{}, ErrUserSuspended
 		}
 		return Profile{}, fmt.Errorf("%s", jsn.Errors[0].Message)
 	}
 
 	if jsn.Data.User.Result.RestID == "" {
 		if jsn.Data.User.Result.Message == "User is suspended" {
-			return Profile{}, fmt.Errorf("user is suspended")
+			return Profile{}, ErrUserSuspended
 		}
-		return Profile{}, fmt.Errorf("user not found")
+		return Profile{}, ErrUserNotFound
 	}
 	jsn.Data.User.Result.Legacy.IDStr = jsn.Data.User.Result.RestID
 
@@ -114,9 +115,9 @@ func (s *Scraper) GetProfile(username string) (Profile, error) {
 	return parseProfile(jsn.Data.User.Result.Legacy), nil
 }
 
-func (s *Scraper) GetProfileByID(userID string) (Profile, error) {
+func (s *Scraper) GetProfileByID(ctx context.Context, userID string) (Profile, error) {
 	var jsn user
-	req, err := http.NewRequest("GET", "https://twitter.com/i/api/graphql/Qw77dDjp9xCpUY-AXwt-yQ/UserByRestId", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://twitter.com/i/api/graphql/Qw77dDjp9xCpUY-AXwt-yQ/UserByRestId", nil)
 	if err != nil {
 		return Profile{}, err
 	}
@@ -141,7 +142,7 @@ func (s *Scraper) GetProfileByID(userID string) (Profile, error) {
 
 	query := url.Values{}
 	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
 	req.URL.RawQuery = query.Encode()
 
 	err = s.RequestAPI(req, &jsn)
@@ -151,16 +152,16 @@ func (s *Scraper) GetProfileByID(userID string) (Profile, error) {
 
 	if len(jsn.Errors) > 0 && jsn.Data.User.Result.RestID == "" {
 		if strings.Contains(jsn.Errors[0].Message, "Missing LdapGroup(visibility-custom-suspension)") {
-			return Profile{}, fmt.Errorf("user is suspended")
+			return Profile{}, ErrUserSuspended
 		}
 		return Profile{}, fmt.Errorf("%s", jsn.Errors[0].Message)
 	}
 
 	if jsn.Data.User.Result.RestID == "" {
 		if jsn.Data.User.Result.Message == "User is suspended" {
-			return Profile{}, fmt.Errorf("user is suspended")
+			return Profile{}, ErrUserSuspended
 		}
-		return Profile{}, fmt.Errorf("user not found")
+		return Profile{}, ErrUserNotFound
 	}
 	jsn.Data.User.Result.Legacy.IDStr = jsn.Data.User.Result.RestID
 
@@ -172,13 +173,13 @@ func (s *Scraper) GetProfileByID(userID string) (Profile, error) {
 }
 
 // GetUserIDByScreenName from API
-func (s *Scraper) GetUserIDByScreenName(screenName string) (string, error) {
+func (s *Scraper) GetUserIDByScreenName(ctx context.Context, screenName string) (string, error) {
 	id, ok := cacheIDs.Load(screenName)
 	if ok {
 		return id.(string), nil
 	}
 
-	profile, err := s.GetProfile(screenName)
+	profile, err := s.GetProfile(ctx, screenName)
 	if err != nil {
 		return "", err
 	}
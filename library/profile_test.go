@@ -1,6 +1,7 @@
 package twitterscraper_test
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
@@ -30,7 +31,7 @@ func TestGetProfile(t *testing.T) {
 		Website:        "https://nomadic.name",
 	}
 
-	profile, err := testScraper.GetProfile("nomadic_ua")
+	profile, err := testScraper.GetProfile(context.Background(), "nomadic_ua")
 	if err != nil {
 		t.Error(err)
 	}
@@ -82,7 +83,7 @@ func TestGetProfilePrivate(t *testing.T) {
 	}
 
 	// some random private profile (found via google)
-	profile, err := testScraper.GetProfile("tomdumont")
+	profile, err := testScraper.GetProfile(context.Background(), "tomdumont")
 	if err != nil {
 		t.Error(err)
 	}
@@ -111,7 +112,7 @@ func TestGetProfilePrivate(t *testing.T) {
 }
 
 func TestGetProfileErrorSuspended(t *testing.T) {
-	_, err := testScraper.GetProfile("1")
+	_, err := testScraper.GetProfile(context.Background(), "1")
 	if err == nil {
 		t.Error("Expected Error, got success")
 	} else {
@@ -124,7 +125,7 @@ func TestGetProfileErrorSuspended(t *testing.T) {
 func TestGetProfileErrorNotFound(t *testing.T) {
 	neUser := "sample3123131"
 	expectedError := "user not found"
-	_, err := testScraper.GetProfile(neUser)
+	_, err := testScraper.GetProfile(context.Background(), neUser)
 	if err == nil {
 		t.Error("Expected Error, got success")
 	} else {
@@ -135,7 +136,7 @@ func TestGetProfileErrorNotFound(t *testing.T) {
 }
 
 func TestGetProfileByID(t *testing.T) {
-	profile, err := testScraper.GetProfileByID("1221221876849995777")
+	profile, err := testScraper.GetProfileByID(context.Background(), "1221221876849995777")
 	if err != nil {
 		t.Error(err)
 	}
@@ -146,7 +147,7 @@ func TestGetProfileByID(t *testing.T) {
 }
 
 func TestGetUserIDByScreenName(t *testing.T) {
-	userID, err := testScraper.GetUserIDByScreenName("Twitter")
+	userID, err := testScraper.GetUserIDByScreenName(context.Background(), "Twitter")
 	if err != nil {
 		t.Errorf("getUserByScreenName() error = %v", err)
 	}
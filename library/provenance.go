@@ -0,0 +1,89 @@
+package twitterscraper
+
+import (
+	"net/url"
+	"time"
+)
+
+// Endpoints reported in tweet provenance.
+const (
+	EndpointTimeline    = "timeline"
+	EndpointMedia       = "media"
+	EndpointTweetDetail = "tweet-detail"
+	EndpointSearch      = "search"
+	EndpointHome        = "home"
+	EndpointBookmarks   = "bookmarks"
+	EndpointLikes       = "likes"
+	EndpointCommunity   = "community"
+)
+
+// Provenance describes where and when tweet was scraped.
+type Provenance struct {
+	// Endpoint produced tweet, one of Endpoint* constants.
+	Endpoint string
+	// Account label set with WithLabels or label of pool account served request.
+	Account string
+	// Proxy label set with WithLabels, host of proxy by default.
+	Proxy     string
+	FetchedAt time.Time
+	// Cursor of page containing tweet, empty for the first page.
+	Cursor string
+	// RequestID of API request returned tweet.
+	RequestID string
+	// RunID set with SetRunID.
+	RunID string
+}
+
+// WithLabels set account and proxy labels reported in provenance of scraped tweets.
+// Empty proxy label means host of current proxy.
+func (s *Scraper) WithLabels(account, proxy string) *Scraper {
+	s.accountLabel = account
+	s.proxyLabel = proxy
+	return s
+}
+
+// proxyLabelOrHost returns proxy label, or host of proxy bound to pool
+// account served request or of proxy of scraper.
+func (s *Scraper) proxyLabelOrHost(bound string) string {
+	if s.proxyLabel != "" {
+		return s.proxyLabel
+	}
+	proxyAddr := s.proxy
+	if len(s.proxyChain) > 0 {
+		proxyAddr = s.proxyChain[len(s.proxyChain)-1]
+	}
+	if bound != "" {
+		proxyAddr = bound
+	}
+	if u, err := url.Parse(proxyAddr); err == nil {
+		return u.Host
+	}
+	return ""
+}
+
+func (s *Scraper) setProvenance(tweets []*Tweet, meta requestMeta, endpoint, cursor string) {
+	provenance := s.newProvenance(meta, endpoint, cursor)
+	for _, tweet := range tweets {
+		if tweet != nil {
+			p := provenance
+			tweet.Provenance = &p
+		}
+	}
+}
+
+// newProvenance of page returned by request described with meta.
+func (s *Scraper) newProvenance(meta requestMeta, endpoint, cursor string) Provenance {
+	account := s.accountLabel
+	if account == "" {
+		account = meta.account
+	}
+	return Provenance{
+		Endpoint:  endpoint,
+		Account:   account,
+		Proxy:     s.proxyLabelOrHost(meta.proxy),
+		FetchedAt: time.Now().UTC(),
+		Cursor:    cursor,
+		RequestID: meta.requestID,
+		RunID:     s.runID,
+	}
+}
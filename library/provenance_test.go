@@ -0,0 +1,108 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestTweetProvenance(t *testing.T) {
+	scraper := newTestScraper(true)
+	scraper.WithLabels("main", "local")
+	runID := twitterscraper.NewRunID()
+	scraper.SetRunID(runID)
+
+	tweet, err := scraper.GetTweet(context.Background(), "1665602315745673217")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweet.Provenance == nil {
+		t.Fatal("Expected tweet provenance")
+	}
+	if tweet.Provenance.Endpoint != twitterscraper.EndpointTweetDetail {
+		t.Errorf("Expected endpoint %s, got %s", twitterscraper.EndpointTweetDetail, tweet.Provenance.Endpoint)
+	}
+	if tweet.Provenance.Account != "main" || tweet.Provenance.Proxy != "local" {
+		t.Errorf("Expected labels main/local, got %s/%s", tweet.Provenance.Account, tweet.Provenance.Proxy)
+	}
+	if tweet.Provenance.FetchedAt.IsZero() {
+		t.Error("Expected fetch time")
+	}
+	if tweet.Provenance.RunID != runID || scraper.Stats().RunID != runID {
+		t.Errorf("Expected run ID %s, got %s", runID, tweet.Provenance.RunID)
+	}
+}
+
+func TestNewRunID(t *testing.T) {
+	id := twitterscraper.NewRunID()
+	if len(id) != 36 || id[14] != '4' || id == twitterscraper.NewRunID() {
+		t.Errorf("Expected random UUID, got %s", id)
+	}
+}
+
+func TestProvenanceOfConcurrentRequests(t *testing.T) {
+	// tweet of every response tells which account requested it
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("auth_token")
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"data":{"user":{"result":{"timeline_v2":{"timeline":{"instructions":[{"entries":[{"entryId":"tweet-%[1]s",
+			"content":{"itemContent":{"tweet_results":{"result":{"__typename":"Tweet","legacy":{"id_str":"%[1]s","full_text":"%[1]s"}}}}}}]}]}}}}}}`, cookie.Value)
+	}))
+	defer ts.Close()
+	target, _ := url.Parse(ts.URL)
+
+	scraper := twitterscraper.New()
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "1", CSRFToken: "csrf"})
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "2", CSRFToken: "csrf"})
+	// test server proxies requests of account-2 to itself
+	if err := scraper.BindAccountToProxy(twitterscraper.AuthToken{Token: "2"}, ts.URL); err != nil {
+		t.Fatal(err)
+	}
+	scraper.BeforeRequest(func(req *http.Request) {
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+	})
+	var mu sync.Mutex
+	accounts := make(map[string]string)
+	scraper.OnRequest(func(info twitterscraper.RequestInfo) {
+		mu.Lock()
+		accounts[info.RequestID] = info.Account
+		mu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tweets, _, err := scraper.FetchTweetsByUserID(context.Background(), "2244994945", 20, "")
+			if err != nil || len(tweets) != 1 {
+				t.Errorf("Expected one tweet, got %d: %v", len(tweets), err)
+				return
+			}
+			tweet := tweets[0]
+			account, proxy := "account-"+tweet.Text, ""
+			if tweet.Text == "2" {
+				proxy = target.Host
+			}
+			if tweet.Provenance.Account != account || tweet.Provenance.Proxy != proxy {
+				t.Errorf("Expected %s via %q, got %s via %q", account, proxy, tweet.Provenance.Account, tweet.Provenance.Proxy)
+			}
+			mu.Lock()
+			if accounts[tweet.Provenance.RequestID] != account {
+				t.Errorf("Expected request %s of %s, got %s", tweet.Provenance.RequestID, account, accounts[tweet.Provenance.RequestID])
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+}
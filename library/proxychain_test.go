@@ -0,0 +1,77 @@
+package twitterscraper_test
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func newConnectProxy(hits *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "CONNECT" {
+			http.Error(w, "only CONNECT", http.StatusMethodNotAllowed)
+			return
+		}
+		atomic.AddInt32(hits, 1)
+		upstream, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			upstream.Close()
+			return
+		}
+		conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go func() {
+			io.Copy(upstream, conn)
+			upstream.Close()
+		}()
+		io.Copy(conn, upstream)
+		conn.Close()
+	}))
+}
+
+func TestSetProxyChain(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer target.Close()
+
+	var firstHits, secondHits int32
+	first := newConnectProxy(&firstHits)
+	defer first.Close()
+	second := newConnectProxy(&secondHits)
+	defer second.Close()
+
+	scraper := twitterscraper.New()
+	scraper.WithOpenAccount(twitterscraper.OpenAccount{OAuthToken: "token", OAuthTokenSecret: "secret"})
+	if err := scraper.SetProxyChain(first.URL, second.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest("GET", target.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result struct{ OK bool }
+	if err := scraper.RequestAPI(req, &result); err != nil {
+		t.Fatal(err)
+	}
+	if !result.OK {
+		t.Error("Expected response from target server")
+	}
+	if firstHits != 1 || secondHits != 1 {
+		t.Errorf("Expected one tunnel through each proxy, got %d and %d", firstHits, secondHits)
+	}
+
+	if err := scraper.SetProxyChain(first.URL, "ftp://localhost"); err == nil {
+		t.Error("Expected error for unsupported proxy in chain")
+	}
+}
@@ -0,0 +1,90 @@
+package twitterscraper
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SearchQuery builds query of advanced search from operators, so they don't
+// need to be written by hand. Methods return query itself for chaining.
+//
+//	query := NewSearchQuery("golang").From("golang").MinLikes(100).Lang("en").ExcludeReplies()
+//	tweets := scraper.SearchTweets(ctx, query.String(), 50)
+type SearchQuery struct {
+	terms []string
+}
+
+// NewSearchQuery creates query searching for text, which may be empty.
+func NewSearchQuery(text string) *SearchQuery {
+	q := &SearchQuery{}
+	if text = strings.TrimSpace(text); text != "" {
+		q.terms = append(q.terms, text)
+	}
+	return q
+}
+
+// From limits tweets to posted by user.
+func (q *SearchQuery) From(user string) *SearchQuery {
+	return q.add("from:" + strings.TrimPrefix(user, "@"))
+}
+
+// To limits tweets to replies to user.
+func (q *SearchQuery) To(user string) *SearchQuery {
+	return q.add("to:" + strings.TrimPrefix(user, "@"))
+}
+
+// Mentions limits tweets to mentioning user.
+func (q *SearchQuery) Mentions(user string) *SearchQuery {
+	return q.add("@" + strings.TrimPrefix(user, "@"))
+}
+
+// Hashtag limits tweets to containing hashtag.
+func (q *SearchQuery) Hashtag(tag string) *SearchQuery {
+	return q.add("#" + strings.TrimPrefix(tag, "#"))
+}
+
+// Since limits tweets to posted at t or later.
+func (q *SearchQuery) Since(t time.Time) *SearchQuery {
+	return q.add("since:" + t.UTC().Format(searchTimeFormat))
+}
+
+// Until limits tweets to posted before t.
+func (q *SearchQuery) Until(t time.Time) *SearchQuery {
+	return q.add("until:" + t.UTC().Format(searchTimeFormat))
+}
+
+// MinLikes limits tweets to having at least n likes.
+func (q *SearchQuery) MinLikes(n int) *SearchQuery {
+	return q.add("min_faves:" + strconv.Itoa(n))
+}
+
+// MinRetweets limits tweets to having at least n retweets.
+func (q *SearchQuery) MinRetweets(n int) *SearchQuery {
+	return q.add("min_retweets:" + strconv.Itoa(n))
+}
+
+// Lang limits tweets to language by ISO 639-1 code, e.g. "en".
+func (q *SearchQuery) Lang(code string) *SearchQuery {
+	return q.add("lang:" + strings.ToLower(code))
+}
+
+// HasMedia limits tweets to having photos or videos.
+func (q *SearchQuery) HasMedia() *SearchQuery {
+	return q.add("filter:media")
+}
+
+// ExcludeReplies skips replies.
+func (q *SearchQuery) ExcludeReplies() *SearchQuery {
+	return q.add("-filter:replies")
+}
+
+// String returns query in advanced search syntax.
+func (q *SearchQuery) String() string {
+	return strings.Join(q.terms, " ")
+}
+
+func (q *SearchQuery) add(term string) *SearchQuery {
+	q.terms = append(q.terms, term)
+	return q
+}
@@ -0,0 +1,34 @@
+package twitterscraper_test
+
+import (
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestSearchQuery(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 2, 1, 12, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+	query := twitterscraper.NewSearchQuery(" go release ").
+		From("@golang").
+		To("rob_pike").
+		Mentions("@gopher").
+		Hashtag("#golang").
+		Since(since).
+		Until(until).
+		MinLikes(100).
+		MinRetweets(10).
+		Lang("EN").
+		HasMedia().
+		ExcludeReplies()
+
+	expected := "go release from:golang to:rob_pike @gopher #golang since:2024-01-01_00:00:00_UTC until:2024-02-01_10:00:00_UTC min_faves:100 min_retweets:10 lang:en filter:media -filter:replies"
+	if got := query.String(); got != expected {
+		t.Errorf("Expected query\n%s\ngot\n%s", expected, got)
+	}
+
+	if got := twitterscraper.NewSearchQuery("").From("golang").String(); got != "from:golang" {
+		t.Errorf("Expected query without text, got %q", got)
+	}
+}
@@ -0,0 +1,67 @@
+package twitterscraper
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitStrategy defines what to do when request is rate limited.
+type RateLimitStrategy int
+
+const (
+	// RateLimitFail returns error of rate limited request - default.
+	RateLimitFail RateLimitStrategy = iota
+	// RateLimitWait waits until x-rate-limit-reset and retries request.
+	RateLimitWait
+	// RateLimitRotate retries request with the next account of pool,
+	// it waits when all accounts are rate limited.
+	RateLimitRotate
+)
+
+// default time to rest after rate limit if response has no reset header
+const defaultRateLimitReset = 15 * time.Minute
+
+// WithRateLimitStrategy set what to do when request is rate limited.
+func (s *Scraper) WithRateLimitStrategy(strategy RateLimitStrategy) *Scraper {
+	s.rateLimitStrategy = strategy
+	return s
+}
+
+// waitRateLimit waits before retrying request failed with rate limit error.
+func (s *Scraper) waitRateLimit(ctx context.Context, err error) error {
+	until := time.Now().Add(defaultRateLimitReset)
+	if apiErr, ok := err.(*APIError); ok {
+		until = rateLimitReset(apiErr.Header)
+	}
+	if s.pool != nil && (s.rateLimitStrategy == RateLimitRotate || err == ErrNoAccounts) {
+		until = s.pool.availableAt(isAgeVerifiedOnly(ctx))
+	}
+
+	s.logWarn("twitterscraper: rate limited, waiting", "until", until, "error", err)
+	return sleepContext(ctx, time.Until(until))
+}
+
+// sleepContext pauses for duration or until context is done.
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimitReset returns time from x-rate-limit-reset header.
+func rateLimitReset(header http.Header) time.Time {
+	if reset, err := strconv.ParseInt(header.Get("X-Rate-Limit-Reset"), 10, 64); err == nil {
+		return time.Unix(reset, 0)
+	}
+	return time.Now().Add(defaultRateLimitReset)
+}
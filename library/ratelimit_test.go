@@ -0,0 +1,216 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestRateLimitStrategy(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "data" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		cookie, _ := r.Cookie("auth_token")
+		if requests == 1 || (cookie != nil && cookie.Value == "limited") {
+			w.Header().Set("X-Rate-Limit-Reset", strconv.FormatInt(time.Now().Add(-time.Second).Unix(), 10))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	newRequest := func() *http.Request {
+		req, _ := http.NewRequest("POST", ts.URL, strings.NewReader("data"))
+		return req
+	}
+
+	scraper := twitterscraper.New().WithRateLimitStrategy(twitterscraper.RateLimitFail)
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "ok", CSRFToken: "ct0"})
+	var apiErr *twitterscraper.APIError
+	if err := scraper.RequestAPI(newRequest(), nil); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected rate limit error, got %v", err)
+	}
+
+	requests = 0
+	scraper = twitterscraper.New().WithRateLimitStrategy(twitterscraper.RateLimitWait)
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "ok", CSRFToken: "ct0"})
+	if err := scraper.RequestAPI(newRequest(), nil); err != nil {
+		t.Errorf("Expected request to be retried after reset, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+
+	requests = 1
+	scraper = twitterscraper.New().WithRateLimitStrategy(twitterscraper.RateLimitRotate)
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "limited", CSRFToken: "ct0"})
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "ok", CSRFToken: "ct0"})
+	if err := scraper.RequestAPI(newRequest(), nil); err != nil {
+		t.Errorf("Expected request to be retried with the next account, got %v", err)
+	}
+	if account := scraper.LastAccount(); account != "account-2" {
+		t.Errorf("Expected account-2 to serve request, got %s", account)
+	}
+}
+
+func TestRateLimitRotateToFreshAccount(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cookie, _ := r.Cookie("auth_token"); cookie != nil && cookie.Value == "limited" {
+			w.Header().Set("X-Rate-Limit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	// limited account is the last one, fresh account never rested
+	scraper := twitterscraper.New().WithRateLimitStrategy(twitterscraper.RateLimitRotate)
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "fresh", CSRFToken: "ct0"})
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "limited", CSRFToken: "ct0"})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequestWithContext(ctx, "GET", ts.URL, nil)
+		if err := scraper.RequestAPI(req, nil); err != nil {
+			t.Fatalf("Expected request %d to rotate to fresh account instead of waiting for reset, got %v", i+1, err)
+		}
+		if account := scraper.LastAccount(); account != "account-1" {
+			t.Errorf("Expected account-1 to serve request %d, got %s", i+1, account)
+		}
+	}
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Write([]byte("{}"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	scraper := twitterscraper.New().WithRateLimitStrategy(twitterscraper.RateLimitWait)
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "ok", CSRFToken: "ct0"})
+	req, _ := http.NewRequest("GET", ts.URL, nil)
+	if err := scraper.RequestAPI(req, nil); err != nil {
+		t.Errorf("Expected 503 to be retried, got %v", err)
+	}
+
+	// 404 is not retryable
+	req, _ = http.NewRequest("GET", ts.URL, nil)
+	if err := scraper.RequestAPI(req, nil); err == nil || requests != 3 {
+		t.Errorf("Expected 404 without retries, got %v after %d requests", err, requests)
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.Write([]byte(`{"data":{}}`))
+		case 3:
+			w.Write([]byte(`{"data":{"user":{}}}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	var retries []twitterscraper.RetryInfo
+	var requestIDs []string
+	scraper, err := twitterscraper.NewWithOptions(twitterscraper.WithRetry(3, time.Millisecond, 2*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "ok", CSRFToken: "ct0"})
+	scraper.OnRetry(func(info twitterscraper.RetryInfo) {
+		retries = append(retries, info)
+	})
+	scraper.OnRequest(func(info twitterscraper.RequestInfo) {
+		requestIDs = append(requestIDs, info.RequestID)
+	})
+
+	// 5xx and empty GraphQL response are retried
+	var target map[string]interface{}
+	req, _ := http.NewRequest("GET", ts.URL+"/i/api/graphql/abc/UserByScreenName", nil)
+	if err := scraper.RequestAPI(req, &target); err != nil || requests != 3 {
+		t.Fatalf("Expected success on the third attempt, got %v after %d requests", err, requests)
+	}
+	if len(retries) != 2 || retries[0].Attempt != 1 || retries[1].Attempt != 2 {
+		t.Fatalf("Expected 2 retries reported, got %+v", retries)
+	}
+	if !errors.Is(retries[1].Err, twitterscraper.ErrEmptyResponse) || retries[1].Endpoint != "UserByScreenName" {
+		t.Errorf("Expected empty response of UserByScreenName, got %+v", retries[1])
+	}
+	for i, retry := range retries {
+		if retry.Delay > 2*time.Millisecond {
+			t.Errorf("Expected delay up to max, got %s", retry.Delay)
+		}
+		if retry.RequestID == "" || retry.RequestID != requestIDs[i] {
+			t.Errorf("Expected request ID %s of failed attempt, got %q", requestIDs[i], retry.RequestID)
+		}
+	}
+
+	// error of the last attempt is returned
+	req, _ = http.NewRequest("GET", ts.URL, nil)
+	if err := scraper.RequestAPI(req, nil); err == nil || requests != 6 {
+		t.Errorf("Expected error after 3 attempts, got %v after %d requests", err, requests)
+	}
+}
+
+func TestRetryMutation(t *testing.T) {
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"data":{"favorite_tweet":"Done"}}`))
+	}))
+	defer ts.Close()
+	target, _ := url.Parse(ts.URL)
+
+	scraper := twitterscraper.New(twitterscraper.WithRetry(2, time.Millisecond, 0))
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "ok", CSRFToken: "ct0"})
+	scraper.BeforeRequest(func(req *http.Request) {
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+	})
+
+	// POST body is sent again on retry
+	variables := map[string]interface{}{"tweet_id": "1"}
+	if err := scraper.DoGraphQLMutation(context.Background(), "lI07N6Otwv1PhnEgXILM7A/FavoriteTweet", variables, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 2 || bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Errorf("Expected the same body in 2 attempts, got %q", bodies)
+	}
+}
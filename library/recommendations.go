@@ -0,0 +1,67 @@
+package twitterscraper
+
+// Kinds of Recommendation.
+const (
+	RecommendationWhoToFollow = "who-to-follow"
+	RecommendationTopics      = "topics"
+)
+
+type (
+	// Recommendation is module of accounts or topics suggested to follow,
+	// which API inserts into timelines and search.
+	Recommendation struct {
+		// Kind is RecommendationWhoToFollow or RecommendationTopics.
+		Kind string
+		// EntryID of module, it tells position of module on page.
+		EntryID string
+		// Title of module, e.g. Who to follow.
+		Title      string
+		Users      []Profile
+		Topics     []Topic
+		Provenance *Provenance
+	}
+
+	topicItem struct {
+		TopicID string `json:"topic_id"`
+		Name    string `json:"name"`
+	}
+)
+
+// OnRecommendation adds hook called with every recommendation module found on
+// pages of home timelines, user timelines and search. Modules are not
+// captured without hooks. Hooks are called synchronously, so they should be
+// fast.
+func (s *Scraper) OnRecommendation(hook func(*Recommendation)) *Scraper {
+	s.stats.mu.Lock()
+	s.stats.recommendHooks = append(s.stats.recommendHooks, hook)
+	s.stats.mu.Unlock()
+	return s
+}
+
+// parseRecommendation of module entry, nil if module has no suggested
+// accounts or topics, e.g. conversation module.
+func parseRecommendation(entryID string, header moduleHeader, items []item) *Recommendation {
+	recommendation := &Recommendation{EntryID: entryID, Title: header.Text}
+	for _, item := range items {
+		content := &item.Item.ItemContent
+		switch {
+		case content.ItemType == "TimelineUser" && content.UserResults.Result.Typename == "User":
+			recommendation.Users = append(recommendation.Users, content.UserResults.Result.parse())
+		case content.ItemType == "TimelineTopic" && content.Topic.TopicID != "":
+			recommendation.Topics = append(recommendation.Topics, Topic{
+				ID:    content.Topic.TopicID,
+				Title: content.Topic.Name,
+			})
+		}
+	}
+
+	switch {
+	case len(recommendation.Topics) > 0:
+		recommendation.Kind = RecommendationTopics
+	case len(recommendation.Users) > 0:
+		recommendation.Kind = RecommendationWhoToFollow
+	default:
+		return nil
+	}
+	return recommendation
+}
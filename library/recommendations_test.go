@@ -0,0 +1,46 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestOnRecommendation(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	var mu sync.Mutex
+	var recommendations []*twitterscraper.Recommendation
+	testScraper.OnRecommendation(func(r *twitterscraper.Recommendation) {
+		mu.Lock()
+		recommendations = append(recommendations, r)
+		mu.Unlock()
+	})
+
+	if _, _, err := testScraper.FetchForYouTweets(context.Background(), 20, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, r := range recommendations {
+		switch r.Kind {
+		case twitterscraper.RecommendationWhoToFollow:
+			if len(r.Users) == 0 || r.Users[0].UserID == "" {
+				t.Errorf("Expected suggested users in module %s", r.EntryID)
+			}
+		case twitterscraper.RecommendationTopics:
+			if len(r.Topics) == 0 || r.Topics[0].ID == "" {
+				t.Errorf("Expected suggested topics in module %s", r.EntryID)
+			}
+		default:
+			t.Errorf("Unexpected kind %q of module %s", r.Kind, r.EntryID)
+		}
+		if r.Provenance == nil || r.Provenance.Endpoint != twitterscraper.EndpointHome {
+			t.Errorf("Expected home provenance of module %s", r.EntryID)
+		}
+	}
+}
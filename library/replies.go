@@ -1,6 +1,9 @@
 package twitterscraper
 
-import "net/url"
+import (
+	"context"
+	"net/url"
+)
 
 type ThreadCursor struct {
 	FocalTweetID string
@@ -9,8 +12,8 @@ type ThreadCursor struct {
 	CursorType   string
 }
 
-func (s *Scraper) GetTweetReplies(id string, cursor string) ([]*Tweet, []*ThreadCursor, error) {
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/ldqoq5MmFHN1FhMGvzC9Jg/TweetDetail")
+func (s *Scraper) GetTweetReplies(ctx context.Context, id string, cursor string) ([]*Tweet, []*ThreadCursor, error) {
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/ldqoq5MmFHN1FhMGvzC9Jg/TweetDetail")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -67,18 +70,22 @@ func (s *Scraper) GetTweetReplies(id string, cursor string) ([]*Tweet, []*Thread
 
 	query := url.Values{}
 	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
 	query.Set("fieldToggles", mapToJSONString(fieldToggles))
 	req.URL.RawQuery = query.Encode()
 
 	var threads threadedConversation
 
-	err = s.RequestAPI(req, &threads)
+	meta, err := s.requestAPIMeta(req, &threads)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	tweets, cursors := threads.parse(id)
+	s.setProvenance(tweets, meta, EndpointTweetDetail, cursor)
+	if tweets, err = s.filterTweets(tweets); err != nil {
+		return nil, nil, err
+	}
 
 	return tweets, cursors, nil
 }
@@ -1,6 +1,7 @@
 package twitterscraper_test
 
 import (
+	"context"
 	"testing"
 )
 
@@ -11,7 +12,7 @@ func TestGetReplies(t *testing.T) {
 
 	tweetId := "1697304622749086011"
 
-	tweets, cursors, err := testScraper.GetTweetReplies(tweetId, "")
+	tweets, cursors, err := testScraper.GetTweetReplies(context.Background(), tweetId, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -24,4 +25,3 @@ func TestGetReplies(t *testing.T) {
 		t.Fatal("No cursors returned")
 	}
 }
-
@@ -0,0 +1,176 @@
+package twitterscraper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type (
+	// ReportTweet is a short form of tweet used in reports.
+	ReportTweet struct {
+		ID       string `json:"id"`
+		URL      string `json:"url"`
+		Text     string `json:"text"`
+		Likes    int    `json:"likes"`
+		Retweets int    `json:"retweets"`
+		Replies  int    `json:"replies"`
+		Views    int    `json:"views"`
+	}
+
+	// HashtagCount is a number of tweets with hashtag.
+	HashtagCount struct {
+		Hashtag string `json:"hashtag"`
+		Count   int    `json:"count"`
+	}
+
+	// EngagementReport aggregates statistics of scraped tweets.
+	// Hours and weekdays are in UTC.
+	EngagementReport struct {
+		Tweets      int                      `json:"tweets"`
+		From        time.Time                `json:"from"`
+		To          time.Time                `json:"to"`
+		ByHour      [24]int                  `json:"by_hour"`
+		ByWeekday   [7]int                   `json:"by_weekday"`
+		MediaTweets int                      `json:"media_tweets"`
+		TextTweets  int                      `json:"text_tweets"`
+		MediaRatio  float64                  `json:"media_ratio"`
+		Top         map[string][]ReportTweet `json:"top"`
+		Hashtags    []HashtagCount           `json:"hashtags"`
+	}
+)
+
+// metrics available to rank top tweets in report
+var reportMetrics = []struct {
+	name  string
+	title string
+	value func(ReportTweet) int
+}{
+	{"likes", "Likes", func(t ReportTweet) int { return t.Likes }},
+	{"retweets", "Retweets", func(t ReportTweet) int { return t.Retweets }},
+	{"replies", "Replies", func(t ReportTweet) int { return t.Replies }},
+	{"views", "Views", func(t ReportTweet) int { return t.Views }},
+}
+
+// NewEngagementReport aggregates posting frequency, top tweets by each metric,
+// media vs text ratio and hashtag frequency. Retweets are not counted.
+// Negative topNbr keeps all tweets in top.
+func NewEngagementReport(tweets []*Tweet, topNbr int) *EngagementReport {
+	report := &EngagementReport{
+		Top:      make(map[string][]ReportTweet),
+		Hashtags: []HashtagCount{},
+	}
+
+	var own []ReportTweet
+	hashtags := make(map[string]int)
+	for _, tweet := range tweets {
+		if tweet == nil || tweet.IsRetweet {
+			continue
+		}
+		own = append(own, ReportTweet{
+			ID:       tweet.ID,
+			URL:      tweet.PermanentURL,
+			Text:     tweet.Text,
+			Likes:    tweet.Likes,
+			Retweets: tweet.Retweets,
+			Replies:  tweet.Replies,
+			Views:    tweet.Views,
+		})
+		report.Tweets++
+
+		created := time.Unix(tweet.Timestamp, 0).UTC()
+		if report.From.IsZero() || created.Before(report.From) {
+			report.From = created
+		}
+		if created.After(report.To) {
+			report.To = created
+		}
+		report.ByHour[created.Hour()]++
+		report.ByWeekday[created.Weekday()]++
+
+		if len(tweet.Photos) > 0 || len(tweet.Videos) > 0 || len(tweet.GIFs) > 0 {
+			report.MediaTweets++
+		} else {
+			report.TextTweets++
+		}
+
+		for _, hashtag := range tweet.Hashtags {
+			hashtags[strings.ToLower(hashtag)]++
+		}
+	}
+
+	if report.Tweets > 0 {
+		report.MediaRatio = float64(report.MediaTweets) / float64(report.Tweets)
+	}
+
+	for hashtag, count := range hashtags {
+		report.Hashtags = append(report.Hashtags, HashtagCount{Hashtag: hashtag, Count: count})
+	}
+	sort.Slice(report.Hashtags, func(i, j int) bool {
+		if report.Hashtags[i].Count == report.Hashtags[j].Count {
+			return report.Hashtags[i].Hashtag < report.Hashtags[j].Hashtag
+		}
+		return report.Hashtags[i].Count > report.Hashtags[j].Count
+	})
+
+	for _, metric := range reportMetrics {
+		top := make([]ReportTweet, len(own))
+		copy(top, own)
+		value := metric.value
+		sort.SliceStable(top, func(i, j int) bool {
+			return value(top[i]) > value(top[j])
+		})
+		if topNbr >= 0 && len(top) > topNbr {
+			top = top[:topNbr]
+		}
+		report.Top[metric.name] = top
+	}
+
+	return report
+}
+
+// Markdown renders report in human readable form.
+func (report *EngagementReport) Markdown() string {
+	var b strings.Builder
+
+	b.WriteString("# Engagement report\n\n")
+	fmt.Fprintf(&b, "- Tweets: %d\n", report.Tweets)
+	if report.Tweets > 0 {
+		fmt.Fprintf(&b, "- Period: %s – %s\n", report.From.Format(time.RFC3339), report.To.Format(time.RFC3339))
+	}
+	fmt.Fprintf(&b, "- With media: %d (%.1f%%)\n", report.MediaTweets, report.MediaRatio*100)
+	fmt.Fprintf(&b, "- Text only: %d\n", report.TextTweets)
+
+	b.WriteString("\n## Posting by hour (UTC)\n\n| Hour | Tweets |\n|---|---|\n")
+	for hour, count := range report.ByHour {
+		fmt.Fprintf(&b, "| %02d | %d |\n", hour, count)
+	}
+
+	b.WriteString("\n## Posting by weekday (UTC)\n\n| Day | Tweets |\n|---|---|\n")
+	for day, count := range report.ByWeekday {
+		fmt.Fprintf(&b, "| %s | %d |\n", time.Weekday(day), count)
+	}
+
+	for _, metric := range reportMetrics {
+		fmt.Fprintf(&b, "\n## Top by %s\n\n| # | %s | Tweet |\n|---|---|---|\n", metric.name, metric.title)
+		for i, tweet := range report.Top[metric.name] {
+			fmt.Fprintf(&b, "| %d | %d | [%s](%s) |\n", i+1, metric.value(tweet), markdownEscape(tweet.Text, 80), tweet.URL)
+		}
+	}
+
+	b.WriteString("\n## Hashtags\n\n| Hashtag | Tweets |\n|---|---|\n")
+	for _, hashtag := range report.Hashtags {
+		fmt.Fprintf(&b, "| #%s | %d |\n", hashtag.Hashtag, hashtag.Count)
+	}
+
+	return b.String()
+}
+
+func markdownEscape(text string, maxLen int) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	if len(runes) > maxLen {
+		runes = append(runes[:maxLen-1], '…')
+	}
+	return strings.NewReplacer("|", "\\|", "[", "\\[", "]", "\\]").Replace(string(runes))
+}
@@ -0,0 +1,56 @@
+package twitterscraper_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestNewEngagementReport(t *testing.T) {
+	monday := strconv.FormatInt(time.Date(2024, 3, 4, 15, 0, 0, 0, time.UTC).Unix(), 10)
+	mondayLater := strconv.FormatInt(time.Date(2024, 3, 4, 16, 0, 0, 0, time.UTC).Unix(), 10)
+	dump := `{"ID":"1","Text":"hello #Go","Likes":10,"Views":100,"Hashtags":["Go"],"Timestamp":` + monday + `}
+{"ID":"2","Text":"photo #go #news","Likes":30,"Views":50,"Hashtags":["go","news"],"Photos":[{"ID":"p","URL":"u"}],"Timestamp":` + mondayLater + `}
+{"ID":"3","Text":"RT","Likes":99,"IsRetweet":true,"Timestamp":` + monday + `}`
+
+	tweets, err := twitterscraper.ReadTweets(strings.NewReader(dump))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) != 3 {
+		t.Fatalf("Expected 3 tweets, got %d", len(tweets))
+	}
+
+	report := twitterscraper.NewEngagementReport(tweets, 1)
+	if report.Tweets != 2 {
+		t.Errorf("Expected 2 tweets without retweets, got %d", report.Tweets)
+	}
+	if report.ByHour[15] != 1 || report.ByHour[16] != 1 {
+		t.Errorf("Unexpected posting by hour %v", report.ByHour)
+	}
+	if report.ByWeekday[time.Monday] != 2 {
+		t.Errorf("Unexpected posting by weekday %v", report.ByWeekday)
+	}
+	if report.MediaTweets != 1 || report.TextTweets != 1 {
+		t.Errorf("Expected 1 media and 1 text tweet, got %d and %d", report.MediaTweets, report.TextTweets)
+	}
+	if top := report.Top["likes"]; len(top) != 1 || top[0].ID != "2" {
+		t.Errorf("Expected top liked tweet 2, got %v", top)
+	}
+	if top := report.Top["views"]; len(top) != 1 || top[0].ID != "1" {
+		t.Errorf("Expected top viewed tweet 1, got %v", top)
+	}
+	if len(report.Hashtags) != 2 || report.Hashtags[0] != (twitterscraper.HashtagCount{Hashtag: "go", Count: 2}) {
+		t.Errorf("Unexpected hashtags %v", report.Hashtags)
+	}
+	if markdown := report.Markdown(); !strings.Contains(markdown, "| #go | 2 |") {
+		t.Errorf("Expected hashtag row in markdown report:\n%s", markdown)
+	}
+
+	if top := twitterscraper.NewEngagementReport(tweets, -1).Top["likes"]; len(top) != 2 {
+		t.Errorf("Expected all tweets in top with negative limit, got %v", top)
+	}
+}
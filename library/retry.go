@@ -0,0 +1,122 @@
+package twitterscraper
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrEmptyResponse is returned by GraphQL request with empty data and no
+// errors, which API sometimes returns transiently. It's detected only with
+// WithRetry.
+var ErrEmptyResponse = errors.New("empty response")
+
+type (
+	// RetryInfo describes failed attempt of API request passed to retry hooks.
+	RetryInfo struct {
+		// Attempt is number of failed attempt, starting from 1.
+		Attempt int
+		// RequestID of failed attempt, as in RequestInfo and errors.
+		RequestID string
+		Endpoint  string
+		// Delay before the next attempt.
+		Delay time.Duration
+		Err   error
+	}
+
+	retryPolicy struct {
+		maxAttempts int
+		baseDelay   time.Duration
+		maxDelay    time.Duration
+	}
+)
+
+// defaultRetry is used with Wait and Rotate rate limit strategies without WithRetry.
+var defaultRetry = retryPolicy{maxAttempts: maxTransientRetries + 1, baseDelay: time.Second}
+
+// SetRetry makes API requests failed with network error, 5xx status or empty
+// GraphQL response to be tried up to maxAttempts times. Delay before attempt
+// grows exponentially from baseDelay up to maxDelay, zero maxDelay means no
+// limit, and is randomized between half and full value, so runs hitting the
+// same error don't retry at once. Empty response of the last attempt is
+// decoded as is. Rate limit errors follow rate limit strategy.
+func (s *Scraper) SetRetry(maxAttempts int, baseDelay, maxDelay time.Duration) *Scraper {
+	s.retry = retryPolicy{maxAttempts: maxAttempts, baseDelay: baseDelay, maxDelay: maxDelay}
+	return s
+}
+
+// OnRetry adds hook called before waiting for the next attempt of failed
+// request. Hooks are called synchronously, so they should be fast.
+func (s *Scraper) OnRetry(hook func(RetryInfo)) *Scraper {
+	s.stats.mu.Lock()
+	s.stats.retryHooks = append(s.stats.retryHooks, hook)
+	s.stats.mu.Unlock()
+	return s
+}
+
+// retryPolicy returns policy set with SetRetry or default one of rate limit
+// strategy, nil if requests are not retried.
+func (s *Scraper) retryPolicy() *retryPolicy {
+	switch {
+	case s.retry.maxAttempts > 0:
+		return &s.retry
+	case s.rateLimitStrategy != RateLimitFail:
+		return &defaultRetry
+	}
+	return nil
+}
+
+// delay returns randomized delay before attempt following failed one.
+func (p *retryPolicy) delay(failed int) time.Duration {
+	delay := p.baseDelay
+	for i := 1; i < failed && (p.maxDelay <= 0 || delay < p.maxDelay); i++ {
+		delay *= 2
+	}
+	if p.maxDelay > 0 && delay > p.maxDelay {
+		delay = p.maxDelay
+	}
+	if delay <= 1 {
+		return delay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func (s *Scraper) reportRetry(info RetryInfo) {
+	s.logWarn("twitterscraper: retrying request", "endpoint", info.Endpoint, "attempt", info.Attempt, "delay", info.Delay, "error", info.Err)
+	s.stats.mu.Lock()
+	hooks := s.stats.retryHooks
+	s.stats.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(info)
+	}
+}
+
+// isTransient reports if failed attempt should be retried by retry policy,
+// rate limit errors are handled by rate limit strategy.
+func isTransient(err error) bool {
+	return IsRetryable(err) && !isRateLimit(err)
+}
+
+// isEmptyGraphQL reports if GraphQL response has neither data nor errors.
+func isEmptyGraphQL(resp *http.Response, content []byte) bool {
+	if resp.Request == nil || !strings.Contains(resp.Request.URL.Path, "/graphql/") {
+		return false
+	}
+	if len(bytes.TrimSpace(content)) == 0 {
+		return true
+	}
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(content, &response); err != nil || len(response.Errors) > 0 {
+		return false
+	}
+	data := string(bytes.TrimSpace(response.Data))
+	return data == "" || data == "null" || data == "{}"
+}
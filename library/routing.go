@@ -0,0 +1,61 @@
+package twitterscraper
+
+import (
+	"errors"
+	"net/http"
+)
+
+// apiRoute is key of API remembered to serve endpoint in auth state.
+type apiRoute struct {
+	endpoint string
+	auth     AuthState
+}
+
+// useLegacy checks if endpoint is requested via legacy API, by default in
+// open account mode, as OAuth tokens of app are accepted only there.
+func (s *Scraper) useLegacy(endpoint string) bool {
+	if legacy, ok := s.routes.Load(apiRoute{endpoint, s.AuthState()}); ok {
+		return legacy.(bool)
+	}
+	return s.isOpenAccount
+}
+
+// withFallback requests endpoint via API of current auth state and, if
+// fallback is allowed and that API refuses request, via the other one, which
+// is then used for endpoint in this auth state.
+func (s *Scraper) withFallback(endpoint string, fallback bool, graphQL, legacy func() error) error {
+	legacyFirst := s.useLegacy(endpoint)
+	first, second := graphQL, legacy
+	if legacyFirst {
+		first, second = legacy, graphQL
+	}
+
+	err := first()
+	if !fallback || !isEndpointBlocked(err) {
+		return err
+	}
+	s.logWarn("twitterscraper: endpoint blocked, trying other API", "endpoint", endpoint, "legacy", !legacyFirst, "error", err)
+	if fallbackErr := second(); fallbackErr != nil {
+		if isEndpointBlocked(fallbackErr) {
+			return err
+		}
+		return fallbackErr
+	}
+	s.routes.Store(apiRoute{endpoint, s.AuthState()}, !legacyFirst)
+	return nil
+}
+
+// isEndpointBlocked checks if API refused request in current auth state, so
+// the other API may serve it. Rate limits, expired authentication, protected
+// accounts and account errors are not blocks.
+func isEndpointBlocked(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || isRateLimit(err) || isAccountErrorCode(apiErr.Code) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusForbidden, http.StatusNotFound, http.StatusGone:
+		return true
+	}
+	return false
+}
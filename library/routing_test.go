@@ -0,0 +1,69 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestOpenAccountFallback(t *testing.T) {
+	var legacy, graphQL int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/2/timeline/"):
+			legacy++
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":[{"code":200,"message":"Forbidden."}]}`))
+		case strings.HasSuffix(r.URL.Path, "/UserTweets"):
+			graphQL++
+			w.Write([]byte(`{"data":{"user":{"result":{"timeline_v2":{"timeline":{"instructions":[]}}}}}}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer ts.Close()
+	target, _ := url.Parse(ts.URL)
+
+	scraper := twitterscraper.New()
+	scraper.WithOpenAccount(twitterscraper.OpenAccount{OAuthToken: "token", OAuthTokenSecret: "secret"})
+	scraper.BeforeRequest(func(req *http.Request) {
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+	})
+
+	// open account is routed to legacy API, which refuses it, so GraphQL is used
+	if _, _, err := scraper.FetchTweetsByUserID(context.Background(), "2244994945", 20, ""); err != nil {
+		t.Fatal(err)
+	}
+	if legacy != 1 || graphQL != 1 {
+		t.Fatalf("Expected legacy API then GraphQL, got %d legacy and %d GraphQL requests", legacy, graphQL)
+	}
+
+	// working API is remembered
+	if _, _, err := scraper.FetchTweetsByUserID(context.Background(), "2244994945", 20, ""); err != nil {
+		t.Fatal(err)
+	}
+	if legacy != 1 || graphQL != 2 {
+		t.Errorf("Expected GraphQL only, got %d legacy and %d GraphQL requests", legacy, graphQL)
+	}
+
+	// next pages are never requested via the other API, as cursors differ
+	legacy, graphQL = 0, 0
+	scraper = twitterscraper.New()
+	scraper.WithOpenAccount(twitterscraper.OpenAccount{OAuthToken: "token", OAuthTokenSecret: "secret"})
+	scraper.BeforeRequest(func(req *http.Request) {
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+	})
+	if _, _, err := scraper.FetchTweetsByUserID(context.Background(), "2244994945", 20, "cursor"); err == nil {
+		t.Error("Expected error of legacy API")
+	}
+	if legacy != 1 || graphQL != 0 {
+		t.Errorf("Expected legacy API only, got %d legacy and %d GraphQL requests", legacy, graphQL)
+	}
+}
@@ -0,0 +1,28 @@
+package twitterscraper
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// NewRunID returns random UUID version 4 to identify run of scraper.
+func NewRunID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:])
+}
+
+// SetRunID set ID of run reported in provenance of scraped tweets, stats and
+// media manifest, so artifacts of concurrent runs against the same user can be
+// told apart. Empty by default.
+func (s *Scraper) SetRunID(id string) *Scraper {
+	s.runID = id
+	return s
+}
+
+// RunID returns ID of run set with SetRunID.
+func (s *Scraper) RunID() string {
+	return s.runID
+}
@@ -1,10 +1,9 @@
 package twitterscraper
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
-	"io"
 	"net/url"
 	"strconv"
 	"strings"
@@ -128,8 +127,8 @@ func (timeline *scheduleTweets) parseTweets() []*ScheduledTweet {
 }
 
 // FetchScheduledTweets gets scheduled tweets via the Twitter frontend GraphQL API.
-func (s *Scraper) FetchScheduledTweets() ([]*ScheduledTweet, error) {
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/ITtjAzvlZni2wWXwf295Qg/FetchScheduledTweets")
+func (s *Scraper) FetchScheduledTweets(ctx context.Context) ([]*ScheduledTweet, error) {
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/ITtjAzvlZni2wWXwf295Qg/FetchScheduledTweets")
 	if err != nil {
 		return nil, err
 	}
@@ -153,8 +152,8 @@ func (s *Scraper) FetchScheduledTweets() ([]*ScheduledTweet, error) {
 }
 
 // DeleteScheduledTweet removes tweet from scheduled.
-func (s *Scraper) DeleteScheduledTweet(id string) error {
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/CTOVqej0JBXAZSwkp1US0g/DeleteScheduledTweet")
+func (s *Scraper) DeleteScheduledTweet(ctx context.Context, id string) error {
+	req, err := s.newRequest(ctx, "POST", "https://twitter.com/i/api/graphql/CTOVqej0JBXAZSwkp1US0g/DeleteScheduledTweet")
 	if err != nil {
 		return err
 	}
@@ -171,7 +170,7 @@ func (s *Scraper) DeleteScheduledTweet(id string) error {
 	}
 
 	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	setRequestBody(req, b)
 
 	var response struct {
 		Data struct {
@@ -192,12 +191,12 @@ func (s *Scraper) DeleteScheduledTweet(id string) error {
 }
 
 // CreateScheduledTweet schedule new tweet.
-func (s *Scraper) CreateScheduledTweet(schedule TweetSchedule) (string, error) {
+func (s *Scraper) CreateScheduledTweet(ctx context.Context, schedule TweetSchedule) (string, error) {
 	if schedule.Date.Unix() <= time.Now().Unix() {
 		return "", errors.New("date can't be in past")
 	}
 
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/LCVzRQGxOaGnOnYH01NQXg/CreateScheduledTweet")
+	req, err := s.newRequest(ctx, "POST", "https://twitter.com/i/api/graphql/LCVzRQGxOaGnOnYH01NQXg/CreateScheduledTweet")
 	if err != nil {
 		return "", err
 	}
@@ -232,7 +231,7 @@ func (s *Scraper) CreateScheduledTweet(schedule TweetSchedule) (string, error) {
 	}
 
 	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	setRequestBody(req, b)
 
 	var response struct {
 		Data struct {
@@ -1,6 +1,7 @@
 package twitterscraper_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -13,7 +14,7 @@ func TestFetchScheduledTweets(t *testing.T) {
 	if skipAuthTest {
 		t.Skip("Skipping test due to environment variable")
 	}
-	scheduled, err := testScraper.FetchScheduledTweets()
+	scheduled, err := testScraper.FetchScheduledTweets(context.Background())
 	if err != nil {
 		t.Error(err)
 	}
@@ -30,7 +31,7 @@ func TestCreateScheduledTweets(t *testing.T) {
 	}
 	var err error
 
-	id, err = testScraper.CreateScheduledTweet(twitterscraper.TweetSchedule{
+	id, err = testScraper.CreateScheduledTweet(context.Background(), twitterscraper.TweetSchedule{
 		Text:   "new tweet",
 		Date:   time.Now().Add(time.Hour * 24 * 31),
 		Medias: nil,
@@ -44,7 +45,7 @@ func TestDeleteScheduledTweets(t *testing.T) {
 	if id == "" {
 		t.Skip("run TestCreateScheduledTweets before")
 	}
-	if err := testScraper.DeleteScheduledTweet(id); err != nil {
+	if err := testScraper.DeleteScheduledTweet(context.Background(), id); err != nil {
 		t.Error(err)
 	} else {
 		id = ""
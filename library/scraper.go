@@ -1,12 +1,16 @@
 package twitterscraper
 
 import (
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -16,20 +20,46 @@ import (
 
 // Scraper object
 type Scraper struct {
-	bearerToken    string
-	client         *http.Client
-	delay          int64
-	guestToken     string
-	guestCreatedAt time.Time
-	includeReplies bool
-	isLogged       bool
-	isOpenAccount  bool
-	oAuthToken     string
-	oAuthSecret    string
-	proxy          string
-	userAgent      string
-	searchMode     SearchMode
-	wg             sync.WaitGroup
+	accountLabel      string
+	adsMode           AdsMode
+	bearerToken       string
+	client            *http.Client
+	cursors           cursorTracker
+	delay             int64
+	failOnParseError  bool
+	features          map[string]interface{}
+	guestToken        string
+	guestCreatedAt    time.Time
+	guestMu           sync.Mutex
+	guestTTL          time.Duration
+	har               *harRecorder
+	includeReplies    bool
+	isLogged          bool
+	isOpenAccount     bool
+	logger            logger
+	monitorPolled     sync.Map
+	monitorSince      sync.Map
+	oAuthToken        string
+	oAuthSecret       string
+	pinnedMode        PinnedMode
+	politeness        Politeness
+	pool              *accountPool
+	proxy             string
+	proxyChain        []string
+	proxyLabel        string
+	rateLimitStrategy RateLimitStrategy
+	requestSlots      chan struct{}
+	retry             retryPolicy
+	routes            sync.Map
+	runID             string
+	userAgent         string
+	searchMode        SearchMode
+	stats             requestStats
+	strict            bool
+	timeouts          map[string]time.Duration
+	tlsConfig         *tls.Config
+	transforms        []TweetTransform
+	wg                sync.WaitGroup
 }
 
 // SearchMode type
@@ -52,11 +82,35 @@ const (
 const DefaultClientTimeout = 10 * time.Second
 const DefaultUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36"
 
-// New creates a Scraper object
-func New() *Scraper {
+// New creates a Scraper object, configured with options if any.
+// Failed options are skipped and logged, if logger is set with WithLogger
+// before them, use NewWithOptions to handle errors.
+func New(opts ...Option) *Scraper {
+	s := newScraper()
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			s.logWarn("twitterscraper: option is not applied", "error", err)
+		}
+	}
+	return s
+}
+
+// NewWithOptions creates a Scraper object configured with options.
+func NewWithOptions(opts ...Option) (*Scraper, error) {
+	s := newScraper()
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func newScraper() *Scraper {
 	jar, _ := cookiejar.New(nil)
 	return &Scraper{
 		bearerToken: bearerToken,
+		guestTTL:    DefaultGuestTokenTTL,
 		userAgent:   DefaultUserAgent,
 		client: &http.Client{
 			Jar:     jar,
@@ -67,12 +121,14 @@ func New() *Scraper {
 
 func (s *Scraper) setBearerToken(token string) {
 	s.bearerToken = token
+	s.guestMu.Lock()
 	s.guestToken = ""
+	s.guestMu.Unlock()
 }
 
 // IsGuestToken check if guest token not empty
 func (s *Scraper) IsGuestToken() bool {
-	return s.guestToken != ""
+	return s.currentGuestToken() != ""
 }
 
 // SetSearchMode switcher
@@ -103,30 +159,70 @@ func (s *Scraper) WithClientTimeout(timeout time.Duration) *Scraper {
 // set http proxy in the format `http://HOST:PORT`
 // set socket proxy in the format `socks5://HOST:PORT`
 func (s *Scraper) SetProxy(proxyAddr string) error {
+	transport, err := s.newTransport(proxyAddr)
+	if err != nil {
+		return err
+	}
+	s.client.Transport = transport
+	s.proxy = proxyAddr
+	s.proxyChain = nil
+	return nil
+}
+
+// SetProxyContext same as SetProxy, but checks that twitter.com is reachable
+// through the proxy before using it. Current proxy is kept if check fails.
+func (s *Scraper) SetProxyContext(ctx context.Context, proxyAddr string) error {
+	transport, err := s.newTransport(proxyAddr)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "HEAD", twURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   s.client.Timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("proxy check failed: %w", err)
+	}
+	resp.Body.Close()
+
+	s.client.Transport = transport
+	s.proxy = proxyAddr
+	s.proxyChain = nil
+	return nil
+}
+
+func (s *Scraper) newTransport(proxyAddr string) (*http.Transport, error) {
 	if proxyAddr == "" {
-		s.client.Transport = &http.Transport{
-			TLSNextProto: make(map[string]func(authority string, c *tls.Conn) http.RoundTripper),
+		return &http.Transport{
+			TLSClientConfig: s.tlsConfig,
+			TLSNextProto:    make(map[string]func(authority string, c *tls.Conn) http.RoundTripper),
 			DialContext: (&net.Dialer{
 				Timeout: s.client.Timeout,
 			}).DialContext,
-		}
-		s.proxy = ""
-		return nil
+		}, nil
 	}
 	if strings.HasPrefix(proxyAddr, "http") {
 		urlproxy, err := url.Parse(proxyAddr)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		s.client.Transport = &http.Transport{
-			Proxy:        http.ProxyURL(urlproxy),
-			TLSNextProto: make(map[string]func(authority string, c *tls.Conn) http.RoundTripper),
+		return &http.Transport{
+			Proxy:           http.ProxyURL(urlproxy),
+			TLSClientConfig: s.tlsConfig,
+			TLSNextProto:    make(map[string]func(authority string, c *tls.Conn) http.RoundTripper),
 			DialContext: (&net.Dialer{
 				Timeout: s.client.Timeout,
 			}).DialContext,
-		}
-		s.proxy = proxyAddr
-		return nil
+		}, nil
 	}
 	if strings.HasPrefix(proxyAddr, "socks5") {
 		baseDialer := &net.Dialer{
@@ -135,7 +231,7 @@ func (s *Scraper) SetProxy(proxyAddr string) error {
 		}
 		proxyURL, err := url.Parse(proxyAddr)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 
 		// username password
@@ -157,20 +253,92 @@ func (s *Scraper) SetProxy(proxyAddr string) error {
 
 		dialSocksProxy, err := proxy.SOCKS5("tcp", host+":"+port, auth, baseDialer)
 		if err != nil {
-			return errors.New("error creating socks5 proxy :" + err.Error())
+			return nil, errors.New("error creating socks5 proxy :" + err.Error())
 		}
-		if contextDialer, ok := dialSocksProxy.(proxy.ContextDialer); ok {
-			dialContext := contextDialer.DialContext
-			s.client.Transport = &http.Transport{
-				DialContext: dialContext,
-			}
-		} else {
-			return errors.New("failed type assertion to DialContext")
+		contextDialer, ok := dialSocksProxy.(proxy.ContextDialer)
+		if !ok {
+			return nil, errors.New("failed type assertion to DialContext")
 		}
-		s.proxy = proxyAddr
-		return nil
+		return &http.Transport{
+			DialContext:     contextDialer.DialContext,
+			TLSClientConfig: s.tlsConfig,
+		}, nil
+	}
+	return nil, errors.New("only support http(s) or socks5 protocol")
+}
+
+// WithInsecureSkipVerify disable TLS certificate verification.
+// Only meant to debug traffic through mitmproxy, Charles or similar, never use it in production.
+// It's SetInsecureSkipVerify logging error instead of returning it.
+func (s *Scraper) WithInsecureSkipVerify(b bool) *Scraper {
+	if err := s.SetInsecureSkipVerify(b); err != nil {
+		s.logAlert("twitterscraper: TLS config is not applied", "error", err)
+	}
+	return s
+}
+
+// SetInsecureSkipVerify disable TLS certificate verification, see
+// WithInsecureSkipVerify. Error is returned if transport of proxy can't be
+// rebuilt with new TLS config.
+func (s *Scraper) SetInsecureSkipVerify(b bool) error {
+	if s.tlsConfig == nil {
+		s.tlsConfig = &tls.Config{}
+	}
+	s.tlsConfig.InsecureSkipVerify = b
+	if b {
+		s.logAlert("twitterscraper: TLS certificate verification is disabled, all traffic can be intercepted")
+	}
+	return s.applyTLSConfig()
+}
+
+// SetRootCA trust PEM encoded certificate authority in addition to system roots.
+// Use it to debug traffic through mitmproxy, Charles or similar with their CA certificate.
+func (s *Scraper) SetRootCA(certFile string) error {
+	pem, err := os.ReadFile(certFile)
+	if err != nil {
+		return err
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return fmt.Errorf("no certificates found in %s", certFile)
+	}
+
+	if s.tlsConfig == nil {
+		s.tlsConfig = &tls.Config{}
+	}
+	s.tlsConfig.RootCAs = pool
+	s.logAlert("twitterscraper: trusting custom certificate authority, traffic can be intercepted by its owner", "file", certFile)
+
+	return s.applyTLSConfig()
+}
+
+// applyTLSConfig rebuilds transport of proxy with current TLS config. Without
+// proxy config is set on copy of current transport, so default transport
+// keeps HTTP/2.
+func (s *Scraper) applyTLSConfig() error {
+	if s.proxy != "" || len(s.proxyChain) > 0 {
+		return s.resetTransport()
+	}
+	transport, ok := s.client.Transport.(*http.Transport)
+	if !ok {
+		transport = http.DefaultTransport.(*http.Transport)
+	}
+	transport = transport.Clone()
+	transport.TLSClientConfig = s.tlsConfig
+	s.client.Transport = transport
+	return nil
+}
+
+// resetTransport rebuilds transport with current proxy settings.
+func (s *Scraper) resetTransport() error {
+	if len(s.proxyChain) > 0 {
+		return s.SetProxyChain(s.proxyChain...)
 	}
-	return errors.New("only support http(s) or socks5 protocol")
+	return s.SetProxy(s.proxy)
 }
 
 func (s *Scraper) SetUserAgent(userAgent string) {
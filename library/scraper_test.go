@@ -0,0 +1,22 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestSetProxyInvalid(t *testing.T) {
+	scraper := twitterscraper.New()
+	if err := scraper.SetProxy("socks5://%zz"); err == nil {
+		t.Error("Expected error for invalid socks5 proxy URL")
+	}
+}
+
+func TestSetProxyContextUnreachable(t *testing.T) {
+	scraper := twitterscraper.New()
+	if err := scraper.SetProxyContext(context.Background(), "socks5://127.0.0.1:1"); err == nil {
+		t.Error("Expected error for unreachable proxy")
+	}
+}
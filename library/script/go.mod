@@ -0,0 +1,10 @@
+module github.com/imperatrona/twitter-scraper/script
+
+go 1.16
+
+require (
+	github.com/imperatrona/twitter-scraper v0.0.14
+	go.starlark.net v0.0.0-20230525235612-a134d8f9ddca
+)
+
+replace github.com/imperatrona/twitter-scraper => ../
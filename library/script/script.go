@@ -0,0 +1,158 @@
+// Package script runs Starlark scripts with access to twitterscraper.Scraper,
+// so conditional scrape flows don't need a whole Go program. It's a separate
+// module, so scraper doesn't depend on Starlark interpreter.
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+	"go.starlark.net/starlark"
+)
+
+// Run executes Starlark script src, string, []byte or io.Reader, or file if
+// src is nil, and calls its main function, if it's defined. Starlark doesn't
+// allow if and for statements at top level, so flow goes into functions.
+// Script gets builtins requesting scraper and emit, which passes tweet to
+// emit func, for example to write it to output:
+//
+//	get_tweet(id)                      tweet, None if not found
+//	get_tweets(username, limit=100)    list of tweets of user
+//	search_tweets(query, limit=50)     list of found tweets
+//	get_quote_tweets(id, limit=100)    list of tweets quoting tweet
+//	get_profile(username)              profile
+//	emit(tweet)
+//
+// Tweet has attributes id, text, username, user_id, name, timestamp, likes,
+// retweets, replies, views, hashtags, urls, permanent_url, conversation_id,
+// is_reply, is_retweet, is_quoted, quoted_status_id, in_reply_to_status_id
+// and retweeted_status_id. Profile has username, user_id, name, biography,
+// followers, following, tweets, is_verified and is_private. Cancelling ctx
+// stops script.
+func Run(ctx context.Context, scraper *twitterscraper.Scraper, filename string, src interface{}, emit func(*twitterscraper.Tweet) error) error {
+	thread := &starlark.Thread{Name: filename}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			thread.Cancel(ctx.Err().Error())
+		case <-done:
+		}
+	}()
+
+	r := runner{ctx: ctx, scraper: scraper, emit: emit}
+	predeclared := starlark.StringDict{
+		"get_tweet":        starlark.NewBuiltin("get_tweet", r.getTweet),
+		"get_tweets":       starlark.NewBuiltin("get_tweets", r.getTweets),
+		"search_tweets":    starlark.NewBuiltin("search_tweets", r.searchTweets),
+		"get_quote_tweets": starlark.NewBuiltin("get_quote_tweets", r.getQuoteTweets),
+		"get_profile":      starlark.NewBuiltin("get_profile", r.getProfile),
+		"emit":             starlark.NewBuiltin("emit", r.emitTweet),
+	}
+	globals, err := starlark.ExecFile(thread, filename, src, predeclared)
+	if main, ok := globals["main"].(starlark.Callable); ok && err == nil {
+		_, err = starlark.Call(thread, main, nil, nil)
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+type runner struct {
+	ctx     context.Context
+	scraper *twitterscraper.Scraper
+	emit    func(*twitterscraper.Tweet) error
+}
+
+func (r runner) getTweet(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var id string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "id", &id); err != nil {
+		return nil, err
+	}
+	tweet, err := r.scraper.GetTweet(r.ctx, id)
+	if errors.Is(err, twitterscraper.ErrTweetNotFound) || errors.Is(err, twitterscraper.ErrAgeRestricted) {
+		return starlark.None, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+	return newTweet(tweet), nil
+}
+
+func (r runner) getTweets(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var username string
+	limit := 100
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "username", &username, "limit?", &limit); err != nil {
+		return nil, err
+	}
+	return collect(b, r.scraper.GetTweets(r.ctx, username, limit))
+}
+
+func (r runner) searchTweets(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var query string
+	limit := 50
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "query", &query, "limit?", &limit); err != nil {
+		return nil, err
+	}
+	return collect(b, r.scraper.SearchTweets(r.ctx, query, limit))
+}
+
+func (r runner) getQuoteTweets(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var id string
+	limit := 100
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "id", &id, "limit?", &limit); err != nil {
+		return nil, err
+	}
+	return collect(b, r.scraper.GetQuoteTweets(r.ctx, id, limit))
+}
+
+func (r runner) getProfile(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var username string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "username", &username); err != nil {
+		return nil, err
+	}
+	profile, err := r.scraper.GetProfile(r.ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+	return newProfile(&profile), nil
+}
+
+func (r runner) emitTweet(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var value starlark.Value
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "tweet", &value); err != nil {
+		return nil, err
+	}
+	tweet, ok := value.(*object)
+	if !ok || tweet.tweet == nil {
+		return nil, fmt.Errorf("%s: got %s, want tweet", b.Name(), value.Type())
+	}
+	if err := r.emit(tweet.tweet); err != nil {
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+	return starlark.None, nil
+}
+
+// collect reads all tweets of channel, the first error fails builtin.
+func collect(b *starlark.Builtin, results <-chan *twitterscraper.TweetResult) (starlark.Value, error) {
+	var tweets []starlark.Value
+	var err error
+	for result := range results {
+		if result.Error != nil {
+			if err == nil {
+				err = fmt.Errorf("%s: %w", b.Name(), result.Error)
+			}
+			continue
+		}
+		tweet := result.Tweet
+		tweets = append(tweets, newTweet(&tweet))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return starlark.NewList(tweets), nil
+}
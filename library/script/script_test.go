@@ -0,0 +1,93 @@
+package script_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+	"github.com/imperatrona/twitter-scraper/script"
+)
+
+func newTestScraper(t *testing.T) *twitterscraper.Scraper {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/TweetDetail") && strings.Contains(r.URL.Query().Get("variables"), `"1"`):
+			w.Write([]byte(`{"data":{"threaded_conversation_with_injections_v2":{"instructions":[{"entries":[{"entryId":"tweet-1",
+				"content":{"itemContent":{"tweet_results":{"result":{"__typename":"Tweet","legacy":{"id_str":"1","user_id_str":"42","full_text":"hello #golang","entities":{"hashtags":[{"text":"golang"}]}}}}}}}]}]}}}`))
+		case strings.HasSuffix(r.URL.Path, "/TweetDetail"):
+			w.Write([]byte(`{"data":{"threaded_conversation_with_injections_v2":{"instructions":[]}}}`))
+		case strings.HasSuffix(r.URL.Path, "/UserByScreenName"):
+			w.Write([]byte(`{"data":{"user":{"result":{"rest_id":"42","legacy":{"screen_name":"gopher","name":"Gopher","followers_count":7}}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	target, _ := url.Parse(ts.URL)
+
+	scraper := twitterscraper.New()
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "token", CSRFToken: "ct0"})
+	scraper.BeforeRequest(func(req *http.Request) {
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+	})
+	return scraper
+}
+
+func TestRun(t *testing.T) {
+	src := `
+def main():
+    tweet = get_tweet("1")
+    if "golang" in tweet.hashtags:
+        emit(tweet)
+        author = get_profile("gopher")
+        if author.followers != 7 or author.user_id != "42":
+            fail("unexpected profile %s" % author)
+    if get_tweet("2") != None:
+        fail("expected None for missing tweet")
+`
+	var emitted []string
+	err := script.Run(context.Background(), newTestScraper(t), "flow.star", src, func(tweet *twitterscraper.Tweet) error {
+		emitted = append(emitted, tweet.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(emitted) != 1 || emitted[0] != "1" {
+		t.Errorf("Expected emitted tweet 1, got %v", emitted)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	errOutput := errors.New("output closed")
+	err := script.Run(context.Background(), newTestScraper(t), "flow.star", `emit(get_tweet("1"))`, func(*twitterscraper.Tweet) error {
+		return errOutput
+	})
+	if err == nil || !strings.Contains(err.Error(), "output closed") {
+		t.Errorf("Expected emit error, got %v", err)
+	}
+
+	err = script.Run(context.Background(), newTestScraper(t), "flow.star", `emit(get_profile("gopher"))`, nil)
+	if err == nil || !strings.Contains(err.Error(), "want tweet") {
+		t.Errorf("Expected error emitting profile, got %v", err)
+	}
+
+	// endless loop is stopped by deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = script.Run(ctx, newTestScraper(t), "flow.star", `
+def main():
+    for i in range(1 << 62):
+        pass
+`, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package script
+
+import (
+	"fmt"
+	"sort"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+	"go.starlark.net/starlark"
+)
+
+// object is read-only tweet or profile with attributes, tweet keeps scraped
+// tweet for emit.
+type object struct {
+	kind  string
+	attrs starlark.StringDict
+	tweet *twitterscraper.Tweet
+}
+
+func newTweet(tweet *twitterscraper.Tweet) *object {
+	return &object{kind: "tweet", tweet: tweet, attrs: starlark.StringDict{
+		"id":                    starlark.String(tweet.ID),
+		"text":                  starlark.String(tweet.Text),
+		"username":              starlark.String(tweet.Username),
+		"user_id":               starlark.String(tweet.UserID),
+		"name":                  starlark.String(tweet.Name),
+		"timestamp":             starlark.MakeInt64(tweet.Timestamp),
+		"likes":                 starlark.MakeInt(tweet.Likes),
+		"retweets":              starlark.MakeInt(tweet.Retweets),
+		"replies":               starlark.MakeInt(tweet.Replies),
+		"views":                 starlark.MakeInt(tweet.Views),
+		"hashtags":              stringList(tweet.Hashtags),
+		"urls":                  stringList(tweet.URLs),
+		"permanent_url":         starlark.String(tweet.PermanentURL),
+		"conversation_id":       starlark.String(tweet.ConversationID),
+		"is_reply":              starlark.Bool(tweet.IsReply),
+		"is_retweet":            starlark.Bool(tweet.IsRetweet),
+		"is_quoted":             starlark.Bool(tweet.IsQuoted),
+		"quoted_status_id":      starlark.String(tweet.QuotedStatusID),
+		"in_reply_to_status_id": starlark.String(tweet.InReplyToStatusID),
+		"retweeted_status_id":   starlark.String(tweet.RetweetedStatusID),
+	}}
+}
+
+func newProfile(profile *twitterscraper.Profile) *object {
+	return &object{kind: "profile", attrs: starlark.StringDict{
+		"username":    starlark.String(profile.Username),
+		"user_id":     starlark.String(profile.UserID),
+		"name":        starlark.String(profile.Name),
+		"biography":   starlark.String(profile.Biography),
+		"followers":   starlark.MakeInt(profile.FollowersCount),
+		"following":   starlark.MakeInt(profile.FollowingCount),
+		"tweets":      starlark.MakeInt(profile.TweetsCount),
+		"is_verified": starlark.Bool(profile.IsVerified),
+		"is_private":  starlark.Bool(profile.IsPrivate),
+	}}
+}
+
+func stringList(values []string) *starlark.List {
+	list := make([]starlark.Value, len(values))
+	for i, value := range values {
+		list[i] = starlark.String(value)
+	}
+	return starlark.NewList(list)
+}
+
+func (o *object) String() string {
+	if o.tweet != nil {
+		return fmt.Sprintf("tweet(%s)", o.tweet.ID)
+	}
+	return fmt.Sprintf("%s(%s)", o.kind, o.attrs["username"].(starlark.String).GoString())
+}
+
+func (o *object) Type() string          { return o.kind }
+func (o *object) Freeze()               { o.attrs.Freeze() }
+func (o *object) Truth() starlark.Bool  { return starlark.True }
+func (o *object) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: %s", o.kind) }
+
+func (o *object) Attr(name string) (starlark.Value, error) {
+	return o.attrs[name], nil
+}
+
+func (o *object) AttrNames() []string {
+	names := make([]string, 0, len(o.attrs))
+	for name := range o.attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
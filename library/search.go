@@ -3,12 +3,21 @@ package twitterscraper
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 const searchURL = "https://twitter.com/i/api/graphql/nK1dw4oV3k4w5TdtcAdSww/SearchTimeline"
 
+const (
+	// searchWindow is time range of one query of SearchTweetsAll.
+	searchWindow = 24 * time.Hour
+	// searchTimeFormat of since: and until: operators.
+	searchTimeFormat = "2006-01-02_15:04:05_UTC"
+)
+
 type searchTimeline struct {
 	Data struct {
 		SearchByRawQuery struct {
@@ -35,6 +44,9 @@ func (timeline *searchTimeline) parseTweets() ([]*Tweet, string) {
 				continue
 			}
 			for _, entry := range instruction.Entries {
+				if isAIModule(entry.EntryID, entry.Content.ItemContent.ItemType, entry.Content.Items) {
+					continue
+				}
 				if entry.Content.ItemContent.TweetDisplayType == "Tweet" {
 					var legacy *legacyTweet = &entry.Content.ItemContent.TweetResults.Result.Legacy
 					var user *legacyUser = &entry.Content.ItemContent.TweetResults.Result.Core.UserResults.Result.Legacy
@@ -43,6 +55,7 @@ func (timeline *searchTimeline) parseTweets() ([]*Tweet, string) {
 						user = &entry.Content.ItemContent.TweetResults.Result.Tweet.Core.UserResults.Result.Legacy
 					}
 					if tweet := parseLegacyTweet(user, legacy); tweet != nil {
+						entry.Content.ItemContent.PromotedMetadata.flag(tweet)
 						var views = entry.Content.ItemContent.TweetResults.Result.Views.Count
 						if entry.Content.ItemContent.TweetResults.Result.Typename == "TweetWithVisibilityResults" {
 							views = entry.Content.ItemContent.TweetResults.Result.Tweet.Views.Count
@@ -89,27 +102,96 @@ func (timeline *searchTimeline) parseUsers() ([]*Profile, string) {
 
 // SearchTweets returns channel with tweets for a given search query
 func (s *Scraper) SearchTweets(ctx context.Context, query string, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, query, maxTweetsNbr, s.FetchSearchTweets)
+	return s.getTweetTimeline(ctx, query, maxTweetsNbr, s.FetchSearchTweets)
+}
+
+// SearchTweetsAll returns channel with all tweets for a given search query
+// posted in [since, until), from the newest. Search returns only about 800
+// tweets for a query, so the range is split into daily windows with since:
+// and until: operators, every window is paginated in latest mode and tweets
+// repeated at window boundaries are skipped. Zero until means now.
+func (s *Scraper) SearchTweetsAll(ctx context.Context, query string, since, until time.Time) <-chan *TweetResult {
+	if until.IsZero() {
+		until = time.Now()
+	}
+	channel := make(chan *TweetResult)
+	go func() {
+		defer close(channel)
+		// send returns false if context is done and consumer may not read anymore
+		send := func(result *TweetResult) bool {
+			select {
+			case channel <- result:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		seen := make(map[string]bool)
+		for windowUntil := until; windowUntil.After(since); windowUntil = windowUntil.Add(-searchWindow) {
+			windowSince := windowUntil.Add(-searchWindow)
+			if windowSince.Before(since) {
+				windowSince = since
+			}
+			windowQuery := fmt.Sprintf("%s since:%s until:%s", query,
+				windowSince.UTC().Format(searchTimeFormat), windowUntil.UTC().Format(searchTimeFormat))
+
+			var cursor string
+			for {
+				if ctx.Err() != nil {
+					send(&TweetResult{Error: ctx.Err()})
+					return
+				}
+				tweets, next, err := s.fetchSearchTweets(ctx, windowQuery, 50, cursor, SearchLatest)
+				if err != nil {
+					send(&TweetResult{Error: err})
+					return
+				}
+
+				for _, tweet := range tweets {
+					if seen[tweet.ID] {
+						continue
+					}
+					if !tweet.TimeParsed.IsZero() && (tweet.TimeParsed.Before(windowSince) || !tweet.TimeParsed.Before(windowUntil)) {
+						continue
+					}
+					seen[tweet.ID] = true
+					if !s.transformTweet(tweet) {
+						continue
+					}
+					if !send(&TweetResult{Tweet: *tweet}) {
+						return
+					}
+				}
+				if len(tweets) == 0 || next == "" || next == cursor {
+					break
+				}
+				cursor = next
+			}
+		}
+	}()
+	return channel
 }
 
-// SearchProfiles returns channel with profiles for a given search query
+// SearchProfiles returns channel with profiles for a given search query,
+// resumes as GetFollowers.
 func (s *Scraper) SearchProfiles(ctx context.Context, query string, maxProfilesNbr int) <-chan *ProfileResult {
-	return getUserTimeline(ctx, query, maxProfilesNbr, s.FetchSearchProfiles)
+	return getUserTimeline(ctx, query, maxProfilesNbr, s.trackProfiles(cursorKey("search-profiles", query), s.FetchSearchProfiles))
 }
 
 // getSearchTimeline gets results for a given search query, via the Twitter frontend API
-func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*searchTimeline, error) {
+func (s *Scraper) getSearchTimeline(ctx context.Context, query string, maxNbr int, cursor string, mode SearchMode) (*searchTimeline, requestMeta, error) {
 	if !s.isLogged {
-		return nil, errors.New("scraper is not logged in for search")
+		return nil, requestMeta{}, errors.New("scraper is not logged in for search")
 	}
 
 	if maxNbr > 50 {
 		maxNbr = 50
 	}
 
-	req, err := s.newRequest("GET", searchURL)
+	req, err := s.newRequest(ctx, "GET", searchURL)
 	if err != nil {
-		return nil, err
+		return nil, requestMeta{}, err
 	}
 
 	variables := map[string]interface{}{
@@ -149,7 +231,7 @@ func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*s
 	if cursor != "" {
 		variables["cursor"] = cursor
 	}
-	switch s.searchMode {
+	switch mode {
 	case SearchLatest:
 		variables["product"] = "Latest"
 	case SearchPhotos:
@@ -162,34 +244,47 @@ func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*s
 
 	q := url.Values{}
 	q.Set("variables", mapToJSONString(variables))
-	q.Set("features", mapToJSONString(features))
+	q.Set("features", mapToJSONString(s.withFeatures(features)))
 	q.Set("fieldToggles", mapToJSONString(fieldToggles))
 	req.URL.RawQuery = q.Encode()
 
 	var timeline searchTimeline
-	err = s.RequestAPI(req, &timeline)
+	meta, err := s.requestAPIMeta(req, &timeline)
 	if err != nil {
-		return nil, err
+		return nil, meta, err
 	}
-	return &timeline, nil
+	return &timeline, meta, nil
 }
 
 // FetchSearchTweets gets tweets for a given search query, via the Twitter frontend API
-func (s *Scraper) FetchSearchTweets(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-	timeline, err := s.getSearchTimeline(query, maxTweetsNbr, cursor)
+func (s *Scraper) FetchSearchTweets(ctx context.Context, query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchSearchTweets(ctx, query, maxTweetsNbr, cursor, s.searchMode)
+}
+
+func (s *Scraper) fetchSearchTweets(ctx context.Context, query string, maxTweetsNbr int, cursor string, mode SearchMode) ([]*Tweet, string, error) {
+	timeline, meta, err := s.getSearchTimeline(ctx, query, maxTweetsNbr, cursor, mode)
 	if err != nil {
 		return nil, "", err
 	}
 	tweets, nextCursor := timeline.parseTweets()
+	s.setProvenance(tweets, meta, EndpointSearch, cursor)
+	s.reportModules(timeline.modules(), meta, EndpointSearch, cursor)
+	if tweets, err = s.filterTweets(tweets); err != nil {
+		return nil, "", err
+	}
 	return tweets, nextCursor, nil
 }
 
-// FetchSearchProfiles gets users for a given search query, via the Twitter frontend API
-func (s *Scraper) FetchSearchProfiles(query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
-	timeline, err := s.getSearchTimeline(query, maxProfilesNbr, cursor)
+// FetchSearchProfiles gets users for a given search query, via the Twitter
+// frontend API. People results are requested regardless of search mode.
+func (s *Scraper) FetchSearchProfiles(ctx context.Context, query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	timeline, _, err := s.getSearchTimeline(ctx, query, maxProfilesNbr, cursor, SearchUsers)
 	if err != nil {
 		return nil, "", err
 	}
 	users, nextCursor := timeline.parseUsers()
+	if users, err = s.filterProfiles(users); err != nil {
+		return nil, "", err
+	}
 	return users, nextCursor, nil
 }
@@ -3,6 +3,7 @@ package twitterscraper_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	twitterscraper "github.com/imperatrona/twitter-scraper"
 )
@@ -16,7 +17,7 @@ func TestFetchSearchCursor(t *testing.T) {
 	tweetsNbr := 0
 	nextCursor := ""
 	for tweetsNbr < maxTweetsNbr {
-		tweets, cursor, err := testScraper.FetchSearchTweets("twitter", maxTweetsNbr, nextCursor)
+		tweets, cursor, err := testScraper.FetchSearchTweets(context.Background(), "twitter", maxTweetsNbr, nextCursor)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -95,3 +96,55 @@ func TestGetSearchTweets(t *testing.T) {
 		t.Errorf("Expected tweets count=%v, got: %v", maxTweetsNbr, count)
 	}
 }
+
+func TestSearchTweetsAll(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	until := time.Now().Add(-time.Hour)
+	since := until.Add(-36 * time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	count := 0
+	dupcheck := make(map[string]bool)
+	for tweet := range testScraper.SearchTweetsAll(ctx, "from:x", since, until) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		if dupcheck[tweet.ID] {
+			t.Errorf("Detect duplicated tweet ID: %s", tweet.ID)
+		}
+		dupcheck[tweet.ID] = true
+		if tweet.TimeParsed.Before(since) || !tweet.TimeParsed.Before(until) {
+			t.Errorf("Expected tweet %s posted in range, got %v", tweet.ID, tweet.TimeParsed)
+		}
+		if count++; count == 100 {
+			cancel()
+			break
+		}
+	}
+}
+
+func TestSearchProfilesIgnoresSearchMode(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	testScraper.ClearCursors()
+	defer testScraper.ClearCursors()
+	testScraper.SetSearchMode(twitterscraper.SearchLatest)
+
+	count := 0
+	for profile := range testScraper.SearchProfiles(context.Background(), "Twitter", 20) {
+		if profile.Error != nil {
+			t.Fatal(profile.Error)
+		}
+		if profile.UserID == "" {
+			t.Error("Expected UserID is empty")
+		}
+		count++
+	}
+	if count != 20 {
+		t.Errorf("Expected 20 profiles, got %d", count)
+	}
+}
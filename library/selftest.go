@@ -0,0 +1,184 @@
+package twitterscraper
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// selfTestURL is requested to check TLS and HTTP version of connection to API.
+const selfTestURL = "https://api.twitter.com/"
+
+// reputationFields are boolean fields of IP checker response meaning the IP
+// is likely to be treated as automated traffic.
+var reputationFields = map[string]bool{
+	"proxy": true, "vpn": true, "tor": true, "hosting": true, "abuser": true,
+	"is_proxy": true, "is_vpn": true, "is_tor": true, "is_hosting": true, "is_datacenter": true, "is_abuser": true,
+}
+
+type (
+	// SelfTestCheck is result of one check of SelfTest.
+	SelfTestCheck struct {
+		Name string `json:"name"`
+		// OK is false if check failed or found detection risk.
+		OK     bool   `json:"ok"`
+		Detail string `json:"detail"`
+	}
+
+	// SelfTestReport of SelfTest.
+	SelfTestReport struct {
+		Checks []SelfTestCheck `json:"checks"`
+	}
+)
+
+// Risks returns failed checks.
+func (r *SelfTestReport) Risks() []SelfTestCheck {
+	var risks []SelfTestCheck
+	for _, check := range r.Checks {
+		if !check.OK {
+			risks = append(risks, check)
+		}
+	}
+	return risks
+}
+
+func (r *SelfTestReport) add(name string, ok bool, detail string) {
+	r.Checks = append(r.Checks, SelfTestCheck{Name: name, OK: ok, Detail: detail})
+}
+
+// SelfTest checks how requests of scraper look to API before a big run:
+// User-Agent, TLS and HTTP version of connection, issuance of guest token
+// and reputation of IP by checkers. Checker is URL returning JSON object,
+// such as http://ip-api.com/json/?fields=query,proxy,hosting, its true
+// proxy, vpn, tor, hosting or abuser fields are reported as risks. Requests
+// go through proxy of scraper, not through proxies bound to pool accounts.
+func (s *Scraper) SelfTest(ctx context.Context, checkers ...string) *SelfTestReport {
+	report := &SelfTestReport{}
+	s.checkUserAgent(report)
+	s.checkConnection(ctx, report)
+
+	if err := s.GetGuestToken(ctx); err != nil {
+		report.add("guest-token", false, fmt.Sprintf("guest token not issued: %v", err))
+	} else {
+		report.add("guest-token", true, "guest token issued")
+	}
+
+	for _, checker := range checkers {
+		s.checkReputation(ctx, report, checker)
+	}
+	return report
+}
+
+func (s *Scraper) checkUserAgent(report *SelfTestReport) {
+	switch ua := s.userAgent; {
+	case ua == "":
+		report.add("user-agent", false, "User-Agent is empty, Go default is sent")
+	case strings.Contains(ua, "Go-http-client"):
+		report.add("user-agent", false, "User-Agent of Go HTTP client")
+	case !strings.HasPrefix(ua, "Mozilla/"):
+		report.add("user-agent", false, fmt.Sprintf("User-Agent %q is not of browser", ua))
+	default:
+		report.add("user-agent", true, ua)
+	}
+}
+
+// checkConnection reports connection to API older than browsers use.
+func (s *Scraper) checkConnection(ctx context.Context, report *SelfTestReport) {
+	req, err := http.NewRequestWithContext(ctx, "GET", selfTestURL, nil)
+	if err != nil {
+		report.add("tls", false, err.Error())
+		return
+	}
+	req.Header.Set("User-Agent", s.userAgent)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		report.add("tls", false, fmt.Sprintf("connection failed: %v", err))
+		return
+	}
+	resp.Body.Close()
+
+	if resp.TLS == nil {
+		report.add("tls", false, "connection is not encrypted")
+		return
+	}
+	detail := fmt.Sprintf("%s, %s", tlsVersionName(resp.TLS.Version), resp.Proto)
+	switch {
+	case resp.TLS.Version < tls.VersionTLS13:
+		report.add("tls", false, detail+", browsers use TLS 1.3")
+	case resp.ProtoMajor < 2:
+		report.add("tls", false, detail+", browsers use HTTP/2")
+	default:
+		report.add("tls", true, detail)
+	}
+}
+
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	}
+	return fmt.Sprintf("TLS 0x%04x", version)
+}
+
+// checkReputation reports risky fields of checker response.
+func (s *Scraper) checkReputation(ctx context.Context, report *SelfTestReport, checker string) {
+	name := "ip:" + checker
+	req, err := http.NewRequestWithContext(ctx, "GET", checker, nil)
+	if err != nil {
+		report.add(name, false, err.Error())
+		return
+	}
+	req.Header.Set("User-Agent", s.userAgent)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		report.add(name, false, fmt.Sprintf("checker failed: %v", err))
+		return
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		report.add(name, false, fmt.Sprintf("checker failed: %v", err))
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		report.add(name, false, fmt.Sprintf("checker returned %s", resp.Status))
+		return
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		report.add(name, false, fmt.Sprintf("checker returned invalid JSON: %v", err))
+		return
+	}
+	var flagged []string
+	for field, value := range fields {
+		if b, ok := value.(bool); ok && b && reputationFields[strings.ToLower(field)] {
+			flagged = append(flagged, field)
+		}
+	}
+	sort.Strings(flagged)
+
+	ip := ""
+	for _, field := range []string{"ip", "query"} {
+		if value, ok := fields[field].(string); ok {
+			ip = value + " "
+			break
+		}
+	}
+	if len(flagged) > 0 {
+		report.add(name, false, fmt.Sprintf("IP %sflagged as %s", ip, strings.Join(flagged, ", ")))
+		return
+	}
+	report.add(name, true, fmt.Sprintf("IP %sis not flagged", ip))
+}
@@ -0,0 +1,47 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestSelfTestReputation(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/clean" {
+			w.Write([]byte(`{"query":"203.0.113.1","proxy":false,"hosting":false}`))
+			return
+		}
+		w.Write([]byte(`{"query":"203.0.113.1","proxy":false,"hosting":true}`))
+	}))
+	defer ts.Close()
+
+	scraper := twitterscraper.New()
+	report := scraper.SelfTest(context.Background(), ts.URL+"/clean", ts.URL+"/datacenter")
+
+	checks := make(map[string]twitterscraper.SelfTestCheck)
+	for _, check := range report.Checks {
+		checks[check.Name] = check
+	}
+	if !checks["user-agent"].OK {
+		t.Errorf("Expected default User-Agent to pass, got %+v", checks["user-agent"])
+	}
+	if clean := checks["ip:"+ts.URL+"/clean"]; !clean.OK {
+		t.Errorf("Expected clean IP to pass, got %+v", clean)
+	}
+	datacenter := checks["ip:"+ts.URL+"/datacenter"]
+	if datacenter.OK || datacenter.Detail != "IP 203.0.113.1 flagged as hosting" {
+		t.Errorf("Expected hosting IP to be flagged, got %+v", datacenter)
+	}
+
+	found := false
+	for _, risk := range report.Risks() {
+		found = found || risk.Name == datacenter.Name
+	}
+	if !found {
+		t.Error("Expected flagged IP in risks")
+	}
+}
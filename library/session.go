@@ -0,0 +1,388 @@
+package twitterscraper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// maximum tweets fetched by thread verification per target
+	maxThreadGapFetches = 50
+	// snowflakeEpoch is unix time in milliseconds of zero time of tweet IDs
+	snowflakeEpoch = 1288834974657
+	// snowflakeMinID is the first tweet ID with time
+	snowflakeMinID = 29700859247
+)
+
+type (
+	// Sink receives tweets scraped in session.
+	Sink interface {
+		WriteTweet(tweet *Tweet) error
+	}
+
+	// Session scrapes timelines of many users: tweets are deduplicated, self
+	// threads are assembled and tweets of every user are sorted before writing
+	// to sinks.
+	Session struct {
+		scraper       *Scraper
+		maxTweetsNbr  int
+		tracker       Tracker
+		verifyThreads bool
+	}
+
+	// Target of session with policy overriding session defaults.
+	Target struct {
+		Username string
+		// MaxTweets overrides session limit if greater than 0.
+		MaxTweets int
+		// Replies includes replies of user.
+		Replies bool
+		// AuthorReplies limits timeline to replies of user to other users, see
+		// GetAuthorReplies. It takes precedence over Replies.
+		AuthorReplies bool
+		// Since and Until limit time of tweets, zero time means no limit.
+		Since time.Time
+		Until time.Time
+		// Sinks receive tweets of target instead of session sinks, if set.
+		Sinks []Sink
+		// Politeness overrides delay and page size of scraper profile for
+		// requests of target, if set.
+		Politeness *Politeness
+	}
+
+	// SessionResult of Session.Run.
+	SessionResult struct {
+		Targets int
+		Failed  int
+		Tweets  int
+		// Errors of failed targets.
+		Errors map[string]error
+	}
+
+	ndjsonSink struct {
+		encoder *json.Encoder
+	}
+)
+
+// NewSession creates session scraping up to maxTweetsNbr tweets of every user.
+// Use AddAccount on scraper to rotate accounts.
+func NewSession(scraper *Scraper, maxTweetsNbr int) *Session {
+	return &Session{scraper: scraper, maxTweetsNbr: maxTweetsNbr}
+}
+
+// WithTracker set tracker of processed tweets and threads, which is not
+// closed by session. By default tweets are tracked in memory for one run.
+func (session *Session) WithTracker(tracker Tracker) *Session {
+	session.tracker = tracker
+	return session
+}
+
+// WithThreadVerification makes session check threads of every target for
+// gaps: own tweet replied by author, missing from timeline while newer than
+// its oldest scraped tweet, is fetched before threads are assembled. Tweet
+// which is not found, age-restricted or of protected or suspended author is
+// written as tombstone with IsTombstone and TombstoneReason set.
+func (session *Session) WithThreadVerification(verify bool) *Session {
+	session.verifyThreads = verify
+	return session
+}
+
+// NewNDJSONSink writes every tweet as line of JSON to w.
+func NewNDJSONSink(w io.Writer) Sink {
+	return &ndjsonSink{encoder: json.NewEncoder(w)}
+}
+
+func (sink *ndjsonSink) WriteTweet(tweet *Tweet) error {
+	return sink.encoder.Encode(tweet)
+}
+
+// Run scrapes tweets of targets, which are usernames. Failed targets are skipped
+// and reported in result, while rate limit, context cancellation or sink error
+// stop session and are returned with result so far.
+func (session *Session) Run(ctx context.Context, targets []string, sinks ...Sink) (*SessionResult, error) {
+	list := make([]Target, len(targets))
+	for i, username := range targets {
+		list[i] = Target{Username: username}
+	}
+	return session.RunTargets(ctx, list, sinks...)
+}
+
+// RunTargets is the same as Run, but every target may have own limit, replies,
+// time range and sinks.
+func (session *Session) RunTargets(ctx context.Context, targets []Target, sinks ...Sink) (*SessionResult, error) {
+	result := &SessionResult{Targets: len(targets), Errors: make(map[string]error)}
+	tracker := session.tracker
+	if tracker == nil {
+		tracker = NewMemoryTracker()
+	}
+
+	for _, target := range targets {
+		tweets, err := session.scrapeTarget(ctx, target, tracker)
+		if err == nil && session.verifyThreads {
+			tweets, err = session.fillThreadGaps(ctx, tweets, tracker)
+		}
+		if err != nil {
+			result.Errors[target.Username] = err
+			result.Failed++
+		}
+
+		assembleThreads(tweets)
+		roots, trackErr := continueThreads(tracker, tweets)
+		if trackErr != nil {
+			return result, trackErr
+		}
+		SortTweets(tweets)
+		targetSinks := sinks
+		if len(target.Sinks) > 0 {
+			targetSinks = target.Sinks
+		}
+		for _, tweet := range tweets {
+			for _, sink := range targetSinks {
+				if err := sink.WriteTweet(tweet); err != nil {
+					return result, err
+				}
+			}
+			result.Tweets++
+		}
+		// written tweets are skipped by the next targets and runs sharing tracker
+		for _, tweet := range tweets {
+			if trackErr := tracker.Add(tweet.ID, tweet.UserID, roots[tweet.ID]); trackErr != nil {
+				return result, trackErr
+			}
+		}
+
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		if isRateLimit(err) {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// scrapeTarget returns new tweets of target in its time range.
+func (session *Session) scrapeTarget(ctx context.Context, target Target, tracker Tracker) ([]*Tweet, error) {
+	maxTweetsNbr := session.maxTweetsNbr
+	if target.MaxTweets > 0 {
+		maxTweetsNbr = target.MaxTweets
+	}
+
+	// timeline is cancelled when it gets older than target range
+	timelineCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if target.Politeness != nil {
+		timelineCtx = context.WithValue(timelineCtx, politenessKey{}, *target.Politeness)
+	}
+	timeline := session.scraper.GetTweets(timelineCtx, target.Username, maxTweetsNbr)
+	if target.Replies {
+		timeline = session.scraper.GetTweetsAndReplies(timelineCtx, target.Username, maxTweetsNbr)
+	}
+	if target.AuthorReplies {
+		timeline = session.scraper.GetAuthorReplies(timelineCtx, target.Username, maxTweetsNbr)
+	}
+
+	var tweets []*Tweet
+	var err error
+	seen := make(map[string]bool)
+	for tweet := range timeline {
+		if tweet.Error != nil {
+			if timelineCtx.Err() == nil || ctx.Err() != nil {
+				err = tweet.Error
+			}
+			continue
+		}
+		created := time.Unix(tweet.Timestamp, 0)
+		if !target.Since.IsZero() && created.Before(target.Since) {
+			// pinned tweet may be older than the rest of timeline
+			if !tweet.IsPin {
+				cancel()
+			}
+			continue
+		}
+		if (!target.Until.IsZero() && !created.Before(target.Until)) || seen[tweet.ID] {
+			continue
+		}
+		processed, trackErr := tracker.Seen(tweet.ID)
+		if trackErr != nil {
+			err = trackErr
+			break
+		}
+		if processed {
+			continue
+		}
+		seen[tweet.ID] = true
+		t := tweet.Tweet
+		tweets = append(tweets, &t)
+	}
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	return tweets, err
+}
+
+// continueThreads returns thread root ID of every assembled tweet. Reply of
+// author to own tweet processed before continues its thread and is marked
+// IsSelfThread.
+func continueThreads(tracker Tracker, tweets []*Tweet) (map[string]string, error) {
+	inThread := make(map[string]bool)
+	for _, tweet := range tweets {
+		for _, reply := range tweet.Thread {
+			inThread[reply.ID] = true
+		}
+	}
+
+	roots := make(map[string]string, len(tweets))
+	for _, tweet := range tweets {
+		if inThread[tweet.ID] {
+			continue
+		}
+		rootID := tweet.ID
+		if tweet.InReplyToStatusID != "" {
+			userID, parentRootID, ok, err := tracker.Thread(tweet.InReplyToStatusID)
+			if err != nil {
+				return nil, err
+			}
+			if ok && userID == tweet.UserID {
+				rootID = parentRootID
+				tweet.IsSelfThread = true
+			}
+		}
+		roots[tweet.ID] = rootID
+		for _, reply := range tweet.Thread {
+			roots[reply.ID] = rootID
+		}
+	}
+	return roots, nil
+}
+
+// assembleThreads adds replies of author to own tweets to Thread of the first
+// tweet, replies are ordered from oldest.
+func assembleThreads(tweets []*Tweet) {
+	byID := make(map[string]*Tweet, len(tweets))
+	for _, tweet := range tweets {
+		byID[tweet.ID] = tweet
+	}
+
+	for _, tweet := range tweets {
+		root := tweet
+		for i := 0; i < len(tweets); i++ {
+			parent, ok := byID[root.InReplyToStatusID]
+			if !ok || parent.UserID != root.UserID {
+				break
+			}
+			root = parent
+		}
+		if root != tweet {
+			root.Thread = append(root.Thread, tweet)
+			root.IsSelfThread = true
+		}
+	}
+
+	for _, tweet := range tweets {
+		thread := tweet.Thread
+		sort.SliceStable(thread, func(i, j int) bool {
+			return thread[i].Timestamp < thread[j].Timestamp
+		})
+	}
+}
+
+// fillThreadGaps fetches own tweets of author replied in tweets and missing
+// from them, back to the oldest of tweets, so threads are assembled without
+// gaps. Fetched tweets are checked too, so the whole missing chain is
+// fetched. Tweets which are not found, age-restricted or of protected or
+// suspended author are added as tombstones, other errors stop it.
+func (session *Session) fillThreadGaps(ctx context.Context, tweets []*Tweet, tracker Tracker) ([]*Tweet, error) {
+	if len(tweets) == 0 {
+		return tweets, nil
+	}
+	scraped := make(map[string]bool, len(tweets))
+	oldest := tweets[0].ID
+	for _, tweet := range tweets {
+		scraped[tweet.ID] = true
+		if compareIDs(tweet.ID, oldest) < 0 {
+			oldest = tweet.ID
+		}
+	}
+
+	fetches := 0
+	for i := 0; i < len(tweets) && fetches < maxThreadGapFetches; i++ {
+		tweet := tweets[i]
+		parentID := tweet.InReplyToStatusID
+		if parentID == "" || tweet.InReplyToUserID != tweet.UserID || scraped[parentID] || compareIDs(parentID, oldest) < 0 {
+			continue
+		}
+		_, _, processed, err := tracker.Thread(parentID)
+		if err != nil {
+			return tweets, err
+		}
+		if processed {
+			continue
+		}
+
+		fetches++
+		parent, err := session.scraper.GetTweet(ctx, parentID)
+		if reason := tombstoneReason(err); reason != "" {
+			parent, err = newTombstone(parentID, tweet), nil
+			parent.TombstoneReason = reason
+		}
+		if err != nil {
+			return tweets, err
+		}
+		scraped[parent.ID] = true
+		tweets = append(tweets, parent)
+	}
+	return tweets, nil
+}
+
+// tombstoneReason returns TombstoneReason of tweet unavailable with err,
+// empty if err isn't caused by the tweet.
+func tombstoneReason(err error) string {
+	switch {
+	case errors.Is(err, ErrTweetNotFound), errors.Is(err, ErrUserNotFound):
+		return "NotFound"
+	case errors.Is(err, ErrAgeRestricted):
+		return "AgeRestricted"
+	case errors.Is(err, ErrProtected):
+		return "Protected"
+	case errors.Is(err, ErrUserSuspended):
+		return "Suspended"
+	}
+	return ""
+}
+
+// newTombstone returns placeholder of unavailable tweet of author of reply,
+// time of tweet is taken from its ID.
+func newTombstone(id string, reply *Tweet) *Tweet {
+	tombstone := &Tweet{
+		ConversationID: reply.ConversationID,
+		ID:             id,
+		IsTombstone:    true,
+		Name:           reply.Name,
+		PermanentURL:   fmt.Sprintf("https://twitter.com/%s/status/%s", reply.Username, id),
+		UserID:         reply.UserID,
+		Username:       reply.Username,
+	}
+	if created, ok := snowflakeTime(id); ok {
+		tombstone.TimeParsed = created
+		tombstone.Timestamp = created.Unix()
+	}
+	return tombstone
+}
+
+// snowflakeTime returns time encoded in tweet ID, tweets before November 2010
+// have no time in ID.
+func snowflakeTime(id string) (time.Time, bool) {
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || n < snowflakeMinID {
+		return time.Time{}, false
+	}
+	ms := int64(n>>22) + snowflakeEpoch
+	return time.Unix(ms/1000, ms%1000*int64(time.Millisecond)).UTC(), true
+}
@@ -0,0 +1,163 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+type sliceSink struct {
+	tweets []*twitterscraper.Tweet
+}
+
+func (sink *sliceSink) WriteTweet(tweet *twitterscraper.Tweet) error {
+	sink.tweets = append(sink.tweets, tweet)
+	return nil
+}
+
+func TestSessionRun(t *testing.T) {
+	sink := &sliceSink{}
+	session := twitterscraper.NewSession(newTestScraper(true), 20)
+
+	// the same user twice, tweets must be written once
+	result, err := session.Run(context.Background(), []string{"x", "x"}, sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Targets != 2 || result.Failed != 0 {
+		t.Errorf("Expected 2 successful targets, got %+v", result)
+	}
+	if result.Tweets != len(sink.tweets) || len(sink.tweets) == 0 || len(sink.tweets) > 20 {
+		t.Errorf("Expected up to 20 tweets written once, got %d of %d", len(sink.tweets), result.Tweets)
+	}
+	for i := 1; i < len(sink.tweets); i++ {
+		if sink.tweets[i].Timestamp > sink.tweets[i-1].Timestamp {
+			t.Errorf("Expected tweets from newest, got %s after %s", sink.tweets[i].ID, sink.tweets[i-1].ID)
+		}
+	}
+}
+
+func TestSessionRunTargets(t *testing.T) {
+	sink, xSink := &sliceSink{}, &sliceSink{}
+	session := twitterscraper.NewSession(newTestScraper(true), 20)
+
+	since := time.Now().AddDate(0, -6, 0)
+	result, err := session.RunTargets(context.Background(), []twitterscraper.Target{
+		{Username: "x", MaxTweets: 5, Sinks: []twitterscraper.Sink{xSink}},
+		{Username: "nomadic_ua", Since: since},
+	}, sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Failed != 0 {
+		t.Errorf("Expected no failed targets, got %+v", result)
+	}
+	if len(xSink.tweets) == 0 || len(xSink.tweets) > 5 {
+		t.Errorf("Expected up to 5 tweets in target sink, got %d", len(xSink.tweets))
+	}
+	for _, tweet := range sink.tweets {
+		if tweet.Username == "x" {
+			t.Error("Expected tweets of target with own sinks only there")
+		}
+		if time.Unix(tweet.Timestamp, 0).Before(since) {
+			t.Errorf("Expected tweets since %v, got %s", since, tweet.ID)
+		}
+	}
+}
+
+func TestSessionThreadVerification(t *testing.T) {
+	sink := &sliceSink{}
+	session := twitterscraper.NewSession(newTestScraper(true), 40).WithThreadVerification(true)
+	if _, err := session.RunTargets(context.Background(), []twitterscraper.Target{{Username: "nomadic_ua", Replies: true}}, sink); err != nil {
+		t.Fatal(err)
+	}
+	if len(sink.tweets) == 0 {
+		t.Fatal("Expected tweets")
+	}
+
+	written := make(map[string]bool)
+	oldest := sink.tweets[0].Timestamp
+	for _, tweet := range sink.tweets {
+		written[tweet.ID] = true
+		if !tweet.IsTombstone && tweet.Timestamp < oldest {
+			oldest = tweet.Timestamp
+		}
+	}
+	for _, tweet := range sink.tweets {
+		if tweet.IsTombstone && (tweet.UserID == "" || tweet.Timestamp == 0) {
+			t.Errorf("Expected author and time of tombstone, got %+v", tweet)
+		}
+		if tweet.InReplyToUserID == tweet.UserID && tweet.InReplyToStatusID != "" && !written[tweet.InReplyToStatusID] && tweet.Timestamp > oldest {
+			if parent, err := testScraper.GetTweet(context.Background(), tweet.InReplyToStatusID); err == nil && parent.Timestamp > oldest {
+				t.Errorf("Expected parent %s of %s in thread", parent.ID, tweet.ID)
+			}
+		}
+	}
+}
+
+func TestSessionThreadTombstones(t *testing.T) {
+	tweet := func(id, parentID string) string {
+		return `{"entryId":"tweet-` + id + `","content":{"itemContent":{"tweet_results":{"result":{"__typename":"Tweet",
+			"core":{"user_results":{"result":{"legacy":{"screen_name":"tombstones"}}}},
+			"legacy":{"id_str":"` + id + `","user_id_str":"100","conversation_id_str":"1",
+			"in_reply_to_status_id_str":"` + parentID + `","in_reply_to_user_id_str":"100"}}}}}}`
+	}
+	unavailable := map[string]string{
+		"4": `{"__typename":"TweetUnavailable","reason":"Protected"}`,
+		"2": `{"__typename":"TweetTombstone","tombstone":{"text":{"text":"This Post is from a suspended account. Learn more"}}}`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/UserByScreenName"):
+			w.Write([]byte(`{"data":{"user":{"result":{"rest_id":"100","legacy":{"screen_name":"tombstones"}}}}}`))
+		case strings.HasSuffix(r.URL.Path, "/UserTweets"):
+			w.Write([]byte(`{"data":{"user":{"result":{"timeline_v2":{"timeline":{"instructions":[{"entries":[` +
+				tweet("5", "4") + `,` + tweet("3", "2") + `,` + tweet("1", "") + `]}]}}}}}}`))
+		case strings.HasSuffix(r.URL.Path, "/TweetDetail"):
+			for id, result := range unavailable {
+				if strings.Contains(r.URL.Query().Get("variables"), `"focalTweetId":"`+id+`"`) {
+					w.Write([]byte(`{"data":{"threaded_conversation_with_injections_v2":{"instructions":[{"entries":[{"entryId":"tweet-` + id + `",
+						"content":{"itemContent":{"tweet_results":{"result":` + result + `}}}}]}]}}}`))
+					return
+				}
+			}
+			w.Write([]byte(`{}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer ts.Close()
+	target, _ := url.Parse(ts.URL)
+
+	scraper := twitterscraper.New()
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "token", CSRFToken: "csrf"})
+	scraper.BeforeRequest(func(req *http.Request) {
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+	})
+
+	sink := &sliceSink{}
+	session := twitterscraper.NewSession(scraper, 20).WithThreadVerification(true)
+	result, err := session.Run(context.Background(), []string{"tombstones"}, sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Failed != 0 {
+		t.Fatalf("Expected unavailable parents not to fail target, got %v", result.Errors)
+	}
+	reasons := make(map[string]string)
+	for _, tweet := range sink.tweets {
+		if tweet.IsTombstone {
+			reasons[tweet.ID] = tweet.TombstoneReason
+		}
+	}
+	if len(reasons) != 2 || reasons["4"] != "Protected" || reasons["2"] != "Suspended" {
+		t.Errorf("Expected tombstones of protected and suspended parents, got %v", reasons)
+	}
+}
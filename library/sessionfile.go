@@ -0,0 +1,114 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// savedSession is authentication state and cursors of scraper stored in session file.
+type savedSession struct {
+	Cookies          []*http.Cookie `json:"cookies"`
+	GuestToken       string         `json:"guest_token,omitempty"`
+	GuestCreatedAt   time.Time      `json:"guest_created_at,omitempty"`
+	BearerToken      string         `json:"bearer_token"`
+	OAuthToken       string         `json:"oauth_token,omitempty"`
+	OAuthTokenSecret string         `json:"oauth_token_secret,omitempty"`
+	IsLogged         bool           `json:"is_logged"`
+	IsOpenAccount    bool           `json:"is_open_account,omitempty"`
+	// Cursors of interrupted scrapes.
+	Cursors map[string]string `json:"cursors,omitempty"`
+}
+
+// WriteSession writes cookies, guest token, bearer token, OAuth tokens and
+// cursors of interrupted scrapes as JSON to w.
+func (s *Scraper) WriteSession(w io.Writer) error {
+	s.guestMu.Lock()
+	guestToken, guestCreatedAt := s.guestToken, s.guestCreatedAt
+	s.guestMu.Unlock()
+	session := savedSession{
+		Cookies:          s.GetCookies(),
+		GuestToken:       guestToken,
+		GuestCreatedAt:   guestCreatedAt,
+		BearerToken:      s.bearerToken,
+		OAuthToken:       s.oAuthToken,
+		OAuthTokenSecret: s.oAuthSecret,
+		IsLogged:         s.isLogged,
+		IsOpenAccount:    s.isOpenAccount,
+		Cursors:          s.cursors.all(),
+	}
+	if session.Cookies == nil {
+		session.Cookies = []*http.Cookie{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(session)
+}
+
+// SaveSession writes session to file, so it can be resumed with LoadSession
+// without authenticating again. The file contains credentials and is created
+// readable only by owner. File is replaced at once, so it's never half
+// written, and locked while saved: LockError is returned if another run is
+// saving it.
+func (s *Scraper) SaveSession(filename string) error {
+	lock, err := LockFile(filename)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	f, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+
+	if err := s.WriteSession(f); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), filename)
+}
+
+// ReadSession restores session written by WriteSession.
+func (s *Scraper) ReadSession(r io.Reader) error {
+	var session savedSession
+	if err := json.NewDecoder(r).Decode(&session); err != nil {
+		return err
+	}
+
+	s.ClearCookies()
+	s.SetCookies(session.Cookies)
+	if session.BearerToken != "" {
+		s.setBearerToken(session.BearerToken)
+	}
+	s.guestMu.Lock()
+	s.guestToken = session.GuestToken
+	s.guestCreatedAt = session.GuestCreatedAt
+	s.guestMu.Unlock()
+	s.oAuthToken = session.OAuthToken
+	s.oAuthSecret = session.OAuthTokenSecret
+	s.isLogged = session.IsLogged
+	s.isOpenAccount = session.IsOpenAccount
+	s.cursors.reset(session.Cursors)
+	return nil
+}
+
+// LoadSession restores session saved with SaveSession.
+func (s *Scraper) LoadSession(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return s.ReadSession(f)
+}
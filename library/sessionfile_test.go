@@ -0,0 +1,105 @@
+package twitterscraper_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestSaveLoadSession(t *testing.T) {
+	var authorization, cookie string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		cookie = r.Header.Get("Cookie")
+	}))
+	defer server.Close()
+
+	scraper := twitterscraper.New()
+	scraper.SetAuthToken(twitterscraper.AuthToken{Token: "token", CSRFToken: "csrf"})
+	scraper.WithOpenAccount(twitterscraper.OpenAccount{OAuthToken: "oauth", OAuthTokenSecret: "secret"})
+
+	filename := filepath.Join(t.TempDir(), "session.json")
+	if err := scraper.SaveSession(filename); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(filename); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected session file readable only by owner, got %v %v", info.Mode(), err)
+	}
+
+	loaded := twitterscraper.New()
+	if err := loaded.LoadSession(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	cookies := loaded.GetCookies()
+	if len(cookies) != 2 || cookies[0].Value != "token" || cookies[1].Value != "csrf" {
+		t.Errorf("Expected auth_token and ct0 cookies, got %v", cookies)
+	}
+
+	// logged in session doesn't request guest token
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	if err := loaded.RequestAPI(req, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(authorization, "OAuth ") || !strings.Contains(authorization, "oauth_token=oauth,") {
+		t.Errorf("Expected request signed with OAuth token, got %q", authorization)
+	}
+	if cookie != "" {
+		t.Errorf("Expected no twitter.com cookies sent to other host, got %q", cookie)
+	}
+
+	if err := loaded.LoadSession(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for missing session file")
+	}
+}
+
+func TestSaveSessionLocked(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "session.json")
+	lock, err := twitterscraper.LockFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scraper := twitterscraper.New()
+	if err := scraper.SaveSession(filename); !errors.Is(err, twitterscraper.ErrLocked) {
+		t.Errorf("Expected ErrLocked while session file is locked, got %v", err)
+	}
+	lock.Unlock()
+
+	// concurrent savers either save whole session or get LockError
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			saver := twitterscraper.New()
+			saver.SetAuthToken(twitterscraper.AuthToken{Token: "token", CSRFToken: "csrf"})
+			errs[i] = saver.SaveSession(filename)
+		}(i)
+	}
+	wg.Wait()
+	saved := 0
+	for _, err := range errs {
+		if err == nil {
+			saved++
+		} else if !errors.Is(err, twitterscraper.ErrLocked) {
+			t.Errorf("Expected nil or ErrLocked, got %v", err)
+		}
+	}
+	if saved == 0 {
+		t.Error("Expected at least one saver to succeed")
+	}
+	if err := twitterscraper.New().LoadSession(filename); err != nil {
+		t.Errorf("Expected valid session file, got %v", err)
+	}
+	if _, err := os.Stat(filename + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("Expected lock file removed, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+//go:build go1.21
+// +build go1.21
+
+package twitterscraper
+
+import "log/slog"
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogLogger) debug(msg string, args ...interface{}) {
+	l.logger.Debug(msg, args...)
+}
+
+func (l slogLogger) warn(msg string, args ...interface{}) {
+	l.logger.Warn(msg, args...)
+}
+
+// WithLogger set logger of scraper. API requests and responses are logged at
+// debug level with credentials redacted, rate limit waits, retries, accounts
+// excluded from pool and cursor resets are logged as warnings. Scraper
+// doesn't log by default. Requires Go 1.21.
+func (s *Scraper) WithLogger(logger *slog.Logger) *Scraper {
+	s.logger = nil
+	if logger != nil {
+		s.logger = slogLogger{logger: logger}
+	}
+	return s
+}
+
+// WithLogger option, see Scraper.WithLogger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Scraper) error {
+		s.WithLogger(logger)
+		return nil
+	}
+}
@@ -0,0 +1,100 @@
+//go:build go1.21
+// +build go1.21
+
+package twitterscraper_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestWithLogger(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	scraper := twitterscraper.New(twitterscraper.WithLogger(logger), twitterscraper.WithRetry(2, time.Millisecond, 0))
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "secret-token", CSRFToken: "secret-csrf"})
+
+	req, _ := http.NewRequest("GET", ts.URL+"/i/api/graphql/abc/UserTweets", nil)
+	req.Header.Set("X-Client-Transaction-Id", "secret-tx")
+	req.Header.Set("X-Client-Uuid", "secret-uuid")
+	if err := scraper.RequestAPI(req, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	logged := out.String()
+	if strings.Count(logged, "level=DEBUG msg=\"twitterscraper: request\"") != 2 {
+		t.Errorf("Expected 2 requests logged at debug level, got:\n%s", logged)
+	}
+	if !strings.Contains(logged, "level=WARN msg=\"twitterscraper: retrying request\"") {
+		t.Errorf("Expected retry warning, got:\n%s", logged)
+	}
+	if strings.Contains(logged, "secret-") {
+		t.Errorf("Expected credentials to be redacted, got:\n%s", logged)
+	}
+}
+
+func TestAgeRestrictedRetryWaitsForVerifiedAccount(t *testing.T) {
+	// age-verified account rests until the next second after the first request
+	verifiedRequests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := `{"__typename":"TweetTombstone","tombstone":{"text":{"text":"Age-restricted adult content."}}}`
+		if cookie, _ := r.Cookie("auth_token"); cookie.Value == "verified" {
+			verifiedRequests++
+			if verifiedRequests == 1 {
+				w.Header().Set("X-Rate-Limit-Reset", strconv.FormatInt(time.Now().Add(time.Second).Unix(), 10))
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			result = `{"__typename":"Tweet","legacy":{"id_str":"1","full_text":"adult"}}`
+		}
+		w.Write([]byte(`{"data":{"threaded_conversation_with_injections_v2":{"instructions":[{"entries":[{"entryId":"tweet-1",
+			"content":{"itemContent":{"tweet_results":{"result":` + result + `}}}}]}]}}}`))
+	}))
+	defer ts.Close()
+	target, _ := url.Parse(ts.URL)
+
+	var out bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&out, nil))
+	verified := twitterscraper.AuthToken{Token: "verified", CSRFToken: "csrf"}
+	scraper := twitterscraper.New(twitterscraper.WithLogger(logger), twitterscraper.WithRateLimitStrategy(twitterscraper.RateLimitRotate))
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "regular", CSRFToken: "csrf"})
+	scraper.AddAccount(verified)
+	scraper.MarkAgeVerified(verified, true)
+	scraper.BeforeRequest(func(req *http.Request) {
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+	})
+
+	tweet, err := scraper.GetTweet(context.Background(), "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweet.Text != "adult" {
+		t.Errorf("Expected tweet of age-verified account, got %q", tweet.Text)
+	}
+	// regular account is available, but only resting age-verified one can serve retry
+	if waits := strings.Count(out.String(), "rate limited, waiting"); waits != 1 {
+		t.Errorf("Expected one wait for age-verified account, got %d", waits)
+	}
+}
@@ -1,17 +1,25 @@
 package twitterscraper
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/url"
+	"regexp"
 	"time"
 )
 
-func (s *Scraper) GetSpace(id string) (*Space, error) {
+// spaceURLRegexp matches space ID in space URL of tweet.
+var spaceURLRegexp = regexp.MustCompile(`/i/spaces/([A-Za-z0-9]+)`)
+
+// GetSpace returns space with its participants. Playlist of audio is requested
+// too, if space is running or ended with replay.
+func (s *Scraper) GetSpace(ctx context.Context, id string) (*Space, error) {
 	if !s.isLogged {
 		return nil, errors.New("scraper is not logged in")
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/d03OdorPdZ_sH9V3D1_yWQ/AudioSpaceById")
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/d03OdorPdZ_sH9V3D1_yWQ/AudioSpaceById")
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +62,7 @@ func (s *Scraper) GetSpace(id string) (*Space, error) {
 
 	query := url.Values{}
 	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
 	req.URL.RawQuery = query.Encode()
 
 	var spaceData space
@@ -69,9 +77,82 @@ func (s *Scraper) GetSpace(id string) (*Space, error) {
 		return nil, errors.New("some erorr happend")
 	}
 
+	if space.State == "Running" || (space.State == "Ended" && space.ReplayAvailable) {
+		if space.PlaylistURL, err = s.GetSpacePlaylist(ctx, space.MediaKey); err != nil {
+			return nil, err
+		}
+	}
+
 	return space, nil
 }
 
+// GetSpacePlaylist returns URL of HLS playlist of space audio by media key of
+// space, it can be downloaded with ffmpeg to archive space.
+func (s *Scraper) GetSpacePlaylist(ctx context.Context, mediaKey string) (string, error) {
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/1.1/live_video_stream/status/"+url.PathEscape(mediaKey))
+	if err != nil {
+		return "", err
+	}
+	q := req.URL.Query()
+	q.Set("client", "web")
+	q.Set("use_syndication_guest_id", "false")
+	q.Set("cookie_set_host", "twitter.com")
+	req.URL.RawQuery = q.Encode()
+
+	var status struct {
+		Source struct {
+			Location string `json:"location"`
+		} `json:"source"`
+	}
+	if err := s.RequestAPI(req, &status); err != nil {
+		return "", err
+	}
+	if status.Source.Location == "" {
+		return "", fmt.Errorf("no playlist of space media %s", mediaKey)
+	}
+	return status.Source.Location, nil
+}
+
+// SearchLiveSpaces returns up to maxSpacesNbr running spaces shared in tweets
+// matching query. Up to 100 tweets are searched.
+func (s *Scraper) SearchLiveSpaces(ctx context.Context, query string, maxSpacesNbr int) ([]*Space, error) {
+	var spaces []*Space
+	seen := make(map[string]bool)
+	for tweet := range s.SearchTweets(ctx, query+" filter:spaces", 100) {
+		if tweet.Error != nil {
+			return nil, tweet.Error
+		}
+		for _, id := range SpaceIDs(&tweet.Tweet) {
+			if seen[id] || len(spaces) >= maxSpacesNbr {
+				continue
+			}
+			seen[id] = true
+			space, err := s.GetSpace(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if space.State == "Running" {
+				spaces = append(spaces, space)
+			}
+		}
+		if len(spaces) >= maxSpacesNbr {
+			break
+		}
+	}
+	return spaces, nil
+}
+
+// SpaceIDs returns IDs of spaces linked in tweet.
+func SpaceIDs(tweet *Tweet) []string {
+	var ids []string
+	for _, u := range tweet.URLs {
+		if match := spaceURLRegexp.FindStringSubmatch(u); match != nil {
+			ids = append(ids, match[1])
+		}
+	}
+	return ids
+}
+
 type Topic struct {
 	ID    string
 	Title string
@@ -105,6 +186,12 @@ type Space struct {
 	ScheduledStart time.Time
 	StartedAt      time.Time
 	UpdatedAt      time.Time
+	// MediaKey identifies audio of space for GetSpacePlaylist.
+	MediaKey        string
+	ReplayAvailable bool
+	// PlaylistURL is HLS playlist of audio of running space or ended space
+	// with replay.
+	PlaylistURL string
 }
 
 type spaceUser struct {
@@ -249,10 +336,12 @@ func (space *space) parse() *Space {
 			TotalCount:   space.Data.AudioSpace.Metadata.TotalLiveListeners,
 			CurrentCount: space.Data.AudioSpace.Participants.Total,
 		},
-		CreatedAt:      time.Unix(space.Data.AudioSpace.Metadata.CreatedAt/1000, 0),
-		ScheduledStart: time.Unix(space.Data.AudioSpace.Metadata.ScheduledStart/1000, 0),
-		StartedAt:      time.Unix(space.Data.AudioSpace.Metadata.StartedAt/1000, 0),
-		UpdatedAt:      time.Unix(space.Data.AudioSpace.Metadata.UpdatedAt/1000, 0),
+		CreatedAt:       time.Unix(space.Data.AudioSpace.Metadata.CreatedAt/1000, 0),
+		ScheduledStart:  time.Unix(space.Data.AudioSpace.Metadata.ScheduledStart/1000, 0),
+		StartedAt:       time.Unix(space.Data.AudioSpace.Metadata.StartedAt/1000, 0),
+		UpdatedAt:       time.Unix(space.Data.AudioSpace.Metadata.UpdatedAt/1000, 0),
+		MediaKey:        space.Data.AudioSpace.Metadata.MediaKey,
+		ReplayAvailable: space.Data.AudioSpace.Metadata.IsSpaceAvailableForReplay,
 	}
 
 	for _, topic := range space.Data.AudioSpace.Metadata.Topics {
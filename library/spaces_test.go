@@ -1,8 +1,11 @@
 package twitterscraper_test
 
 import (
+	"context"
 	"errors"
 	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
 )
 
 func TestGetSpace(t *testing.T) {
@@ -12,7 +15,7 @@ func TestGetSpace(t *testing.T) {
 
 	spaceId := "1OdJrXPVLEnKX"
 
-	space, err := testScraper.GetSpace(spaceId)
+	space, err := testScraper.GetSpace(context.Background(), spaceId)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -21,3 +24,33 @@ func TestGetSpace(t *testing.T) {
 		t.Fatal(errors.New("returned space id is not requested"))
 	}
 }
+
+func TestSpaceIDs(t *testing.T) {
+	tweet := &twitterscraper.Tweet{URLs: []string{
+		"https://twitter.com/i/spaces/1mnxeAMPEqqxX",
+		"https://example.com/",
+		"https://x.com/i/spaces/1OdJrXPVLEnKX/peek",
+	}}
+	ids := twitterscraper.SpaceIDs(tweet)
+	if len(ids) != 2 || ids[0] != "1mnxeAMPEqqxX" || ids[1] != "1OdJrXPVLEnKX" {
+		t.Errorf("Expected 2 space IDs, got %v", ids)
+	}
+}
+
+func TestSearchLiveSpaces(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	spaces, err := testScraper.SearchLiveSpaces(context.Background(), "crypto", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spaces) > 3 {
+		t.Errorf("Expected up to 3 spaces, got %d", len(spaces))
+	}
+	for _, space := range spaces {
+		if space.State != "Running" || space.PlaylistURL == "" {
+			t.Errorf("Expected running space with playlist, got %+v", space)
+		}
+	}
+}
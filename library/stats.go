@@ -0,0 +1,211 @@
+package twitterscraper
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type (
+	// RequestInfo describes API request passed to request hooks.
+	RequestInfo struct {
+		// RequestID is random ID of request, it's also set in errors, HAR and provenance.
+		RequestID string
+		// Endpoint is GraphQL operation name or path of REST API.
+		Endpoint string
+		// Account is label of pool account or label set with WithLabels.
+		Account string
+		// StatusCode is 0 if request failed without response.
+		StatusCode int
+		// RateLimitRemaining is x-rate-limit-remaining of response, -1 if
+		// response has no such header.
+		RateLimitRemaining int
+		Duration           time.Duration
+		Error              error
+	}
+
+	// Stats of API requests made by scraper.
+	Stats struct {
+		// RunID set with SetRunID.
+		RunID    string
+		Requests int
+		Errors   int
+		// ParseErrors is number of skipped entries, see OnParseError.
+		ParseErrors int
+		ByEndpoint  map[string]int
+		ByAccount   map[string]int
+		ByStatus    map[int]int
+		// AgeRestricted is IDs of age-restricted tweets skipped as pool has
+		// no age-verified account, see MarkAgeVerified.
+		AgeRestricted []string
+	}
+
+	requestStats struct {
+		mu             sync.Mutex
+		stats          Stats
+		hooks          []func(RequestInfo)
+		httpHooks      []func(*http.Request)
+		responseHooks  []func(*http.Response, time.Duration)
+		retryHooks     []func(RetryInfo)
+		parseHooks     []func(*ParseError)
+		recommendHooks []func(*Recommendation)
+		moduleHooks    []func(*OtherModule)
+		lastRequestID  string
+		lastAccount    string
+	}
+)
+
+// Stats returns snapshot of requests counters.
+func (s *Scraper) Stats() Stats {
+	s.stats.mu.Lock()
+	defer s.stats.mu.Unlock()
+
+	snapshot := Stats{
+		RunID:       s.runID,
+		Requests:    s.stats.stats.Requests,
+		Errors:      s.stats.stats.Errors,
+		ParseErrors: s.stats.stats.ParseErrors,
+		ByEndpoint:  make(map[string]int, len(s.stats.stats.ByEndpoint)),
+		ByAccount:   make(map[string]int, len(s.stats.stats.ByAccount)),
+		ByStatus:    make(map[int]int, len(s.stats.stats.ByStatus)),
+	}
+	for endpoint, count := range s.stats.stats.ByEndpoint {
+		snapshot.ByEndpoint[endpoint] = count
+	}
+	for account, count := range s.stats.stats.ByAccount {
+		snapshot.ByAccount[account] = count
+	}
+	for status, count := range s.stats.stats.ByStatus {
+		snapshot.ByStatus[status] = count
+	}
+	snapshot.AgeRestricted = append(snapshot.AgeRestricted, s.stats.stats.AgeRestricted...)
+	return snapshot
+}
+
+// ResetStats set all requests counters to zero.
+func (s *Scraper) ResetStats() {
+	s.stats.mu.Lock()
+	s.stats.stats = Stats{}
+	s.stats.mu.Unlock()
+}
+
+// OnRequest adds hook called after every API request. Hooks are called
+// synchronously, so they should be fast.
+func (s *Scraper) OnRequest(hook func(RequestInfo)) *Scraper {
+	s.stats.mu.Lock()
+	s.stats.hooks = append(s.stats.hooks, hook)
+	s.stats.mu.Unlock()
+	return s
+}
+
+// BeforeRequest adds hook called with every API request right before it's
+// sent, after auth headers are set, so hook can log it or add headers, e.g.
+// for tracing. Hook is called for every attempt of retried request.
+func (s *Scraper) BeforeRequest(hook func(*http.Request)) *Scraper {
+	s.stats.mu.Lock()
+	s.stats.httpHooks = append(s.stats.httpHooks, hook)
+	s.stats.mu.Unlock()
+	return s
+}
+
+// OnResponse adds hook called with every API response and time it took,
+// before body is read. Hook must not read or close body. Requests failed
+// without response don't call it, use OnRequest to see them.
+func (s *Scraper) OnResponse(hook func(*http.Response, time.Duration)) *Scraper {
+	s.stats.mu.Lock()
+	s.stats.responseHooks = append(s.stats.responseHooks, hook)
+	s.stats.mu.Unlock()
+	return s
+}
+
+func (s *Scraper) beforeRequest(req *http.Request) {
+	s.stats.mu.Lock()
+	hooks := s.stats.httpHooks
+	s.stats.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(req)
+	}
+}
+
+func (s *Scraper) onResponse(resp *http.Response, duration time.Duration) {
+	s.stats.mu.Lock()
+	hooks := s.stats.responseHooks
+	s.stats.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(resp, duration)
+	}
+}
+
+// LastAccount returns label of pool account served the last request, empty
+// without pool. With concurrent requests it may be account of other request,
+// use Provenance or RequestInfo to know account of certain request.
+func (s *Scraper) LastAccount() string {
+	s.stats.mu.Lock()
+	defer s.stats.mu.Unlock()
+	return s.stats.lastAccount
+}
+
+// LastRequestID returns ID of the last API request, which is only useful for
+// debugging, as concurrent requests change it. To correlate certain request
+// use RequestID of RequestInfo, RetryInfo, APIError or Provenance.
+func (s *Scraper) LastRequestID() string {
+	s.stats.mu.Lock()
+	defer s.stats.mu.Unlock()
+	return s.stats.lastRequestID
+}
+
+func (s *Scraper) recordRequest(requestID string, req *http.Request, account *poolAccount, resp *http.Response, started time.Time, err error) {
+	info := RequestInfo{
+		RequestID:          requestID,
+		Endpoint:           endpointName(req.URL),
+		Account:            s.accountLabel,
+		RateLimitRemaining: -1,
+		Duration:           time.Since(started),
+		Error:              err,
+	}
+	var label string
+	if account != nil {
+		label = account.status.Label
+		info.Account = label
+	}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+		if remaining, err := strconv.Atoi(resp.Header.Get("X-Rate-Limit-Remaining")); err == nil {
+			info.RateLimitRemaining = remaining
+		}
+	}
+
+	s.stats.mu.Lock()
+	stats := &s.stats.stats
+	if stats.ByEndpoint == nil {
+		stats.ByEndpoint = make(map[string]int)
+		stats.ByAccount = make(map[string]int)
+		stats.ByStatus = make(map[int]int)
+	}
+	stats.Requests++
+	if err != nil {
+		stats.Errors++
+	}
+	stats.ByEndpoint[info.Endpoint]++
+	stats.ByAccount[info.Account]++
+	stats.ByStatus[info.StatusCode]++
+	s.stats.lastRequestID = requestID
+	s.stats.lastAccount = label
+	hooks := s.stats.hooks
+	s.stats.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(info)
+	}
+}
+
+// recordAgeRestricted reports age-restricted tweet skipped for lack of age-verified account.
+func (s *Scraper) recordAgeRestricted(id string) {
+	s.stats.mu.Lock()
+	s.stats.stats.AgeRestricted = append(s.stats.stats.AgeRestricted, id)
+	s.stats.mu.Unlock()
+	s.logWarn("twitterscraper: age-restricted tweet skipped, no age-verified account", "tweet", id)
+}
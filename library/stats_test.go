@@ -0,0 +1,104 @@
+package twitterscraper_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestStats(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/1.1/users/lookup.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-Rate-Limit-Remaining", "42")
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	scraper := twitterscraper.New()
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "token1", CSRFToken: "ct0"})
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "token2", CSRFToken: "ct0"})
+
+	var infos []twitterscraper.RequestInfo
+	scraper.OnRequest(func(info twitterscraper.RequestInfo) {
+		infos = append(infos, info)
+	})
+
+	var lastErr error
+	for _, path := range []string{"/i/api/graphql/abc/UserTweets", "/i/api/graphql/def/UserTweets", "/1.1/users/lookup.json"} {
+		req, _ := http.NewRequest("GET", ts.URL+path, nil)
+		lastErr = scraper.RequestAPI(req, nil)
+	}
+
+	stats := scraper.Stats()
+	if stats.Requests != 3 || stats.Errors != 1 {
+		t.Errorf("Expected 3 requests with 1 error, got %d with %d", stats.Requests, stats.Errors)
+	}
+	if stats.ByEndpoint["UserTweets"] != 2 || stats.ByEndpoint["1.1/users/lookup"] != 1 {
+		t.Errorf("Unexpected requests by endpoint %v", stats.ByEndpoint)
+	}
+	if stats.ByAccount["account-1"] != 2 || stats.ByAccount["account-2"] != 1 {
+		t.Errorf("Unexpected requests by account %v", stats.ByAccount)
+	}
+	if stats.ByStatus[200] != 2 || stats.ByStatus[404] != 1 {
+		t.Errorf("Unexpected requests by status %v", stats.ByStatus)
+	}
+
+	if len(infos) != 3 || infos[2].StatusCode != 404 || infos[2].Error == nil || infos[2].Account != "account-1" {
+		t.Errorf("Unexpected request hook calls %+v", infos)
+	}
+	if infos[0].RateLimitRemaining != 42 || infos[2].RateLimitRemaining != -1 {
+		t.Errorf("Unexpected rate limit remaining %d and %d", infos[0].RateLimitRemaining, infos[2].RateLimitRemaining)
+	}
+
+	var apiErr *twitterscraper.APIError
+	if !errors.As(lastErr, &apiErr) || apiErr.RequestID == "" || apiErr.RequestID != infos[2].RequestID || apiErr.RequestID != scraper.LastRequestID() {
+		t.Errorf("Expected the same request ID in error, hook and scraper, got %v", lastErr)
+	}
+	if infos[0].RequestID == infos[1].RequestID {
+		t.Error("Expected unique request IDs")
+	}
+
+	scraper.ResetStats()
+	if stats := scraper.Stats(); stats.Requests != 0 {
+		t.Errorf("Expected stats to be reset, got %d requests", stats.Requests)
+	}
+}
+
+func TestHTTPHooks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace-Id") != "trace" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	var statuses []int
+	scraper := twitterscraper.New()
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "token", CSRFToken: "ct0"})
+	scraper.BeforeRequest(func(req *http.Request) {
+		req.Header.Set("X-Trace-Id", "trace")
+	})
+	scraper.OnResponse(func(resp *http.Response, took time.Duration) {
+		if took < 0 {
+			t.Errorf("Expected non-negative duration, got %v", took)
+		}
+		statuses = append(statuses, resp.StatusCode)
+	})
+
+	req, _ := http.NewRequest("GET", ts.URL+"/i/api/graphql/abc/UserTweets", nil)
+	if err := scraper.RequestAPI(req, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0] != http.StatusOK {
+		t.Errorf("Expected one response hook call with 200, got %v", statuses)
+	}
+}
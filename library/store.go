@@ -0,0 +1,355 @@
+package twitterscraper
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// maxTweetsInto limits tweets requested by GetTweetsInto, timeline doesn't
+// return more anyway.
+const maxTweetsInto = 3200
+
+// ErrNoFullTextSearch is returned by Search of SQLiteStore opened with driver without FTS5.
+var ErrNoFullTextSearch = errors.New("SQLite driver doesn't support FTS5")
+
+type (
+	// TweetStore keeps scraped tweets. Put of tweet already in store keeps the
+	// stored one.
+	TweetStore interface {
+		Put(tweet *Tweet) error
+		Exists(id string) (bool, error)
+		Close() error
+	}
+
+	// JSONLStore writes tweets as JSON lines.
+	JSONLStore struct {
+		mu     sync.Mutex
+		file   *os.File
+		lock   *FileLock
+		out    *bufio.Writer
+		ids    map[string]bool
+		encode func(tweet *Tweet) (interface{}, error)
+	}
+
+	// SQLiteStore keeps tweets as JSON in tweets table of SQLite database.
+	// Text of tweets is indexed in tweets_fts FTS5 table, if driver supports it.
+	SQLiteStore struct {
+		db   *sql.DB
+		lock *FileLock
+		fts  bool
+	}
+
+	storeSink struct {
+		store TweetStore
+	}
+)
+
+// NewJSONLStore writes tweets to w, Close flushes output but doesn't close w.
+func NewJSONLStore(w io.Writer) *JSONLStore {
+	return &JSONLStore{out: bufio.NewWriter(w), ids: make(map[string]bool)}
+}
+
+// OpenJSONLStore appends tweets to file, IDs of tweets already in file are
+// read, so they are not written again. File is locked until Close, LockError
+// is returned if another run uses it.
+func OpenJSONLStore(filename string) (*JSONLStore, error) {
+	lock, err := LockFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+	store := NewJSONLStore(f)
+	store.file = f
+	store.lock = lock
+
+	decoder := json.NewDecoder(f)
+	for {
+		var line struct{ ID string }
+		if err := decoder.Decode(&line); err == io.EOF {
+			break
+		} else if err != nil {
+			f.Close()
+			lock.Unlock()
+			return nil, fmt.Errorf("error reading %s: %w", filename, err)
+		}
+		store.ids[line.ID] = true
+	}
+	return store, nil
+}
+
+// WithEncoding set function returning value written for tweet, tweet is
+// written as is by default. Value must keep ID to be found by Exists in file
+// opened again.
+func (store *JSONLStore) WithEncoding(encode func(tweet *Tweet) (interface{}, error)) *JSONLStore {
+	store.encode = encode
+	return store
+}
+
+// Put writes tweet, if it wasn't written before.
+func (store *JSONLStore) Put(tweet *Tweet) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if store.ids[tweet.ID] {
+		return nil
+	}
+
+	var value interface{} = tweet
+	if store.encode != nil {
+		var err error
+		if value, err = store.encode(tweet); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if _, err := store.out.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	store.ids[tweet.ID] = true
+	return nil
+}
+
+// Exists tells if tweet was written.
+func (store *JSONLStore) Exists(id string) (bool, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.ids[id], nil
+}
+
+// Flush writes buffered tweets.
+func (store *JSONLStore) Flush() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.out.Flush()
+}
+
+// Close flushes output, closes and unlocks file opened by OpenJSONLStore.
+func (store *JSONLStore) Close() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	err := store.out.Flush()
+	if store.file != nil {
+		if closeErr := store.file.Close(); err == nil {
+			err = closeErr
+		}
+		if unlockErr := store.lock.Unlock(); err == nil {
+			err = unlockErr
+		}
+	}
+	return err
+}
+
+// OpenSQLiteStore opens database with SQLite driver registered by caller, such
+// as modernc.org/sqlite or github.com/mattn/go-sqlite3, and creates tweets
+// table and its full-text index if needed. Driver without FTS5, such as
+// go-sqlite3 built without sqlite_fts5 tag, stores tweets without index.
+// Database file is locked until Close, LockError is returned if another run
+// uses it.
+func OpenSQLiteStore(driverName, dataSourceName string) (*SQLiteStore, error) {
+	var lock *FileLock
+	if filename := sqliteFilename(dataSourceName); filename != "" {
+		var err error
+		if lock, err = LockFile(filename); err != nil {
+			return nil, err
+		}
+	}
+	unlock := func() {
+		if lock != nil {
+			lock.Unlock()
+		}
+	}
+
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS tweets (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		username TEXT NOT NULL,
+		timestamp INTEGER NOT NULL,
+		data TEXT NOT NULL
+	)`)
+	if err != nil {
+		db.Close()
+		unlock()
+		return nil, err
+	}
+	store := &SQLiteStore{db: db, lock: lock}
+	store.fts = store.createIndex() == nil
+	return store, nil
+}
+
+// createIndex creates tweets_fts table with text of tweets, triggers keeping
+// it in sync with tweets table and indexes tweets stored before it.
+func (store *SQLiteStore) createIndex() error {
+	var exists int
+	err := store.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'tweets_fts'`).Scan(&exists)
+	if err != nil || exists > 0 {
+		return err
+	}
+
+	tx, err := store.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, statement := range []string{
+		`CREATE VIRTUAL TABLE tweets_fts USING fts5(id UNINDEXED, text)`,
+		`CREATE TRIGGER tweets_fts_insert AFTER INSERT ON tweets BEGIN
+			INSERT INTO tweets_fts (id, text) VALUES (new.id, json_extract(new.data, '$.Text'));
+		END`,
+		`CREATE TRIGGER tweets_fts_delete AFTER DELETE ON tweets BEGIN
+			DELETE FROM tweets_fts WHERE id = old.id;
+		END`,
+		`CREATE TRIGGER tweets_fts_update AFTER UPDATE OF data ON tweets BEGIN
+			UPDATE tweets_fts SET text = json_extract(new.data, '$.Text') WHERE id = new.id;
+		END`,
+		`INSERT INTO tweets_fts (id, text) SELECT id, json_extract(data, '$.Text') FROM tweets`,
+	} {
+		if _, err := tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// sqliteFilename returns database file of data source name, empty for in
+// memory database.
+func sqliteFilename(dataSourceName string) string {
+	filename := strings.TrimPrefix(dataSourceName, "file:")
+	if i := strings.Index(filename, "?"); i >= 0 {
+		filename = filename[:i]
+	}
+	if filename == ":memory:" {
+		return ""
+	}
+	return filename
+}
+
+// Put inserts tweet, if it isn't in table.
+func (store *SQLiteStore) Put(tweet *Tweet) error {
+	data, err := json.Marshal(tweet)
+	if err != nil {
+		return err
+	}
+	_, err = store.db.Exec(`INSERT OR IGNORE INTO tweets (id, user_id, username, timestamp, data) VALUES (?, ?, ?, ?, ?)`,
+		tweet.ID, tweet.UserID, tweet.Username, tweet.Timestamp, string(data))
+	return err
+}
+
+// Exists tells if tweet is in table.
+func (store *SQLiteStore) Exists(id string) (bool, error) {
+	var count int
+	err := store.db.QueryRow(`SELECT COUNT(*) FROM tweets WHERE id = ?`, id).Scan(&count)
+	return count > 0, err
+}
+
+// Search returns up to limit stored tweets matching FTS5 query, the most
+// relevant first, no limit if 0. ErrNoFullTextSearch is returned if driver
+// doesn't support FTS5.
+func (store *SQLiteStore) Search(query string, limit int) ([]*Tweet, error) {
+	if !store.fts {
+		return nil, ErrNoFullTextSearch
+	}
+	if limit <= 0 {
+		limit = -1
+	}
+
+	return store.query(`SELECT tweets.data FROM tweets_fts JOIN tweets ON tweets.id = tweets_fts.id
+		WHERE tweets_fts MATCH ? ORDER BY tweets_fts.rank LIMIT ?`, query, limit)
+}
+
+// Tweets returns all stored tweets, the newest first.
+func (store *SQLiteStore) Tweets() ([]*Tweet, error) {
+	return store.query(`SELECT data FROM tweets ORDER BY timestamp DESC, id DESC`)
+}
+
+// query returns tweets decoded from data column of rows of query.
+func (store *SQLiteStore) query(query string, args ...interface{}) ([]*Tweet, error) {
+	rows, err := store.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tweets []*Tweet
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var tweet Tweet
+		if err := json.Unmarshal([]byte(data), &tweet); err != nil {
+			return nil, err
+		}
+		tweets = append(tweets, &tweet)
+	}
+	return tweets, rows.Err()
+}
+
+// Close closes and unlocks database.
+func (store *SQLiteStore) Close() error {
+	err := store.db.Close()
+	if store.lock != nil {
+		if unlockErr := store.lock.Unlock(); err == nil {
+			err = unlockErr
+		}
+	}
+	return err
+}
+
+// StoreSink returns Sink putting tweets of Session to store.
+func StoreSink(store TweetStore) Sink {
+	return storeSink{store: store}
+}
+
+func (sink storeSink) WriteTweet(tweet *Tweet) error {
+	return sink.store.Put(tweet)
+}
+
+// GetTweetsInto puts tweets of user to store from the newest, until tweet
+// already in store, so repeated calls fetch only new tweets. Returns number
+// of stored tweets.
+func (s *Scraper) GetTweetsInto(ctx context.Context, username string, store TweetStore) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stored := 0
+	for result := range s.GetTweets(ctx, username, maxTweetsInto) {
+		if result.Error != nil {
+			return stored, result.Error
+		}
+		exists, err := store.Exists(result.ID)
+		if err != nil {
+			return stored, err
+		}
+		if exists {
+			// pinned tweet is out of timeline order
+			if result.IsPin {
+				continue
+			}
+			return stored, nil
+		}
+		if err := store.Put(&result.Tweet); err != nil {
+			return stored, err
+		}
+		stored++
+	}
+	return stored, nil
+}
@@ -0,0 +1,62 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestJSONLStore(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "tweets.ndjson")
+	store, err := twitterscraper.OpenJSONLStore(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1", "2", "1"} {
+		if err := store.Put(&twitterscraper.Tweet{ID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = twitterscraper.OpenJSONLStore(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	for _, id := range []string{"1", "2"} {
+		if exists, err := store.Exists(id); err != nil || !exists {
+			t.Errorf("Expected tweet %s in reopened store, got %v %v", id, exists, err)
+		}
+	}
+	if exists, _ := store.Exists("3"); exists {
+		t.Error("Expected tweet 3 not in store")
+	}
+}
+
+func TestGetTweetsInto(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	store, err := twitterscraper.OpenJSONLStore(filepath.Join(t.TempDir(), "tweets.ndjson"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	stored, err := testScraper.GetTweetsInto(context.Background(), "x", store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored == 0 {
+		t.Fatal("Expected stored tweets")
+	}
+	// nothing new since the first call
+	if stored, err = testScraper.GetTweetsInto(context.Background(), "x", store); err != nil || stored > 1 {
+		t.Errorf("Expected no new tweets, got %d %v", stored, err)
+	}
+}
@@ -0,0 +1,162 @@
+package twitterscraper
+
+import "fmt"
+
+// ParseError is returned in strict parsing mode when required field of tweet or profile is missing,
+// or when response doesn't match expected JSON structure.
+type ParseError struct {
+	// Type of object, tweet, profile or response.
+	Type  string
+	ID    string
+	Field string
+	// Err is JSON decoding error of response.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("invalid %s %s: %s: %v", e.Type, e.ID, e.Field, e.Err)
+	}
+	return fmt.Sprintf("invalid %s %s: missing %s", e.Type, e.ID, e.Field)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// WithStrictParsing enable/disable strict parsing mode. In strict mode tweets and
+// profiles with missing required fields produce ParseError instead of being returned
+// partially filled. Lenient mode is default.
+func (s *Scraper) WithStrictParsing(b bool) *Scraper {
+	s.strict = b
+	return s
+}
+
+// WithFailOnParseError enable/disable failing the whole page on ParseError. By default
+// invalid entries are skipped and reported to parse error hooks, the rest of the page
+// is returned and pagination continues.
+func (s *Scraper) WithFailOnParseError(b bool) *Scraper {
+	s.failOnParseError = b
+	return s
+}
+
+// OnParseError adds hook called for every skipped entry. Hooks are called
+// synchronously, so they should be fast.
+func (s *Scraper) OnParseError(hook func(*ParseError)) *Scraper {
+	s.stats.mu.Lock()
+	s.stats.parseHooks = append(s.stats.parseHooks, hook)
+	s.stats.mu.Unlock()
+	return s
+}
+
+// reportParseError counts skipped entry and calls parse error hooks.
+func (s *Scraper) reportParseError(err *ParseError) {
+	s.stats.mu.Lock()
+	s.stats.stats.ParseErrors++
+	hooks := s.stats.parseHooks
+	s.stats.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(err)
+	}
+}
+
+func (s *Scraper) validateTweets(tweets []*Tweet) error {
+	if !s.strict {
+		return nil
+	}
+	for _, tweet := range tweets {
+		if err := validateTweet(tweet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filterTweets skips ads of page in AdsExclude mode and invalid tweets in
+// strict mode, or fails on the first one if WithFailOnParseError is set.
+func (s *Scraper) filterTweets(tweets []*Tweet) ([]*Tweet, error) {
+	tweets = s.excludeAds(tweets)
+	if !s.strict {
+		return tweets, nil
+	}
+	valid := tweets[:0]
+	for _, tweet := range tweets {
+		if err := validateTweet(tweet); err != nil {
+			if s.failOnParseError {
+				return nil, err
+			}
+			s.reportParseError(err)
+			continue
+		}
+		valid = append(valid, tweet)
+	}
+	return valid, nil
+}
+
+// filterProfiles skips invalid profiles of page, see filterTweets.
+func (s *Scraper) filterProfiles(profiles []*Profile) ([]*Profile, error) {
+	if !s.strict {
+		return profiles, nil
+	}
+	valid := profiles[:0]
+	for _, profile := range profiles {
+		if err := validateProfile(profile); err != nil {
+			if s.failOnParseError {
+				return nil, err
+			}
+			s.reportParseError(err)
+			continue
+		}
+		valid = append(valid, profile)
+	}
+	return valid, nil
+}
+
+func validateTweet(tweet *Tweet) *ParseError {
+	if tweet == nil {
+		return &ParseError{Type: "tweet", Field: "tweet"}
+	}
+	required := []struct {
+		field   string
+		missing bool
+	}{
+		{"ID", tweet.ID == ""},
+		{"UserID", tweet.UserID == ""},
+		{"Username", tweet.Username == ""},
+		{"Timestamp", tweet.Timestamp == 0},
+		{"PermanentURL", tweet.PermanentURL == ""},
+		{"RetweetedStatusID", tweet.IsRetweet && tweet.RetweetedStatusID == "" && tweet.RetweetedStatus == nil},
+		{"QuotedStatusID", tweet.IsQuoted && tweet.QuotedStatusID == ""},
+		{"InReplyToStatusID", tweet.IsReply && tweet.InReplyToStatusID == ""},
+	}
+	for _, r := range required {
+		if r.missing {
+			return &ParseError{Type: "tweet", ID: tweet.ID, Field: r.field}
+		}
+	}
+	for _, photo := range tweet.Photos {
+		if photo.URL == "" {
+			return &ParseError{Type: "tweet", ID: tweet.ID, Field: "Photos.URL"}
+		}
+	}
+	for _, video := range tweet.Videos {
+		if video.URL == "" {
+			return &ParseError{Type: "tweet", ID: tweet.ID, Field: "Videos.URL"}
+		}
+	}
+	return nil
+}
+
+func validateProfile(profile *Profile) *ParseError {
+	if profile == nil {
+		return &ParseError{Type: "profile", Field: "profile"}
+	}
+	if profile.UserID == "" {
+		return &ParseError{Type: "profile", ID: profile.Username, Field: "UserID"}
+	}
+	if profile.Username == "" {
+		return &ParseError{Type: "profile", ID: profile.UserID, Field: "Username"}
+	}
+	return nil
+}
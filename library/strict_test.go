@@ -0,0 +1,66 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestStrictParsing(t *testing.T) {
+	scraper := newTestScraper(true)
+	scraper.WithStrictParsing(true)
+
+	tweet, err := scraper.GetTweet(context.Background(), "1665602315745673217")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweet.ID != "1665602315745673217" {
+		t.Errorf("Expected tweet 1665602315745673217, got %s", tweet.ID)
+	}
+}
+
+func TestPartialResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"entries":[{"id":"1","count":1},{"id":"2","count":"many"},{"id":"3","count":3}]}`))
+	}))
+	defer server.Close()
+
+	type page struct {
+		Entries []struct {
+			ID    string `json:"id"`
+			Count int    `json:"count"`
+		} `json:"entries"`
+	}
+
+	scraper := twitterscraper.New()
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "token", CSRFToken: "csrf"})
+	var reported []*twitterscraper.ParseError
+	scraper.OnParseError(func(err *twitterscraper.ParseError) {
+		reported = append(reported, err)
+	})
+
+	var target page
+	req, _ := http.NewRequest("GET", server.URL+"/i/api/graphql/abc/UserTweets", nil)
+	if err := scraper.RequestAPI(req, &target); err != nil {
+		t.Fatal(err)
+	}
+	if len(target.Entries) != 3 || target.Entries[2].Count != 3 {
+		t.Errorf("Expected the rest of page decoded, got %+v", target.Entries)
+	}
+	if len(reported) != 1 || reported[0].Type != "response" || reported[0].ID != "UserTweets" || !strings.HasSuffix(reported[0].Field, "count") {
+		t.Errorf("Expected one reported parse error, got %v", reported)
+	}
+	if stats := scraper.Stats(); stats.ParseErrors != 1 {
+		t.Errorf("Expected 1 parse error in stats, got %d", stats.ParseErrors)
+	}
+
+	scraper.WithFailOnParseError(true)
+	req, _ = http.NewRequest("GET", server.URL+"/i/api/graphql/abc/UserTweets", nil)
+	if err := scraper.RequestAPI(req, &target); err == nil {
+		t.Error("Expected error with WithFailOnParseError")
+	}
+}
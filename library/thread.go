@@ -0,0 +1,294 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+const (
+	// maximum conversation pages requested by GetThread
+	maxThreadPages = 10
+	// maximum conversation pages requested by GetReplies
+	maxReplyPages = 50
+	// maximum ancestors linked by GetTweetWithAncestors
+	maxAncestors = 200
+)
+
+type (
+	// ReplyNode is tweet of reply tree with its direct replies, oldest first.
+	ReplyNode struct {
+		Tweet   *Tweet
+		Parent  *ReplyNode `json:"-"`
+		Replies []*ReplyNode
+	}
+
+	// Participant is author of replies in conversation.
+	Participant struct {
+		UserID     string    `json:"user_id"`
+		Username   string    `json:"username"`
+		Name       string    `json:"name"`
+		Replies    int       `json:"replies"`
+		FirstReply time.Time `json:"first_reply"`
+		LastReply  time.Time `json:"last_reply"`
+	}
+
+	// replyPage is conversation request of GetReplies.
+	replyPage struct {
+		focalID string
+		cursor  string
+	}
+)
+
+// GetThread returns root tweet of conversation with all replies of its author
+// forming self-thread in Thread, ordered oldest first. tweetID may be root or
+// any tweet of the thread. Conversation is paginated if needed, tweets seen on
+// several pages are returned once.
+func (s *Scraper) GetThread(ctx context.Context, tweetID string) (*Tweet, error) {
+	tweet, err := s.GetTweet(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	rootID := tweet.ConversationID
+	if rootID == "" {
+		rootID = tweet.ID
+	}
+
+	conversation := make(map[string]*Tweet)
+	var cursor string
+	for page := 0; page < maxThreadPages; page++ {
+		tweets, cursors, err := s.GetTweetReplies(ctx, rootID, cursor)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tweets {
+			if _, ok := conversation[t.ID]; !ok {
+				conversation[t.ID] = t
+			}
+		}
+
+		cursor = ""
+		for _, c := range cursors {
+			if c.ThreadID == rootID && (c.CursorType == "Bottom" || c.CursorType == "ShowMoreThreads") {
+				cursor = c.Cursor
+			}
+		}
+		if cursor == "" {
+			break
+		}
+	}
+
+	root, ok := conversation[rootID]
+	if !ok {
+		if root, err = s.GetTweet(ctx, rootID); err != nil {
+			return nil, err
+		}
+	}
+
+	// add author replies to root or to tweets already in thread, until nothing is added
+	inThread := map[string]bool{root.ID: true}
+	var thread []*Tweet
+	for added := true; added; {
+		added = false
+		for _, t := range conversation {
+			if !inThread[t.ID] && t.UserID == root.UserID && inThread[t.InReplyToStatusID] {
+				inThread[t.ID] = true
+				thread = append(thread, t)
+				added = true
+			}
+		}
+	}
+
+	sort.Slice(thread, func(i, j int) bool {
+		if thread[i].Timestamp != thread[j].Timestamp {
+			return thread[i].Timestamp < thread[j].Timestamp
+		}
+		return compareIDs(thread[i].ID, thread[j].ID) < 0
+	})
+	root.Thread = thread
+	root.IsSelfThread = len(thread) > 0
+	return root, nil
+}
+
+// GetTweetWithAncestors returns tweet with InReplyToStatus set on it and on
+// every ancestor, so the chain of replies can be walked up to the root of
+// conversation. Ancestors shown in conversation of tweet are taken from it,
+// the rest is requested one by one. Deleted or unavailable ancestor ends the
+// chain.
+func (s *Scraper) GetTweetWithAncestors(ctx context.Context, id string) (*Tweet, error) {
+	tweet, err := s.GetTweet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	conversation := make(map[string]*Tweet)
+	if tweet.InReplyToStatusID != "" && s.isLogged {
+		tweets, _, err := s.GetTweetReplies(ctx, id, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tweets {
+			conversation[t.ID] = t
+		}
+	}
+
+	current := tweet
+	for i := 0; current.InReplyToStatusID != "" && i < maxAncestors; i++ {
+		parent, ok := conversation[current.InReplyToStatusID]
+		if !ok {
+			if parent, err = s.GetTweet(ctx, current.InReplyToStatusID); errors.Is(err, ErrTweetNotFound) {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		current.InReplyToStatus = parent
+		current = parent
+	}
+	return tweet, nil
+}
+
+// GetReplies returns tree of replies to tweet, the returned node is the tweet
+// itself. Conversation is walked following all "show more" cursors, and
+// replies which have more replies than conversation shows are requested as
+// focal tweets. Replies deeper than depth levels are dropped, tree holds up
+// to limit replies, zero depth or limit means no limit. Requests stop after
+// 50 pages anyway.
+func (s *Scraper) GetReplies(ctx context.Context, tweetID string, depth, limit int) (*ReplyNode, error) {
+	root, err := s.GetTweet(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+
+	conversation := map[string]*Tweet{root.ID: root}
+	fetched := map[string]bool{}
+	seenCursors := map[string]bool{}
+	queue := []replyPage{{focalID: root.ID}}
+	tree, size := buildReplyTree(root, conversation, depth, limit)
+	for page := 0; len(queue) > 0 && page < maxReplyPages; page++ {
+		next := queue[0]
+		queue = queue[1:]
+		if next.cursor == "" {
+			fetched[next.focalID] = true
+		}
+
+		tweets, cursors, err := s.GetTweetReplies(ctx, next.focalID, next.cursor)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tweets {
+			if _, ok := conversation[t.ID]; !ok {
+				conversation[t.ID] = t
+			}
+		}
+		for _, c := range cursors {
+			// top cursor leads to tweets above focal one
+			if c.CursorType == "Top" || seenCursors[c.Cursor] {
+				continue
+			}
+			seenCursors[c.Cursor] = true
+			queue = append(queue, replyPage{focalID: next.focalID, cursor: c.Cursor})
+		}
+
+		tree, size = buildReplyTree(root, conversation, depth, limit)
+		if limit > 0 && size >= limit {
+			break
+		}
+		if len(queue) == 0 {
+			queue = tree.unexpanded(1, depth, fetched)
+		}
+	}
+	return tree, nil
+}
+
+// buildReplyTree links replies of conversation to root, level by level, and
+// returns tree with number of replies in it.
+func buildReplyTree(root *Tweet, conversation map[string]*Tweet, depth, limit int) (*ReplyNode, int) {
+	children := make(map[string][]*Tweet)
+	for _, t := range conversation {
+		if t.ID != root.ID && t.InReplyToStatusID != "" {
+			children[t.InReplyToStatusID] = append(children[t.InReplyToStatusID], t)
+		}
+	}
+
+	tree := &ReplyNode{Tweet: root}
+	level := []*ReplyNode{tree}
+	size := 0
+	for d := 1; len(level) > 0 && (depth <= 0 || d <= depth); d++ {
+		var nextLevel []*ReplyNode
+		for _, parent := range level {
+			replies := children[parent.Tweet.ID]
+			sort.Slice(replies, func(i, j int) bool { return compareIDs(replies[i].ID, replies[j].ID) < 0 })
+			for _, t := range replies {
+				if limit > 0 && size >= limit {
+					return tree, size
+				}
+				node := &ReplyNode{Tweet: t, Parent: parent}
+				parent.Replies = append(parent.Replies, node)
+				nextLevel = append(nextLevel, node)
+				size++
+			}
+		}
+		level = nextLevel
+	}
+	return tree, size
+}
+
+// unexpanded returns requests for replies of tree, at level and below, which
+// have more replies than tree has and weren't focal tweet yet.
+func (node *ReplyNode) unexpanded(level, depth int, fetched map[string]bool) []replyPage {
+	var pages []replyPage
+	for _, reply := range node.Replies {
+		if depth > 0 && level >= depth {
+			break
+		}
+		if reply.Tweet.Replies > len(reply.Replies) && !fetched[reply.Tweet.ID] {
+			pages = append(pages, replyPage{focalID: reply.Tweet.ID})
+		}
+		pages = append(pages, reply.unexpanded(level+1, depth, fetched)...)
+	}
+	return pages
+}
+
+// Participants returns unique authors of replies in tree below node, with
+// number of their replies and times of the first and the last one. The most
+// active participants go first, ties are ordered by the first reply.
+func (node *ReplyNode) Participants() []Participant {
+	byID := make(map[string]*Participant)
+	var walk func(node *ReplyNode)
+	walk = func(node *ReplyNode) {
+		for _, reply := range node.Replies {
+			t := reply.Tweet
+			p, ok := byID[t.UserID]
+			if !ok {
+				p = &Participant{UserID: t.UserID, Username: t.Username, Name: t.Name, FirstReply: t.TimeParsed, LastReply: t.TimeParsed}
+				byID[t.UserID] = p
+			}
+			p.Replies++
+			if t.TimeParsed.Before(p.FirstReply) {
+				p.FirstReply = t.TimeParsed
+			}
+			if t.TimeParsed.After(p.LastReply) {
+				p.LastReply = t.TimeParsed
+			}
+			walk(reply)
+		}
+	}
+	walk(node)
+
+	participants := make([]Participant, 0, len(byID))
+	for _, p := range byID {
+		participants = append(participants, *p)
+	}
+	sort.Slice(participants, func(i, j int) bool {
+		if participants[i].Replies != participants[j].Replies {
+			return participants[i].Replies > participants[j].Replies
+		}
+		if !participants[i].FirstReply.Equal(participants[j].FirstReply) {
+			return participants[i].FirstReply.Before(participants[j].FirstReply)
+		}
+		return participants[i].UserID < participants[j].UserID
+	})
+	return participants
+}
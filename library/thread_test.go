@@ -0,0 +1,139 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestGetThread(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	root, err := testScraper.GetThread(context.Background(), "1665602315745673217")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.ID != "1665602315745673217" || !root.IsSelfThread {
+		t.Fatalf("Expected root of self-thread, got %s", root.ID)
+	}
+	if len(root.Thread) < 7 {
+		t.Errorf("Expected at least 7 thread tweets, got %d", len(root.Thread))
+	}
+
+	seen := map[string]bool{root.ID: true}
+	for i, tweet := range root.Thread {
+		if tweet.UserID != root.UserID || seen[tweet.ID] {
+			t.Errorf("Expected unique tweets of thread author, got %s", tweet.ID)
+		}
+		seen[tweet.ID] = true
+		if i > 0 && tweet.Timestamp < root.Thread[i-1].Timestamp {
+			t.Error("Expected thread ordered oldest first")
+		}
+	}
+}
+
+func TestGetTweetWithAncestors(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	thread, err := testScraper.GetThread(context.Background(), "1665602315745673217")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(thread.Thread) < 2 {
+		t.Fatal("Expected thread of several tweets")
+	}
+	last := thread.Thread[len(thread.Thread)-1]
+
+	tweet, err := testScraper.GetTweetWithAncestors(context.Background(), last.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	depth := 0
+	for current := tweet; current.InReplyToStatus != nil; current = current.InReplyToStatus {
+		if current.InReplyToStatus.ID != current.InReplyToStatusID {
+			t.Errorf("Expected parent %s of %s, got %s", current.InReplyToStatusID, current.ID, current.InReplyToStatus.ID)
+		}
+		depth++
+		if current.InReplyToStatus.InReplyToStatusID == "" && current.InReplyToStatus.ID != thread.ID {
+			t.Errorf("Expected chain to end at root %s, got %s", thread.ID, current.InReplyToStatus.ID)
+		}
+	}
+	if depth < len(thread.Thread) {
+		t.Errorf("Expected at least %d ancestors, got %d", len(thread.Thread), depth)
+	}
+}
+
+func TestGetReplyTree(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	tree, err := testScraper.GetReplies(context.Background(), "1328684389388185600", 2, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Tweet.ID != "1328684389388185600" || tree.Parent != nil {
+		t.Fatalf("Expected tweet as root, got %s", tree.Tweet.ID)
+	}
+	if len(tree.Replies) == 0 {
+		t.Fatal("Expected replies")
+	}
+
+	count := 0
+	var walk func(node *twitterscraper.ReplyNode, level int)
+	walk = func(node *twitterscraper.ReplyNode, level int) {
+		for _, reply := range node.Replies {
+			count++
+			if level > 2 {
+				t.Errorf("Expected depth up to 2, got %d", level)
+			}
+			if reply.Parent != node || reply.Tweet.InReplyToStatusID != node.Tweet.ID {
+				t.Errorf("Expected %s to be reply of %s", reply.Tweet.ID, node.Tweet.ID)
+			}
+			walk(reply, level+1)
+		}
+	}
+	walk(tree, 1)
+	if count > 30 {
+		t.Errorf("Expected up to 30 replies, got %d", count)
+	}
+}
+
+func TestReplyNodeParticipants(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	reply := func(userID string, minutes int, replies ...*twitterscraper.ReplyNode) *twitterscraper.ReplyNode {
+		return &twitterscraper.ReplyNode{
+			Tweet:   &twitterscraper.Tweet{UserID: userID, Username: "user" + userID, TimeParsed: at.Add(time.Duration(minutes) * time.Minute)},
+			Replies: replies,
+		}
+	}
+	tree := reply("1", 0,
+		reply("2", 5, reply("1", 6), reply("2", 30)),
+		reply("3", 1, reply("1", 40)),
+		reply("1", 10, reply("3", 2)),
+	)
+
+	participants := tree.Participants()
+	expected := []struct {
+		userID      string
+		replies     int
+		first, last int
+	}{
+		{"1", 3, 6, 40},
+		{"3", 2, 1, 2},
+		{"2", 2, 5, 30},
+	}
+	if len(participants) != len(expected) {
+		t.Fatalf("Expected %d participants, got %+v", len(expected), participants)
+	}
+	for i, e := range expected {
+		p := participants[i]
+		if p.UserID != e.userID || p.Username != "user"+e.userID || p.Replies != e.replies ||
+			!p.FirstReply.Equal(at.Add(time.Duration(e.first)*time.Minute)) || !p.LastReply.Equal(at.Add(time.Duration(e.last)*time.Minute)) {
+			t.Errorf("Expected participant %d to be %+v, got %+v", i, e, p)
+		}
+	}
+}
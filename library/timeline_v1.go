@@ -119,6 +119,7 @@ func (timeline *timelineV1) parseTweet(id string) *Tweet {
 			tw.IsReply = true
 			tw.InReplyToStatus = timeline.parseTweet(tweet.InReplyToStatusIDStr)
 			tw.InReplyToStatusID = tweet.InReplyToStatusIDStr
+			tw.InReplyToUserID = tweet.InReplyToUserIDStr
 		}
 		if tweet.RetweetedStatusIDStr != "" {
 			tw.IsRetweet = true
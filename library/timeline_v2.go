@@ -34,6 +34,33 @@ type result struct {
 	Typename string `json:"__typename"`
 	tweet
 	Tweet tweet `json:"tweet"`
+	// reason and tombstone of unavailable tweet
+	Reason    string `json:"reason"`
+	Tombstone struct {
+		Text struct {
+			Text string `json:"text"`
+		} `json:"text"`
+	} `json:"tombstone"`
+}
+
+// isAgeRestricted checks if tweet is hidden as adult content for logged out or not age-verified accounts.
+func (result *result) isAgeRestricted() bool {
+	return result.Reason == "NsfwLoggedOut" || strings.HasPrefix(result.Tombstone.Text.Text, "Age-restricted")
+}
+
+// unavailable returns ErrAgeRestricted, ErrProtected or ErrUserSuspended if
+// tweet is unavailable for that reason, nil otherwise.
+func (result *result) unavailable() error {
+	text := result.Tombstone.Text.Text
+	switch {
+	case result.isAgeRestricted():
+		return ErrAgeRestricted
+	case result.Reason == "Protected" || strings.Contains(text, "account owner limits who can view"):
+		return ErrProtected
+	case result.Reason == "Suspended" || strings.Contains(text, "from a suspended account"):
+		return ErrUserSuspended
+	}
+	return nil
 }
 
 func (result *result) parse() *Tweet {
@@ -83,17 +110,24 @@ type item struct {
 			TweetResults     struct {
 				Result result `json:"result"`
 			} `json:"tweet_results"`
-			CursorType string `json:"cursorType"`
-			Value      string `json:"value"`
+			UserResults struct {
+				Result userResult `json:"result"`
+			} `json:"user_results"`
+			Topic            topicItem         `json:"topic"`
+			PromotedMetadata *promotedMetadata `json:"promotedMetadata"`
+			CursorType       string            `json:"cursorType"`
+			Value            string            `json:"value"`
 		} `json:"itemContent"`
 	} `json:"item"`
 }
 
 type entry struct {
+	EntryID string `json:"entryId"`
 	Content struct {
-		CursorType  string `json:"cursorType"`
-		Value       string `json:"value"`
-		Items       []item `json:"items"`
+		CursorType  string       `json:"cursorType"`
+		Value       string       `json:"value"`
+		Items       []item       `json:"items"`
+		Header      moduleHeader `json:"header"`
 		ItemContent struct {
 			ItemType         string `json:"itemType"`
 			TweetDisplayType string `json:"tweetDisplayType"`
@@ -104,8 +138,9 @@ type entry struct {
 			UserResults     struct {
 				Result userResult `json:"result"`
 			} `json:"user_results"`
-			CursorType string `json:"cursorType"`
-			Value      string `json:"value"`
+			PromotedMetadata *promotedMetadata `json:"promotedMetadata"`
+			CursorType       string            `json:"cursorType"`
+			Value            string            `json:"value"`
 		} `json:"itemContent"`
 	} `json:"content"`
 }
@@ -149,14 +184,19 @@ func (timeline *timelineV2) parseTweets() ([]*Tweet, string) {
 				cursor = entry.Content.Value
 				continue
 			}
+			if isAIModule(entry.EntryID, entry.Content.ItemContent.ItemType, entry.Content.Items) {
+				continue
+			}
 			if entry.Content.ItemContent.TweetResults.Result.Typename == "Tweet" || entry.Content.ItemContent.TweetResults.Result.Typename == "TweetWithVisibilityResults" {
 				if tweet := entry.Content.ItemContent.TweetResults.Result.parse(); tweet != nil {
+					entry.Content.ItemContent.PromotedMetadata.flag(tweet)
 					tweets = append(tweets, tweet)
 				}
 			}
 			if len(entry.Content.Items) > 0 {
 				for _, item := range entry.Content.Items {
 					if tweet := item.Item.ItemContent.TweetResults.Result.parse(); tweet != nil {
+						item.Item.ItemContent.PromotedMetadata.flag(tweet)
 						tweets = append(tweets, tweet)
 					}
 				}
@@ -164,8 +204,12 @@ func (timeline *timelineV2) parseTweets() ([]*Tweet, string) {
 		}
 		if len(instruction.ModuleItems) > 0 {
 			for _, entry := range instruction.ModuleItems {
+				if isAIModule(entry.EntryID, entry.Item.ItemContent.ItemType, nil) {
+					continue
+				}
 				if entry.Item.ItemContent.TweetResults.Result.Typename == "Tweet" || entry.Item.ItemContent.TweetResults.Result.Typename == "TweetWithVisibilityResults" {
 					if tweet := entry.Item.ItemContent.TweetResults.Result.parse(); tweet != nil {
+						entry.Item.ItemContent.PromotedMetadata.flag(tweet)
 						tweets = append(tweets, tweet)
 					}
 				}
@@ -199,6 +243,7 @@ func (timeline *bookmarksTimelineV2) parseTweets() ([]*Tweet, string) {
 			}
 			if entry.Content.ItemContent.TweetResults.Result.Typename == "Tweet" {
 				if tweet := entry.Content.ItemContent.TweetResults.Result.parse(); tweet != nil {
+					entry.Content.ItemContent.PromotedMetadata.flag(tweet)
 					tweets = append(tweets, tweet)
 				}
 			}
@@ -207,6 +252,25 @@ func (timeline *bookmarksTimelineV2) parseTweets() ([]*Tweet, string) {
 	return tweets, cursor
 }
 
+type bookmarkFolderTimelineV2 struct {
+	Data struct {
+		Folder struct {
+			Timeline struct {
+				Instructions []struct {
+					Entries []entry `json:"entries"`
+					Type    string  `json:"type"`
+				} `json:"instructions"`
+			} `json:"timeline"`
+		} `json:"bookmark_collection_timeline"`
+	} `json:"data"`
+}
+
+func (timeline *bookmarkFolderTimelineV2) parseTweets() ([]*Tweet, string) {
+	var bookmarks bookmarksTimelineV2
+	bookmarks.Data.Bookmarks.Timeline = timeline.Data.Folder.Timeline
+	return bookmarks.parseTweets()
+}
+
 type retweetersTimelineV2 struct {
 	Data struct {
 		RetweetersTimeline struct {
@@ -269,6 +333,18 @@ type threadedConversation struct {
 	} `json:"data"`
 }
 
+// unavailable returns reason of focal tweet being unavailable, see result.unavailable.
+func (conversation *threadedConversation) unavailable(focalTweetID string) error {
+	for _, instruction := range conversation.Data.ThreadedConversationWithInjectionsV2.Instructions {
+		for _, entry := range instruction.Entries {
+			if entry.EntryID == "tweet-"+focalTweetID {
+				return entry.Content.ItemContent.TweetResults.Result.unavailable()
+			}
+		}
+	}
+	return nil
+}
+
 func (conversation *threadedConversation) parse(focalTweetID string) ([]*Tweet, []*ThreadCursor) {
 	var tweets []*Tweet
 	var cursors []*ThreadCursor
@@ -276,6 +352,7 @@ func (conversation *threadedConversation) parse(focalTweetID string) ([]*Tweet,
 		for _, entry := range instruction.Entries {
 			if entry.Content.ItemContent.TweetResults.Result.Typename == "Tweet" || entry.Content.ItemContent.TweetResults.Result.Typename == "TweetWithVisibilityResults" {
 				if tweet := entry.Content.ItemContent.TweetResults.Result.parse(); tweet != nil {
+					entry.Content.ItemContent.PromotedMetadata.flag(tweet)
 					if entry.Content.ItemContent.TweetDisplayType == "SelfThread" {
 						tweet.IsSelfThread = true
 					}
@@ -295,6 +372,7 @@ func (conversation *threadedConversation) parse(focalTweetID string) ([]*Tweet,
 			for _, item := range entry.Content.Items {
 				if item.Item.ItemContent.TweetResults.Result.Typename == "Tweet" || item.Item.ItemContent.TweetResults.Result.Typename == "TweetWithVisibilityResults" {
 					if tweet := item.Item.ItemContent.TweetResults.Result.parse(); tweet != nil {
+						item.Item.ItemContent.PromotedMetadata.flag(tweet)
 						if item.Item.ItemContent.TweetDisplayType == "SelfThread" {
 							tweet.IsSelfThread = true
 						}
@@ -324,6 +402,7 @@ func (conversation *threadedConversation) parse(focalTweetID string) ([]*Tweet,
 		for _, item := range instruction.ModuleItems {
 			if item.Item.ItemContent.TweetResults.Result.Typename == "Tweet" || item.Item.ItemContent.TweetResults.Result.Typename == "TweetWithVisibilityResults" {
 				if tweet := item.Item.ItemContent.TweetResults.Result.parse(); tweet != nil {
+					item.Item.ItemContent.PromotedMetadata.flag(tweet)
 					if item.Item.ItemContent.TweetDisplayType == "SelfThread" {
 						tweet.IsSelfThread = true
 					}
@@ -0,0 +1,55 @@
+package twitterscraper
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultEndpointTimeouts are timeouts of heavy endpoints, other endpoints use
+// client timeout. Endpoint is GraphQL operation name or path of REST API.
+var DefaultEndpointTimeouts = map[string]time.Duration{
+	"SearchTimeline":         30 * time.Second,
+	"TweetDetail":            20 * time.Second,
+	"i/media/upload":         60 * time.Second,
+	"Bookmarks":              20 * time.Second,
+	"BookmarkFolderTimeline": 20 * time.Second,
+	"HomeTimeline":           20 * time.Second,
+	"HomeLatestTimeline":     20 * time.Second,
+}
+
+// SetEndpointTimeout set timeout of requests to endpoint, which is GraphQL
+// operation name like UserByScreenName or path of REST API like 1.1/users/lookup.
+// Zero timeout resets endpoint to client timeout.
+func (s *Scraper) SetEndpointTimeout(endpoint string, timeout time.Duration) *Scraper {
+	if s.timeouts == nil {
+		s.timeouts = make(map[string]time.Duration)
+	}
+	s.timeouts[endpoint] = timeout
+	return s
+}
+
+// endpointTimeout returns timeout of endpoint, 0 means client timeout.
+func (s *Scraper) endpointTimeout(endpoint string) time.Duration {
+	if timeout, ok := s.timeouts[endpoint]; ok {
+		return timeout
+	}
+	return DefaultEndpointTimeouts[endpoint]
+}
+
+// endpointName returns GraphQL operation name or path of REST API without
+// version prefix, extension and IDs, e.g. 1.1/users/lookup or 2/timeline/conversation/{id}.
+func endpointName(u *url.URL) string {
+	path := strings.Trim(u.Path, "/")
+	if strings.Contains(path, "graphql/") {
+		return path[strings.LastIndex(path, "/")+1:]
+	}
+
+	segments := strings.Split(strings.TrimSuffix(path, ".json"), "/")
+	for i, segment := range segments {
+		if segment != "" && strings.Trim(segment, "0123456789") == "" && i > 0 {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
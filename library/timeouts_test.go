@@ -0,0 +1,38 @@
+package twitterscraper_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestEndpointTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	scraper := twitterscraper.New().WithClientTimeout(time.Second)
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "token", CSRFToken: "ct0"})
+	scraper.SetEndpointTimeout("UserByScreenName", 10*time.Millisecond)
+
+	req, _ := http.NewRequest("GET", ts.URL+"/i/api/graphql/G3KGOASz96M-Qu0nwmGXNg/UserByScreenName", nil)
+	if err := scraper.RequestAPI(req, nil); err == nil {
+		t.Error("Expected timeout of UserByScreenName")
+	}
+
+	req, _ = http.NewRequest("GET", ts.URL+"/1.1/users/lookup.json", nil)
+	if err := scraper.RequestAPI(req, nil); err != nil {
+		t.Errorf("Expected client timeout for other endpoints, got %v", err)
+	}
+
+	scraper.SetEndpointTimeout("1.1/users/lookup", 10*time.Millisecond)
+	req, _ = http.NewRequest("GET", ts.URL+"/1.1/users/lookup.json", nil)
+	if err := scraper.RequestAPI(req, nil); err == nil {
+		t.Error("Expected timeout of 1.1/users/lookup")
+	}
+}
@@ -0,0 +1,146 @@
+package twitterscraper
+
+import (
+	"database/sql"
+	"sync"
+)
+
+type (
+	// Tracker remembers tweets processed by Session and self-threads they
+	// belong to. Tracker kept between runs lets session skip tweets written
+	// before and continue threads started in previous runs.
+	Tracker interface {
+		// Seen tells if tweet was processed.
+		Seen(id string) (bool, error)
+		// Thread returns author and ID of thread root of processed tweet.
+		Thread(id string) (userID, rootID string, ok bool, err error)
+		// Add records processed tweet, rootID is ID of the tweet itself if
+		// it doesn't continue thread.
+		Add(id, userID, rootID string) error
+		Close() error
+	}
+
+	// MemoryTracker keeps processed tweets in memory, it's default tracker of
+	// session living for one run.
+	MemoryTracker struct {
+		mu     sync.Mutex
+		tweets map[string]trackedTweet
+	}
+
+	// SQLiteTracker keeps processed tweets in SQLite database, so they survive
+	// restarts and don't grow memory of multi-day scrapes.
+	SQLiteTracker struct {
+		db   *sql.DB
+		lock *FileLock
+	}
+
+	trackedTweet struct {
+		userID string
+		rootID string
+	}
+)
+
+// NewMemoryTracker creates empty tracker.
+func NewMemoryTracker() *MemoryTracker {
+	return &MemoryTracker{tweets: make(map[string]trackedTweet)}
+}
+
+// Seen tells if tweet was added.
+func (t *MemoryTracker) Seen(id string) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.tweets[id]
+	return ok, nil
+}
+
+// Thread returns author and thread root of added tweet.
+func (t *MemoryTracker) Thread(id string) (string, string, bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tweet, ok := t.tweets[id]
+	return tweet.userID, tweet.rootID, ok, nil
+}
+
+// Add records tweet.
+func (t *MemoryTracker) Add(id, userID, rootID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tweets[id] = trackedTweet{userID: userID, rootID: rootID}
+	return nil
+}
+
+// Close does nothing.
+func (t *MemoryTracker) Close() error {
+	return nil
+}
+
+// OpenSQLiteTracker opens database with SQLite driver registered by caller and
+// creates processed_tweets table if needed. Database file is locked until
+// Close, LockError is returned if another run uses it.
+func OpenSQLiteTracker(driverName, dataSourceName string) (*SQLiteTracker, error) {
+	var lock *FileLock
+	if filename := sqliteFilename(dataSourceName); filename != "" {
+		var err error
+		if lock, err = LockFile(filename); err != nil {
+			return nil, err
+		}
+	}
+	unlock := func() {
+		if lock != nil {
+			lock.Unlock()
+		}
+	}
+
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS processed_tweets (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		root_id TEXT NOT NULL
+	)`)
+	if err != nil {
+		db.Close()
+		unlock()
+		return nil, err
+	}
+	return &SQLiteTracker{db: db, lock: lock}, nil
+}
+
+// Seen tells if tweet is in table.
+func (t *SQLiteTracker) Seen(id string) (bool, error) {
+	_, _, ok, err := t.Thread(id)
+	return ok, err
+}
+
+// Thread returns author and thread root of tweet in table.
+func (t *SQLiteTracker) Thread(id string) (string, string, bool, error) {
+	var userID, rootID string
+	err := t.db.QueryRow(`SELECT user_id, root_id FROM processed_tweets WHERE id = ?`, id).Scan(&userID, &rootID)
+	if err == sql.ErrNoRows {
+		return "", "", false, nil
+	}
+	if err != nil {
+		return "", "", false, err
+	}
+	return userID, rootID, true, nil
+}
+
+// Add inserts or replaces tweet in table.
+func (t *SQLiteTracker) Add(id, userID, rootID string) error {
+	_, err := t.db.Exec(`INSERT OR REPLACE INTO processed_tweets (id, user_id, root_id) VALUES (?, ?, ?)`, id, userID, rootID)
+	return err
+}
+
+// Close closes and unlocks database.
+func (t *SQLiteTracker) Close() error {
+	err := t.db.Close()
+	if t.lock != nil {
+		if unlockErr := t.lock.Unlock(); err == nil {
+			err = unlockErr
+		}
+	}
+	return err
+}
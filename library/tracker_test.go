@@ -0,0 +1,45 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestMemoryTracker(t *testing.T) {
+	tracker := twitterscraper.NewMemoryTracker()
+	if err := tracker.Add("2", "1", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if seen, _ := tracker.Seen("2"); !seen {
+		t.Error("Expected tweet 2 seen")
+	}
+	if seen, _ := tracker.Seen("3"); seen {
+		t.Error("Expected tweet 3 not seen")
+	}
+	if userID, rootID, ok, _ := tracker.Thread("2"); !ok || userID != "1" || rootID != "1" {
+		t.Errorf("Expected tweet 2 of user 1 in thread 1, got %s %s %v", userID, rootID, ok)
+	}
+}
+
+func TestSessionTracker(t *testing.T) {
+	tracker := twitterscraper.NewMemoryTracker()
+	session := twitterscraper.NewSession(newTestScraper(true), 20).WithTracker(tracker)
+
+	first, err := session.Run(context.Background(), []string{"x"}, &sliceSink{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Tweets == 0 {
+		t.Fatal("Expected tweets in the first run")
+	}
+	// tracker shared by runs skips tweets written before
+	second, err := session.Run(context.Background(), []string{"x"}, &sliceSink{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Tweets > 1 {
+		t.Errorf("Expected no tweets written again, got %d", second.Tweets)
+	}
+}
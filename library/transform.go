@@ -0,0 +1,22 @@
+package twitterscraper
+
+// TweetTransform is applied to every tweet of timelines and search before it's sent
+// to channel. It can modify tweet in place, e.g. compute derived values or clear
+// fields, and returns false to drop tweet.
+type TweetTransform func(tweet *Tweet) bool
+
+// AddTweetTransform adds transform applied after already added ones. Dropped tweets
+// are not counted in max number of tweets.
+func (s *Scraper) AddTweetTransform(transform TweetTransform) *Scraper {
+	s.transforms = append(s.transforms, transform)
+	return s
+}
+
+func (s *Scraper) transformTweet(tweet *Tweet) bool {
+	for _, transform := range s.transforms {
+		if !transform(tweet) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestTweetTransform(t *testing.T) {
+	scraper := newTestScraper(true)
+	scraper.AddTweetTransform(func(tweet *twitterscraper.Tweet) bool {
+		tweet.Text = ""
+		return !tweet.IsRetweet
+	})
+
+	count := 0
+	for tweet := range scraper.GetTweets(context.Background(), "x", 20) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		count++
+		if tweet.IsRetweet {
+			t.Errorf("Expected retweet %s to be dropped", tweet.ID)
+		}
+		if tweet.Text != "" {
+			t.Errorf("Expected text of tweet %s to be cleared", tweet.ID)
+		}
+	}
+	if count != 20 {
+		t.Errorf("Expected 20 tweets, got %d", count)
+	}
+}
@@ -1,10 +1,34 @@
 package twitterscraper
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// WorldwideWOEID is place ID of worldwide trends for GetTrendsForLocation.
+const WorldwideWOEID = 1
+
+// Trend of GetTrendsForLocation.
+type Trend struct {
+	Name string
+	URL  string
+	// TweetVolume is number of tweets of the last 24 hours, 0 if API doesn't
+	// report it.
+	TweetVolume int
+}
+
+type trendsPlace struct {
+	Trends []struct {
+		Name        string `json:"name"`
+		URL         string `json:"url"`
+		TweetVolume *int   `json:"tweet_volume"`
+	} `json:"trends"`
+}
 
 // GetTrends return list of trends.
-func (s *Scraper) GetTrends() ([]string, error) {
-	req, err := s.newRequest("GET", "https://api.twitter.com/2/guide.json")
+func (s *Scraper) GetTrends(ctx context.Context) ([]string, error) {
+	req, err := s.newRequest(ctx, "GET", "https://api.twitter.com/2/guide.json")
 	if err != nil {
 		return nil, err
 	}
@@ -40,3 +64,33 @@ func (s *Scraper) GetTrends() ([]string, error) {
 
 	return trends, nil
 }
+
+// GetTrendsForLocation returns trends of place by its Yahoo! WOEID, such as
+// WorldwideWOEID or 23424977 for United States, via the Twitter REST API.
+func (s *Scraper) GetTrendsForLocation(ctx context.Context, placeID int) ([]Trend, error) {
+	req, err := s.newRequest(ctx, "GET", "https://api.twitter.com/1.1/trends/place.json")
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Set("id", strconv.Itoa(placeID))
+	req.URL.RawQuery = q.Encode()
+
+	var places []trendsPlace
+	if err := s.RequestAPI(req, &places); err != nil {
+		return nil, err
+	}
+	if len(places) == 0 {
+		return nil, fmt.Errorf("no trends found for place %d", placeID)
+	}
+
+	trends := make([]Trend, 0, len(places[0].Trends))
+	for _, t := range places[0].Trends {
+		trend := Trend{Name: t.Name, URL: t.URL}
+		if t.TweetVolume != nil {
+			trend.TweetVolume = *t.TweetVolume
+		}
+		trends = append(trends, trend)
+	}
+	return trends, nil
+}
@@ -1,14 +1,17 @@
 package twitterscraper_test
 
 import (
+	"context"
 	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
 )
 
 func TestGetTrends(t *testing.T) {
 	if skipAuthTest {
 		t.Skip("Skipping test due to environment variable")
 	}
-	trends, err := testScraper.GetTrends()
+	trends, err := testScraper.GetTrends(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -23,3 +26,21 @@ func TestGetTrends(t *testing.T) {
 		}
 	}
 }
+
+func TestGetTrendsForLocation(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	trends, err := testScraper.GetTrendsForLocation(context.Background(), twitterscraper.WorldwideWOEID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trends) == 0 {
+		t.Fatal("Expected trends")
+	}
+	for _, trend := range trends {
+		if trend.Name == "" || trend.URL == "" || trend.TweetVolume < 0 {
+			t.Errorf("Unexpected trend %+v", trend)
+		}
+	}
+}
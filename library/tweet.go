@@ -1,10 +1,9 @@
 package twitterscraper
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
-	"io"
 	"net/url"
 	"strconv"
 	"strings"
@@ -46,8 +45,8 @@ func (newTweet *newTweet) parse() *Tweet {
 	return tw
 }
 
-func (s *Scraper) CreateTweet(tweet NewTweet) (*Tweet, error) {
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/oB-5XsHNAbjvARJEc8CZFw/CreateTweet")
+func (s *Scraper) CreateTweet(ctx context.Context, tweet NewTweet) (*Tweet, error) {
+	req, err := s.newRequest(ctx, "POST", "https://twitter.com/i/api/graphql/oB-5XsHNAbjvARJEc8CZFw/CreateTweet")
 	if err != nil {
 		return nil, err
 	}
@@ -104,13 +103,13 @@ func (s *Scraper) CreateTweet(tweet NewTweet) (*Tweet, error) {
 	}
 
 	body := map[string]interface{}{
-		"features":  features,
+		"features":  s.withFeatures(features),
 		"variables": variables,
 		"queryId":   "oB-5XsHNAbjvARJEc8CZFw",
 	}
 
 	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	setRequestBody(req, b)
 
 	var response newTweet
 	err = s.RequestAPI(req, &response)
@@ -125,8 +124,8 @@ func (s *Scraper) CreateTweet(tweet NewTweet) (*Tweet, error) {
 	return nil, errors.New("tweet wasn't post")
 }
 
-func (s *Scraper) DeleteTweet(tweetId string) error {
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/VaenaVgh5q5ih7kvyVjgtg/DeleteTweet")
+func (s *Scraper) DeleteTweet(ctx context.Context, tweetId string) error {
+	req, err := s.newRequest(ctx, "POST", "https://twitter.com/i/api/graphql/VaenaVgh5q5ih7kvyVjgtg/DeleteTweet")
 	if err != nil {
 		return err
 	}
@@ -143,7 +142,7 @@ func (s *Scraper) DeleteTweet(tweetId string) error {
 	}
 
 	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	setRequestBody(req, b)
 
 	var response struct {
 		Data struct {
@@ -162,8 +161,8 @@ func (s *Scraper) DeleteTweet(tweetId string) error {
 	return nil
 }
 
-func (s *Scraper) CreateRetweet(tweetId string) (string, error) {
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/ojPdsZsimiJrUGLR1sjUtA/CreateRetweet")
+func (s *Scraper) CreateRetweet(ctx context.Context, tweetId string) (string, error) {
+	req, err := s.newRequest(ctx, "POST", "https://twitter.com/i/api/graphql/ojPdsZsimiJrUGLR1sjUtA/CreateRetweet")
 	if err != nil {
 		return "", err
 	}
@@ -180,7 +179,7 @@ func (s *Scraper) CreateRetweet(tweetId string) (string, error) {
 	}
 
 	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	setRequestBody(req, b)
 
 	var response struct {
 		Data struct {
@@ -210,8 +209,8 @@ func (s *Scraper) CreateRetweet(tweetId string) (string, error) {
 }
 
 // Retweeted tweets has their own id, but to delete retweet twitter using id of source tweet
-func (s *Scraper) DeleteRetweet(tweetId string) error {
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/iQtK4dl5hBmXewYZuEOKVw/DeleteRetweet")
+func (s *Scraper) DeleteRetweet(ctx context.Context, tweetId string) error {
+	req, err := s.newRequest(ctx, "POST", "https://twitter.com/i/api/graphql/iQtK4dl5hBmXewYZuEOKVw/DeleteRetweet")
 	if err != nil {
 		return err
 	}
@@ -227,7 +226,7 @@ func (s *Scraper) DeleteRetweet(tweetId string) error {
 	}
 
 	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	setRequestBody(req, b)
 
 	var response struct {
 		Data struct {
@@ -252,8 +251,8 @@ func (s *Scraper) DeleteRetweet(tweetId string) error {
 	return nil
 }
 
-func (s *Scraper) LikeTweet(tweetId string) error {
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/lI07N6Otwv1PhnEgXILM7A/FavoriteTweet")
+func (s *Scraper) LikeTweet(ctx context.Context, tweetId string) error {
+	req, err := s.newRequest(ctx, "POST", "https://twitter.com/i/api/graphql/lI07N6Otwv1PhnEgXILM7A/FavoriteTweet")
 	if err != nil {
 		return err
 	}
@@ -268,7 +267,7 @@ func (s *Scraper) LikeTweet(tweetId string) error {
 	}
 
 	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	setRequestBody(req, b)
 
 	var response struct {
 		Data struct {
@@ -296,8 +295,8 @@ func (s *Scraper) LikeTweet(tweetId string) error {
 	return nil
 }
 
-func (s *Scraper) UnlikeTweet(tweetId string) error {
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/ZYKSe-w7KEslx3JhSIk5LA/UnfavoriteTweet")
+func (s *Scraper) UnlikeTweet(ctx context.Context, tweetId string) error {
+	req, err := s.newRequest(ctx, "POST", "https://twitter.com/i/api/graphql/ZYKSe-w7KEslx3JhSIk5LA/UnfavoriteTweet")
 	if err != nil {
 		return err
 	}
@@ -312,7 +311,7 @@ func (s *Scraper) UnlikeTweet(tweetId string) error {
 	}
 
 	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	setRequestBody(req, b)
 
 	var response struct {
 		Data struct {
@@ -339,12 +338,31 @@ func (s *Scraper) UnlikeTweet(tweetId string) error {
 
 	return nil
 }
-func (s *Scraper) GetTweetRetweeters(tweetId string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
+
+// GetRetweeters returns channel with users who retweeted the tweet. Scrape
+// stopped before the end of list resumes from the last page on the next call,
+// as GetFollowers.
+func (s *Scraper) GetRetweeters(ctx context.Context, tweetID string, maxUsersNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, tweetID, maxUsersNbr, s.trackProfiles(cursorKey("retweeters", tweetID), s.GetTweetRetweeters))
+}
+
+// GetQuoteTweets returns channel with tweets quoting the tweet, from the
+// newest, resumes as GetRetweeters.
+func (s *Scraper) GetQuoteTweets(ctx context.Context, tweetID string, maxTweetsNbr int) <-chan *TweetResult {
+	return s.getTweetTimeline(ctx, tweetID, maxTweetsNbr, s.trackTweets(cursorKey("quotes", tweetID), s.FetchQuoteTweets))
+}
+
+// FetchQuoteTweets gets tweets quoting the tweet, via search as Twitter frontend does.
+func (s *Scraper) FetchQuoteTweets(ctx context.Context, tweetID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchSearchTweets(ctx, "quoted_tweet_id:"+tweetID, maxTweetsNbr, cursor, SearchLatest)
+}
+
+func (s *Scraper) GetTweetRetweeters(ctx context.Context, tweetId string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
 	if maxUsersNbr > 200 {
 		maxUsersNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/8019obfgnveiPiJuS2Rtow/Retweeters")
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/8019obfgnveiPiJuS2Rtow/Retweeters")
 	if err != nil {
 		return nil, "", err
 	}
@@ -387,7 +405,7 @@ func (s *Scraper) GetTweetRetweeters(tweetId string, maxUsersNbr int, cursor str
 
 	query := url.Values{}
 	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
 	req.URL.RawQuery = query.Encode()
 
 	var timeline retweetersTimelineV2
@@ -397,6 +415,9 @@ func (s *Scraper) GetTweetRetweeters(tweetId string, maxUsersNbr int, cursor str
 	}
 
 	users, nextCursor := timeline.parseUsers()
+	if users, err = s.filterProfiles(users); err != nil {
+		return nil, "", err
+	}
 
 	if strings.HasPrefix(nextCursor, "0|") {
 		nextCursor = ""
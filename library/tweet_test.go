@@ -1,6 +1,7 @@
 package twitterscraper_test
 
 import (
+	"context"
 	"testing"
 
 	twitterscraper "github.com/imperatrona/twitter-scraper"
@@ -15,7 +16,7 @@ func TestCreateTweet(t *testing.T) {
 
 	var err error
 	var tweet *twitterscraper.Tweet
-	tweet, err = testScraper.CreateTweet(twitterscraper.NewTweet{
+	tweet, err = testScraper.CreateTweet(context.Background(), twitterscraper.NewTweet{
 		Text:   "i love hollywood 🖤",
 		Medias: nil,
 	})
@@ -36,19 +37,19 @@ func TestCreateTweetWithMedia(t *testing.T) {
 	var err error
 
 	var video *twitterscraper.Media
-	video, err = testScraper.UploadMedia("./photo.jpg")
+	video, err = testScraper.UploadMedia(context.Background(), "./photo.jpg")
 	if err != nil {
 		t.Error(err)
 	}
 
 	var photo *twitterscraper.Media
-	photo, err = testScraper.UploadMedia("./video.mp4")
+	photo, err = testScraper.UploadMedia(context.Background(), "./video.mp4")
 	if err != nil {
 		t.Error(err)
 	}
 
 	var tweet *twitterscraper.Tweet
-	tweet, err = testScraper.CreateTweet(twitterscraper.NewTweet{
+	tweet, err = testScraper.CreateTweet(context.Background(), twitterscraper.NewTweet{
 		Text: "3 more seconds till i get 🖤",
 		Medias: []*twitterscraper.Media{
 			photo,
@@ -72,7 +73,7 @@ func TestDeleteTweet(t *testing.T) {
 		t.Skip("run TestCreateTweet before")
 	}
 
-	if err := testScraper.DeleteTweet(testDeleteTweetId); err != nil {
+	if err := testScraper.DeleteTweet(context.Background(), testDeleteTweetId); err != nil {
 		t.Error(err)
 	}
 }
@@ -81,7 +82,7 @@ func TestCreateRetweet(t *testing.T) {
 	if skipAuthTest {
 		t.Skip("Skipping test due to environment variable")
 	}
-	if _, err := testScraper.CreateRetweet("1792634158977568997"); err != nil {
+	if _, err := testScraper.CreateRetweet(context.Background(), "1792634158977568997"); err != nil {
 		t.Error(err)
 	}
 }
@@ -90,7 +91,7 @@ func TestDeleteRetweet(t *testing.T) {
 	if skipAuthTest {
 		t.Skip("Skipping test due to environment variable")
 	}
-	if err := testScraper.DeleteRetweet("1792634158977568997"); err != nil {
+	if err := testScraper.DeleteRetweet(context.Background(), "1792634158977568997"); err != nil {
 		t.Error(err)
 	}
 }
@@ -101,10 +102,10 @@ func TestLikeAndUnlikeTweet(t *testing.T) {
 	}
 
 	tweetId := "1792634158977568997"
-	if err := testScraper.LikeTweet(tweetId); err != nil {
+	if err := testScraper.LikeTweet(context.Background(), tweetId); err != nil {
 		t.Error(err)
 	}
-	if err := testScraper.UnlikeTweet(tweetId); err != nil {
+	if err := testScraper.UnlikeTweet(context.Background(), tweetId); err != nil {
 		t.Error(err)
 	}
 }
@@ -115,7 +116,7 @@ func TestGetTweetRetweeters(t *testing.T) {
 	}
 	tweetId := "1792634158977568997"
 
-	retweeters, _, err := testScraper.GetTweetRetweeters(tweetId, 20, "")
+	retweeters, _, err := testScraper.GetTweetRetweeters(context.Background(), tweetId, 20, "")
 	if err != nil {
 		t.Error(err)
 	}
@@ -124,3 +125,28 @@ func TestGetTweetRetweeters(t *testing.T) {
 		t.Error("0 tweet retweeters")
 	}
 }
+
+func TestGetRetweetersAndQuotes(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	tweetId := "1792634158977568997"
+
+	retweeters, err := twitterscraper.CollectProfileResults(testScraper.GetRetweeters(context.Background(), tweetId, 30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(retweeters) == 0 || len(retweeters) > 30 {
+		t.Errorf("Expected up to 30 retweeters, got %d", len(retweeters))
+	}
+
+	quotes, err := twitterscraper.CollectTweetResults(testScraper.GetQuoteTweets(context.Background(), tweetId, 30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, quote := range quotes {
+		if quote.QuotedStatusID != tweetId {
+			t.Errorf("Expected tweet %s quoting %s, got %s", quote.ID, tweetId, quote.QuotedStatusID)
+		}
+	}
+}
@@ -2,51 +2,97 @@ package twitterscraper
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
 )
 
+// maximum pages without author replies skipped by FetchAuthorReplies
+const maxAuthorReplyPages = 10
+
 // GetTweets returns channel with tweets for a given user.
 func (s *Scraper) GetTweets(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchTweets)
+	return s.getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchTweets)
 }
 
 // GetTweetsAndReplies returns channel with tweets and replies for a given user.
 func (s *Scraper) GetTweetsAndReplies(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchTweetsAndReplies)
+	return s.getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchTweetsAndReplies)
+}
+
+// GetAuthorReplies returns channel with replies of user to other users, without
+// tweets and replies of user's self-threads.
+func (s *Scraper) GetAuthorReplies(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
+	return s.getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchAuthorReplies)
+}
+
+// GetTweetsByUserID returns channel with tweets for a given user ID.
+func (s *Scraper) GetTweetsByUserID(ctx context.Context, userID string, maxTweetsNbr int) <-chan *TweetResult {
+	return s.getTweetTimeline(ctx, userID, maxTweetsNbr, s.FetchTweetsByUserID)
 }
 
 // FetchTweets gets tweets for a given user, via the Twitter frontend API.
-func (s *Scraper) FetchTweets(user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-	userID, err := s.GetUserIDByScreenName(user)
+func (s *Scraper) FetchTweets(ctx context.Context, user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	userID, err := s.GetUserIDByScreenName(ctx, user)
 	if err != nil {
 		return nil, "", err
 	}
 
-	if s.isOpenAccount {
-		return s.FetchTweetsByUserIDLegacy(userID, maxTweetsNbr, cursor)
-	}
-	return s.FetchTweetsByUserID(userID, maxTweetsNbr, cursor)
+	return s.FetchTweetsByUserID(ctx, userID, maxTweetsNbr, cursor)
 }
 
 // FetchTweetsAndReplies gets tweets and replies for a given user, via the Twitter frontend API.
-func (s *Scraper) FetchTweetsAndReplies(user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-	userID, err := s.GetUserIDByScreenName(user)
+func (s *Scraper) FetchTweetsAndReplies(ctx context.Context, user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	userID, err := s.GetUserIDByScreenName(ctx, user)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return s.FetchTweetsAndRepliesByUserID(ctx, userID, maxTweetsNbr, cursor)
+}
+
+// FetchAuthorReplies gets replies of user to other users from timeline of
+// tweets and replies. Pages without such replies are skipped, up to 10 in a
+// row, so empty page means the end of timeline.
+func (s *Scraper) FetchAuthorReplies(ctx context.Context, user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	userID, err := s.GetUserIDByScreenName(ctx, user)
 	if err != nil {
 		return nil, "", err
 	}
 
-	return s.FetchTweetsAndRepliesByUserID(userID, maxTweetsNbr, cursor)
+	for page := 0; page < maxAuthorReplyPages; page++ {
+		tweets, next, err := s.FetchTweetsAndRepliesByUserID(ctx, userID, maxTweetsNbr, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		if replies := AuthorReplies(tweets, userID); len(replies) > 0 || len(tweets) == 0 || next == "" || next == cursor {
+			return replies, next, nil
+		}
+		cursor = next
+	}
+	return nil, "", nil
+}
+
+// AuthorReplies returns tweets of userID replying to other users, such as
+// tweets of timeline or conversation.
+func AuthorReplies(tweets []*Tweet, userID string) []*Tweet {
+	var replies []*Tweet
+	for _, tweet := range tweets {
+		if tweet.UserID == userID && tweet.IsReply && tweet.InReplyToUserID != userID {
+			replies = append(replies, tweet)
+		}
+	}
+	return replies
 }
 
 // FetchTweetsAndRepliesByUserID gets tweets and replies for a given userID, via the Twitter frontend GraphQL API.
-func (s *Scraper) FetchTweetsAndRepliesByUserID(userID string, maxReplysNbr int, cursor string) ([]*Tweet, string, error) {
+func (s *Scraper) FetchTweetsAndRepliesByUserID(ctx context.Context, userID string, maxReplysNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxReplysNbr > 200 {
 		maxReplysNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/bt4TKuFz4T7Ckk-VvQVSow/UserTweetsAndReplies")
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/bt4TKuFz4T7Ckk-VvQVSow/UserTweetsAndReplies")
 	if err != nil {
 		return nil, "", err
 	}
@@ -92,26 +138,53 @@ func (s *Scraper) FetchTweetsAndRepliesByUserID(userID string, maxReplysNbr int,
 
 	query := url.Values{}
 	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
 	req.URL.RawQuery = query.Encode()
 
 	var timeline timelineV2
-	err = s.RequestAPI(req, &timeline)
+	meta, err := s.requestAPIMeta(req, &timeline)
 	if err != nil {
-		return nil, "", err
+		return nil, "", s.checkProtected(ctx, userID, err)
 	}
 
 	tweets, nextCursor := timeline.parseTweets()
+	tweets = s.placePinned(tweets, timeline.pinnedTweet())
+	s.setProvenance(tweets, meta, EndpointTimeline, cursor)
+	s.reportModules(timeline.modules(), meta, EndpointTimeline, cursor)
+	if tweets, err = s.filterTweets(tweets); err != nil {
+		return nil, "", err
+	}
+	if cursor == "" && len(tweets) == 0 {
+		if err := s.checkProtected(ctx, userID, nil); err != nil {
+			return nil, "", err
+		}
+	}
 	return tweets, nextCursor, nil
 }
 
-// FetchTweetsByUserID gets tweets for a given userID, via the Twitter frontend GraphQL API.
-func (s *Scraper) FetchTweetsByUserID(userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+// FetchTweetsByUserID gets tweets for a given userID, via the Twitter frontend GraphQL API,
+// or legacy API in open account mode. If API refuses the first page in current auth state,
+// the other one is used. Cursors of both APIs differ, so pages of one timeline are always
+// requested via the same API.
+func (s *Scraper) FetchTweetsByUserID(ctx context.Context, userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	var tweets []*Tweet
+	var nextCursor string
+	err := s.withFallback(EndpointTimeline, cursor == "", func() (err error) {
+		tweets, nextCursor, err = s.fetchTweetsByUserIDGraphQL(ctx, userID, maxTweetsNbr, cursor)
+		return err
+	}, func() (err error) {
+		tweets, nextCursor, err = s.FetchTweetsByUserIDLegacy(ctx, userID, maxTweetsNbr, cursor)
+		return err
+	})
+	return tweets, nextCursor, err
+}
+
+func (s *Scraper) fetchTweetsByUserIDGraphQL(ctx context.Context, userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxTweetsNbr > 200 {
 		maxTweetsNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/UGi7tjRPr-d_U3bCPIko5Q/UserTweets")
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/UGi7tjRPr-d_U3bCPIko5Q/UserTweets")
 	if err != nil {
 		return nil, "", err
 	}
@@ -154,26 +227,37 @@ func (s *Scraper) FetchTweetsByUserID(userID string, maxTweetsNbr int, cursor st
 
 	query := url.Values{}
 	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
 	req.URL.RawQuery = query.Encode()
 
 	var timeline timelineV2
-	err = s.RequestAPI(req, &timeline)
+	meta, err := s.requestAPIMeta(req, &timeline)
 	if err != nil {
-		return nil, "", err
+		return nil, "", s.checkProtected(ctx, userID, err)
 	}
 
 	tweets, nextCursor := timeline.parseTweets()
+	tweets = s.placePinned(tweets, timeline.pinnedTweet())
+	s.setProvenance(tweets, meta, EndpointTimeline, cursor)
+	s.reportModules(timeline.modules(), meta, EndpointTimeline, cursor)
+	if tweets, err = s.filterTweets(tweets); err != nil {
+		return nil, "", err
+	}
+	if cursor == "" && len(tweets) == 0 {
+		if err := s.checkProtected(ctx, userID, nil); err != nil {
+			return nil, "", err
+		}
+	}
 	return tweets, nextCursor, nil
 }
 
 // FetchTweetsByUserIDLegacy gets tweets for a given userID, via the Twitter frontend legacy API.
-func (s *Scraper) FetchTweetsByUserIDLegacy(userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+func (s *Scraper) FetchTweetsByUserIDLegacy(ctx context.Context, userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxTweetsNbr > 200 {
 		maxTweetsNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://api.twitter.com/2/timeline/profile/"+userID+".json")
+	req, err := s.newRequest(ctx, "GET", "https://api.twitter.com/2/timeline/profile/"+userID+".json")
 	if err != nil {
 		return nil, "", err
 	}
@@ -187,37 +271,84 @@ func (s *Scraper) FetchTweetsByUserIDLegacy(userID string, maxTweetsNbr int, cur
 	req.URL.RawQuery = q.Encode()
 
 	var timeline timelineV1
-	err = s.RequestAPI(req, &timeline)
+	meta, err := s.requestAPIMeta(req, &timeline)
 	if err != nil {
 		return nil, "", err
 	}
 
 	tweets, nextCursor := timeline.parseTweets()
+	s.setProvenance(tweets, meta, EndpointTimeline, cursor)
+	if tweets, err = s.filterTweets(tweets); err != nil {
+		return nil, "", err
+	}
 	return tweets, nextCursor, nil
 }
 
-// GetTweet get a single tweet by ID.
-func (s *Scraper) GetTweet(id string) (*Tweet, error) {
-	if s.isOpenAccount {
-		req, err := s.newRequest("GET", "https://api.twitter.com/2/timeline/conversation/"+id+".json")
-		if err != nil {
-			return nil, err
-		}
+// GetTweet get a single tweet by ID. In open account mode it's requested via
+// legacy API. If API refuses the request in current auth state, the other one
+// is used. Age-restricted tweet is requested again with age-verified account
+// of pool, without such account ErrAgeRestricted is returned and tweet ID is
+// reported in Stats. Tweet of protected or suspended author returns
+// ErrProtected or ErrUserSuspended.
+func (s *Scraper) GetTweet(ctx context.Context, id string) (*Tweet, error) {
+	tweet, err := s.getTweet(ctx, id)
+	if errors.Is(err, ErrAgeRestricted) {
+		return s.getAgeRestrictedTweet(ctx, id)
+	}
+	return tweet, err
+}
 
-		var timeline timelineV1
-		err = s.RequestAPI(req, &timeline)
-		if err != nil {
-			return nil, err
-		}
+// getAgeRestrictedTweet requests tweet with age-verified account of pool.
+func (s *Scraper) getAgeRestrictedTweet(ctx context.Context, id string) (*Tweet, error) {
+	if isAgeVerifiedOnly(ctx) {
+		return nil, ErrAgeRestricted
+	}
+	if !s.pool.hasAgeVerified() {
+		s.recordAgeRestricted(id)
+		return nil, ErrAgeRestricted
+	}
+	return s.getTweet(withAgeVerified(ctx), id)
+}
 
-		tweets, _ := timeline.parseTweets()
-		for _, tweet := range tweets {
-			if tweet.ID == id {
-				return tweet, nil
-			}
+func (s *Scraper) getTweet(ctx context.Context, id string) (*Tweet, error) {
+	var tweet *Tweet
+	err := s.withFallback(EndpointTweetDetail, true, func() (err error) {
+		tweet, err = s.getTweetGraphQL(ctx, id)
+		return err
+	}, func() (err error) {
+		tweet, err = s.getTweetLegacy(ctx, id)
+		return err
+	})
+	return tweet, err
+}
+
+// getTweetLegacy gets tweet from its conversation via legacy API.
+func (s *Scraper) getTweetLegacy(ctx context.Context, id string) (*Tweet, error) {
+	req, err := s.newRequest(ctx, "GET", "https://api.twitter.com/2/timeline/conversation/"+id+".json")
+	if err != nil {
+		return nil, err
+	}
+
+	var timeline timelineV1
+	meta, err := s.requestAPIMeta(req, &timeline)
+	if err != nil {
+		return nil, err
+	}
+
+	tweets, _ := timeline.parseTweets()
+	for _, tweet := range tweets {
+		if tweet.ID == id {
+			s.setProvenance([]*Tweet{tweet}, meta, EndpointTweetDetail, "")
+			return tweet, s.validateTweets([]*Tweet{tweet})
 		}
-	} else if s.isLogged {
-		req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/VWFGPVAGkZMGRKGe3GFFnA/TweetDetail")
+	}
+	return nil, fmt.Errorf("tweet with ID %s: %w", id, ErrTweetNotFound)
+}
+
+// getTweetGraphQL gets tweet via GraphQL API, TweetDetail when logged in.
+func (s *Scraper) getTweetGraphQL(ctx context.Context, id string) (*Tweet, error) {
+	if s.isLogged {
+		req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/VWFGPVAGkZMGRKGe3GFFnA/TweetDetail")
 		if err != nil {
 			return nil, err
 		}
@@ -256,7 +387,7 @@ func (s *Scraper) GetTweet(id string) (*Tweet, error) {
 
 		query := url.Values{}
 		query.Set("variables", mapToJSONString(variables))
-		query.Set("features", mapToJSONString(features))
+		query.Set("features", mapToJSONString(s.withFeatures(features)))
 		req.URL.RawQuery = query.Encode()
 
 		var conversation threadedConversation
@@ -269,7 +400,7 @@ func (s *Scraper) GetTweet(id string) (*Tweet, error) {
 			s.setBearerToken(bearerToken2)
 		}
 
-		err = s.RequestAPI(req, &conversation)
+		meta, err := s.requestAPIMeta(req, &conversation)
 
 		if curBearerToken != bearerToken2 {
 			s.setBearerToken(curBearerToken)
@@ -282,11 +413,15 @@ func (s *Scraper) GetTweet(id string) (*Tweet, error) {
 		tweets, _ := conversation.parse(id)
 		for _, tweet := range tweets {
 			if tweet.ID == id {
-				return tweet, nil
+				s.setProvenance([]*Tweet{tweet}, meta, EndpointTweetDetail, "")
+				return tweet, s.validateTweets([]*Tweet{tweet})
 			}
 		}
+		if err := conversation.unavailable(id); err != nil {
+			return nil, err
+		}
 	} else {
-		req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/xBtHv5-Xsk268T5ng_OGNg/TweetResultByRestId")
+		req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/xBtHv5-Xsk268T5ng_OGNg/TweetResultByRestId")
 		if err != nil {
 			return nil, err
 		}
@@ -324,7 +459,7 @@ func (s *Scraper) GetTweet(id string) (*Tweet, error) {
 
 		query := url.Values{}
 		query.Set("variables", mapToJSONString(variables))
-		query.Set("features", mapToJSONString(features))
+		query.Set("features", mapToJSONString(s.withFeatures(features)))
 		query.Set("fieldToggles", mapToJSONString(fieldToggles))
 		req.URL.RawQuery = query.Encode()
 
@@ -338,7 +473,7 @@ func (s *Scraper) GetTweet(id string) (*Tweet, error) {
 			s.setBearerToken(bearerToken2)
 		}
 
-		err = s.RequestAPI(req, &result)
+		meta, err := s.requestAPIMeta(req, &result)
 
 		if curBearerToken != bearerToken2 {
 			s.setBearerToken(curBearerToken)
@@ -348,10 +483,15 @@ func (s *Scraper) GetTweet(id string) (*Tweet, error) {
 			return nil, err
 		}
 
-		tweet := result.parse()
-		return tweet, nil
+		if tweet := result.parse(); tweet != nil {
+			s.setProvenance([]*Tweet{tweet}, meta, EndpointTweetDetail, "")
+			return tweet, s.validateTweets([]*Tweet{tweet})
+		}
+		if err := result.Data.TweetResult.Result.unavailable(); err != nil {
+			return nil, err
+		}
 	}
-	return nil, fmt.Errorf("tweet with ID %s not found", id)
+	return nil, fmt.Errorf("tweet with ID %s: %w", id, ErrTweetNotFound)
 }
 
 type homeEntry struct {
@@ -364,9 +504,12 @@ type homeEntry struct {
 			TweetResults struct {
 				Result result `json:"result"`
 			} `json:"tweet_results"`
+			PromotedMetadata *promotedMetadata `json:"promotedMetadata"`
 		} `json:"itemContent"`
-		Cursor     string `json:"value"`
-		CursorType string `json:"cursorType"`
+		Items      []item       `json:"items"`
+		Header     moduleHeader `json:"header"`
+		Cursor     string       `json:"value"`
+		CursorType string       `json:"cursorType"`
 	} `json:"content"`
 }
 
@@ -396,8 +539,11 @@ func (timeline *homeTimeline) parseTweets() ([]*Tweet, string) {
 		for _, entry := range instruction.Entries {
 			if entry.Content.CursorType == "Bottom" {
 				cursor = entry.Content.Cursor
+			} else if isAIModule(entry.EntryId, entry.Content.ItemContent.ItemType, entry.Content.Items) {
+				continue
 			} else if entry.Content.ItemContent.TweetResults.Result.Typename == "Tweet" {
 				if tweet := entry.Content.ItemContent.TweetResults.Result.parse(); tweet != nil {
+					entry.Content.ItemContent.PromotedMetadata.flag(tweet)
 					tweets = append(tweets, tweet)
 				}
 			}
@@ -408,20 +554,20 @@ func (timeline *homeTimeline) parseTweets() ([]*Tweet, string) {
 
 // GetHomeTweets returns channel with tweets from home timeline
 func (s *Scraper) GetHomeTweets(ctx context.Context, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, "", maxTweetsNbr, s.fetchHomeTweets)
+	return s.getTweetTimeline(ctx, "", maxTweetsNbr, s.fetchHomeTweets)
 }
 
-func (s *Scraper) FetchHomeTweets(maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-	return s.fetchHomeTweets("", maxTweetsNbr, cursor)
+func (s *Scraper) FetchHomeTweets(ctx context.Context, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchHomeTweets(ctx, "", maxTweetsNbr, cursor)
 }
 
 // FetchHomeTweets gets tweets from home timline, via the Twitter frontend API.
-func (s *Scraper) fetchHomeTweets(_ string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+func (s *Scraper) fetchHomeTweets(ctx context.Context, _ string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxTweetsNbr > 200 {
 		maxTweetsNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/9EwYy8pLBOSFlEoSP2STiQ/HomeLatestTimeline")
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/9EwYy8pLBOSFlEoSP2STiQ/HomeLatestTimeline")
 	if err != nil {
 		return nil, "", err
 	}
@@ -468,35 +614,40 @@ func (s *Scraper) fetchHomeTweets(_ string, maxTweetsNbr int, cursor string) ([]
 
 	query := url.Values{}
 	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
 	req.URL.RawQuery = query.Encode()
 
 	var timeline homeTimeline
-	err = s.RequestAPI(req, &timeline)
+	meta, err := s.requestAPIMeta(req, &timeline)
 	if err != nil {
 		return nil, "", err
 	}
 
 	tweets, nextCursor := timeline.parseTweets()
+	s.setProvenance(tweets, meta, EndpointHome, cursor)
+	s.reportModules(timeline.modules(), meta, EndpointHome, cursor)
+	if tweets, err = s.filterTweets(tweets); err != nil {
+		return nil, "", err
+	}
 	return tweets, nextCursor, nil
 }
 
 // GetForYouTweets returns channel with tweets from for you timeline
 func (s *Scraper) GetForYouTweets(ctx context.Context, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, "", maxTweetsNbr, s.fetchForYouTweets)
+	return s.getTweetTimeline(ctx, "", maxTweetsNbr, s.fetchForYouTweets)
 }
 
-func (s *Scraper) FetchForYouTweets(maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-	return s.fetchForYouTweets("", maxTweetsNbr, cursor)
+func (s *Scraper) FetchForYouTweets(ctx context.Context, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchForYouTweets(ctx, "", maxTweetsNbr, cursor)
 }
 
 // FetchForYouTweets gets tweets from for you timline, via the Twitter frontend API.
-func (s *Scraper) fetchForYouTweets(_ string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+func (s *Scraper) fetchForYouTweets(ctx context.Context, _ string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxTweetsNbr > 200 {
 		maxTweetsNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/1u0Wlkw6Ru1NwBUD-pDiww/HomeTimeline")
+	req, err := s.newRequest(ctx, "GET", "https://twitter.com/i/api/graphql/1u0Wlkw6Ru1NwBUD-pDiww/HomeTimeline")
 	if err != nil {
 		return nil, "", err
 	}
@@ -544,15 +695,20 @@ func (s *Scraper) fetchForYouTweets(_ string, maxTweetsNbr int, cursor string) (
 
 	query := url.Values{}
 	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
+	query.Set("features", mapToJSONString(s.withFeatures(features)))
 	req.URL.RawQuery = query.Encode()
 
 	var timeline homeTimeline
-	err = s.RequestAPI(req, &timeline)
+	meta, err := s.requestAPIMeta(req, &timeline)
 	if err != nil {
 		return nil, "", err
 	}
 
 	tweets, nextCursor := timeline.parseTweets()
+	s.setProvenance(tweets, meta, EndpointHome, cursor)
+	s.reportModules(timeline.modules(), meta, EndpointHome, cursor)
+	if tweets, err = s.filterTweets(tweets); err != nil {
+		return nil, "", err
+	}
 	return tweets, nextCursor, nil
 }
@@ -2,7 +2,10 @@ package twitterscraper_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -76,7 +79,7 @@ func TestGetTweets(t *testing.T) {
 
 func assertGetTweet(t *testing.T, expectedTweet *twitterscraper.Tweet) {
 	// to get tweet as struct fmt.Printf("%#v", actualTweet)
-	actualTweet, err := testScraper.GetTweet(expectedTweet.ID)
+	actualTweet, err := testScraper.GetTweet(context.Background(), expectedTweet.ID)
 	if err != nil {
 		t.Error(err)
 	} else if diff := cmp.Diff(expectedTweet, actualTweet, cmpOptions...); diff != "" {
@@ -184,7 +187,7 @@ func TestTweetMentions(t *testing.T) {
 		Username: "davidmcraney",
 		Name:     "David McRaney",
 	}}
-	tweet, err := testScraper.GetTweet("1554522888904101890")
+	tweet, err := testScraper.GetTweet(context.Background(), "1554522888904101890")
 	if err != nil {
 		t.Error(err)
 	} else {
@@ -214,7 +217,7 @@ func TestQuotedAndReply(t *testing.T) {
 		UserID:    "978944851",
 		Username:  "VsauceTwo",
 	}
-	tweet, err := testScraper.GetTweet("1237110897597976576")
+	tweet, err := testScraper.GetTweet(context.Background(), "1237110897597976576")
 	if err != nil {
 		t.Error(err)
 	} else {
@@ -225,7 +228,7 @@ func TestQuotedAndReply(t *testing.T) {
 			t.Error("Resulting quote does not match the sample", diff)
 		}
 	}
-	tweet, err = testScraper.GetTweet("1237111868445134850")
+	tweet, err = testScraper.GetTweet(context.Background(), "1237111868445134850")
 	if err != nil {
 		t.Error(err)
 	} else {
@@ -252,7 +255,7 @@ func TestRetweet(t *testing.T) {
 		UserID:         "1399766153053061121",
 		Username:       "premium",
 	}
-	tweet, err := testScraper.GetTweet("1758837226379596068")
+	tweet, err := testScraper.GetTweet(context.Background(), "1758837226379596068")
 	if err != nil {
 		t.Error(err)
 	} else {
@@ -280,7 +283,7 @@ func TestTweetViews(t *testing.T) {
 		Username:     "Support",
 		Views:        3189278,
 	}
-	tweet, err := testScraper.GetTweet("1606055187348688896")
+	tweet, err := testScraper.GetTweet(context.Background(), "1606055187348688896")
 	if err != nil {
 		t.Error(err)
 	} else {
@@ -294,7 +297,7 @@ func TestTweetThread(t *testing.T) {
 	if skipAuthTest {
 		t.Skip("Skipping test due to environment variable")
 	}
-	tweet, err := testScraper.GetTweet("1665602315745673217")
+	tweet, err := testScraper.GetTweet(context.Background(), "1665602315745673217")
 	if err != nil {
 		t.Fatal(err)
 	} else {
@@ -311,7 +314,7 @@ func TestFetchHomeTweets(t *testing.T) {
 	if skipAuthTest {
 		t.Skip("Skipping test due to environment variable")
 	}
-	tweets, _, err := testScraper.FetchHomeTweets(20, "")
+	tweets, _, err := testScraper.FetchHomeTweets(context.Background(), 20, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -319,6 +322,29 @@ func TestFetchHomeTweets(t *testing.T) {
 	if len(tweets) < 1 {
 		t.Fatal("returned 0 tweets")
 	}
+	for _, tweet := range tweets {
+		if tweet.IsAd && tweet.Ad == nil {
+			t.Errorf("Expected advertiser of ad %s", tweet.ID)
+		}
+	}
+}
+
+func TestFetchHomeTweetsWithoutAds(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	testScraper.SetAdsMode(twitterscraper.AdsExclude)
+	defer testScraper.SetAdsMode(twitterscraper.AdsInclude)
+
+	tweets, _, err := testScraper.FetchHomeTweets(context.Background(), 20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tweet := range tweets {
+		if tweet.IsAd {
+			t.Errorf("Expected ad %s to be excluded", tweet.ID)
+		}
+	}
 }
 
 func TestGetHomeTweets(t *testing.T) {
@@ -384,7 +410,7 @@ func TestFetchForYouTweets(t *testing.T) {
 	if skipAuthTest {
 		t.Skip("Skipping test due to environment variable")
 	}
-	tweets, _, err := testScraper.FetchForYouTweets(20, "")
+	tweets, _, err := testScraper.FetchForYouTweets(context.Background(), 20, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -458,7 +484,7 @@ func TestGetTweetsAndReplies(t *testing.T) {
 		t.Skip("Skipping test due to environment variable")
 	}
 
-	tweets, _, err := testScraper.FetchTweetsAndRepliesByUserID("17874544", 20, "")
+	tweets, _, err := testScraper.FetchTweetsAndRepliesByUserID(context.Background(), "17874544", 20, "")
 	if err != nil {
 		t.Error(err)
 	}
@@ -467,3 +493,104 @@ func TestGetTweetsAndReplies(t *testing.T) {
 		t.Errorf("Got %d tweets", len(tweets))
 	}
 }
+
+func TestAuthorReplies(t *testing.T) {
+	tweets := []*twitterscraper.Tweet{
+		{ID: "1", UserID: "10"},
+		{ID: "2", UserID: "10", IsReply: true, InReplyToStatusID: "1", InReplyToUserID: "10"},
+		{ID: "3", UserID: "10", IsReply: true, InReplyToStatusID: "4", InReplyToUserID: "20"},
+		{ID: "4", UserID: "20"},
+		{ID: "5", UserID: "20", IsReply: true, InReplyToStatusID: "3", InReplyToUserID: "10"},
+	}
+	replies := twitterscraper.AuthorReplies(tweets, "10")
+	if len(replies) != 1 || replies[0].ID != "3" {
+		t.Errorf("Expected only reply 3 to other user, got %+v", replies)
+	}
+}
+
+func TestGetAuthorReplies(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	userID, err := testScraper.GetUserIDByScreenName(context.Background(), "Support")
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	for tweet := range testScraper.GetAuthorReplies(context.Background(), "Support", 20) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		if tweet.UserID != userID || !tweet.IsReply || tweet.InReplyToUserID == userID {
+			t.Errorf("Expected reply of @Support to other user, got %s", tweet.PermanentURL)
+		}
+		count++
+	}
+	if count == 0 {
+		t.Error("Expected replies")
+	}
+}
+
+func TestFetchTweetsPinnedMode(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	defer testScraper.SetPinnedMode(twitterscraper.PinnedInclude)
+
+	testScraper.SetPinnedMode(twitterscraper.PinnedExclude)
+	tweets, _, err := testScraper.FetchTweets(context.Background(), "elonmusk", 20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tweet := range tweets {
+		if tweet.IsPin {
+			t.Errorf("Expected no pinned tweet, got %s", tweet.ID)
+		}
+	}
+
+	testScraper.SetPinnedMode(twitterscraper.PinnedSeparate)
+	tweets, _, err = testScraper.FetchTweets(context.Background(), "elonmusk", 20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tweet := range tweets {
+		if tweet.IsPin && i != 0 {
+			t.Errorf("Expected pinned tweet first, got at %d", i)
+		}
+	}
+}
+
+func TestGetTweetsContextCancel(t *testing.T) {
+	// proxy never answers, so request is in flight until context is done
+	release := make(chan struct{})
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer proxy.Close()
+	defer close(release)
+
+	scraper := twitterscraper.New()
+	scraper.AddAccount(twitterscraper.AuthToken{Token: "token", CSRFToken: "csrf"})
+	if err := scraper.SetProxy(proxy.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for tweet := range scraper.GetTweetsByUserID(ctx, "2244994945", 20) {
+			if tweet.Error == nil {
+				t.Error("Expected no tweets")
+			}
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected channel closed after context deadline")
+	}
+}
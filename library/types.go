@@ -1,6 +1,9 @@
 package twitterscraper
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type (
 	// Mention type.
@@ -24,6 +27,16 @@ type (
 		HLSURL  string
 	}
 
+	// Ad is promotion of promoted tweet.
+	Ad struct {
+		AdvertiserID       string
+		AdvertiserUsername string
+		AdvertiserName     string
+		// DisclosureType is NoDisclosure, Political, Issue or other type of
+		// disclosure of ad.
+		DisclosureType string
+	}
+
 	// GIF type.
 	GIF struct {
 		ID      string
@@ -33,6 +46,7 @@ type (
 
 	// Tweet type.
 	Tweet struct {
+		Ad                *Ad
 		ConversationID    string
 		GIFs              []GIF
 		Hashtags          []string
@@ -40,11 +54,18 @@ type (
 		ID                string
 		InReplyToStatus   *Tweet
 		InReplyToStatusID string
+		InReplyToUserID   string
+		IsAd              bool
 		IsQuoted          bool
 		IsPin             bool
 		IsReply           bool
 		IsRetweet         bool
 		IsSelfThread      bool
+		// IsTombstone is set on placeholder of tweet missing in thread, which
+		// couldn't be fetched, only its ID, time and author are known.
+		// TombstoneReason is NotFound, AgeRestricted, Protected or Suspended.
+		IsTombstone       bool
+		TombstoneReason   string
 		Likes             int
 		Name              string
 		Mentions          []Mention
@@ -67,6 +88,7 @@ type (
 		Videos            []Video
 		Views             int
 		SensitiveContent  bool
+		Provenance        *Provenance
 	}
 
 	// ProfileResult of scrapping.
@@ -137,6 +159,7 @@ type (
 		} `json:"extended_entities"`
 		IDStr                 string `json:"id_str"`
 		InReplyToStatusIDStr  string `json:"in_reply_to_status_id_str"`
+		InReplyToUserIDStr    string `json:"in_reply_to_user_id_str"`
 		Place                 Place  `json:"place"`
 		ReplyCount            int    `json:"reply_count"`
 		RetweetCount          int    `json:"retweet_count"`
@@ -244,6 +267,6 @@ type (
 		} `json:"bounding_box"`
 	}
 
-	fetchProfileFunc func(query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error)
-	fetchTweetFunc   func(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error)
+	fetchProfileFunc func(ctx context.Context, query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error)
+	fetchTweetFunc   func(ctx context.Context, query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error)
 )
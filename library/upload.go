@@ -2,6 +2,7 @@ package twitterscraper
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -40,25 +41,25 @@ type uploadStatusResponse struct {
 }
 
 // Uploads photo, video or gif for further posting or scheduling. Expires in 24 hours if not used.
-func (s *Scraper) UploadMedia(filePath string) (*Media, error) {
+func (s *Scraper) UploadMedia(ctx context.Context, filePath string) (*Media, error) {
 	fileContent, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
 
-	media, err := s.uploadInit(filePath, fileContent)
+	media, err := s.uploadInit(ctx, filePath, fileContent)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.uploadAppend(media, fileContent)
+	err = s.uploadAppend(ctx, media, fileContent)
 	if err != nil {
 		return nil, err
 	}
 
 	var status *ProcessingInfo
 
-	status, err = s.uploadFinalize(media)
+	status, err = s.uploadFinalize(ctx, media)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +70,7 @@ func (s *Scraper) UploadMedia(filePath string) (*Media, error) {
 
 	for status.State != "succeeded" {
 		time.Sleep(2 * time.Second)
-		status, err = s.uploadStatus(media)
+		status, err = s.uploadStatus(ctx, media)
 		if err != nil {
 			return nil, err
 		}
@@ -78,7 +79,7 @@ func (s *Scraper) UploadMedia(filePath string) (*Media, error) {
 	return media, nil
 }
 
-func (s *Scraper) uploadInit(filePath string, fileContent []byte) (*Media, error) {
+func (s *Scraper) uploadInit(ctx context.Context, filePath string, fileContent []byte) (*Media, error) {
 	var (
 		videoDuration float64
 		fileType      string
@@ -104,7 +105,7 @@ func (s *Scraper) uploadInit(filePath string, fileContent []byte) (*Media, error
 		return nil, fmt.Errorf("file type %s unsupported by twitter, make sure you uploading photo, video or gif", fileType)
 	}
 
-	req, err := s.newRequest("POST", "https://upload.twitter.com/i/media/upload.json")
+	req, err := s.newRequest(ctx, "POST", "https://upload.twitter.com/i/media/upload.json")
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +138,7 @@ func (s *Scraper) uploadInit(filePath string, fileContent []byte) (*Media, error
 	}, nil
 }
 
-func (s *Scraper) uploadAppend(media *Media, fileContent []byte) error {
+func (s *Scraper) uploadAppend(ctx context.Context, media *Media, fileContent []byte) error {
 	for i := 0; i <= media.Parts; i++ {
 		var partData []byte
 
@@ -158,7 +159,7 @@ func (s *Scraper) uploadAppend(media *Media, fileContent []byte) error {
 		}
 		w.Close()
 
-		req, err := s.newRequest("POST", "https://upload.twitter.com/i/media/upload.json")
+		req, err := s.newRequest(ctx, "POST", "https://upload.twitter.com/i/media/upload.json")
 		if err != nil {
 			return err
 		}
@@ -171,7 +172,7 @@ func (s *Scraper) uploadAppend(media *Media, fileContent []byte) error {
 		req.Header.Set("Content-Type", w.FormDataContentType())
 		req.Header.Set("Origin", "https://twitter.com")
 		req.Header.Set("Referer", "https://twitter.com/")
-		req.Body = io.NopCloser(&buf)
+		setRequestBody(req, buf.Bytes())
 
 		err = s.RequestAPI(req, nil)
 		if err != nil {
@@ -182,8 +183,8 @@ func (s *Scraper) uploadAppend(media *Media, fileContent []byte) error {
 	return nil
 }
 
-func (s *Scraper) uploadFinalize(media *Media) (*ProcessingInfo, error) {
-	req, err := s.newRequest("POST", "https://upload.twitter.com/i/media/upload.json")
+func (s *Scraper) uploadFinalize(ctx context.Context, media *Media) (*ProcessingInfo, error) {
+	req, err := s.newRequest(ctx, "POST", "https://upload.twitter.com/i/media/upload.json")
 	if err != nil {
 		return nil, err
 	}
@@ -206,8 +207,8 @@ func (s *Scraper) uploadFinalize(media *Media) (*ProcessingInfo, error) {
 	return &response.ProcessingInfo, nil
 }
 
-func (s *Scraper) uploadStatus(media *Media) (*ProcessingInfo, error) {
-	req, err := s.newRequest("GET", "https://upload.twitter.com/i/media/upload.json")
+func (s *Scraper) uploadStatus(ctx context.Context, media *Media) (*ProcessingInfo, error) {
+	req, err := s.newRequest(ctx, "GET", "https://upload.twitter.com/i/media/upload.json")
 	if err != nil {
 		return nil, err
 	}
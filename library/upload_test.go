@@ -1,6 +1,7 @@
 package twitterscraper_test
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"os"
@@ -32,7 +33,7 @@ func TestPhotoUpload(t *testing.T) {
 		t.Error(err)
 	}
 
-	media, err := testScraper.UploadMedia(f.Name())
+	media, err := testScraper.UploadMedia(context.Background(), f.Name())
 	if err != nil {
 		t.Error(err)
 	}
@@ -67,7 +68,7 @@ func TestVideoUpload(t *testing.T) {
 		t.Error(err)
 	}
 
-	media, err := testScraper.UploadMedia(f.Name())
+	media, err := testScraper.UploadMedia(context.Background(), f.Name())
 	if err != nil {
 		t.Error(err)
 	}
@@ -102,7 +103,7 @@ func TestGifUpload(t *testing.T) {
 		t.Error(err)
 	}
 
-	media, err := testScraper.UploadMedia(f.Name())
+	media, err := testScraper.UploadMedia(context.Background(), f.Name())
 	if err != nil {
 		t.Error(err)
 	}
@@ -1,9 +1,11 @@
 package twitterscraper
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -19,8 +21,8 @@ var (
 	twURL        = urlParse("https://twitter.com")
 )
 
-func (s *Scraper) newRequest(method string, url string) (*http.Request, error) {
-	req, err := http.NewRequest(method, url, nil)
+func (s *Scraper) newRequest(ctx context.Context, method string, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -62,23 +64,41 @@ func (s *Scraper) newRequest(method string, url string) (*http.Request, error) {
 	return req, nil
 }
 
+// setRequestBody sets body of request, which is read again when request is
+// retried.
+func setRequestBody(req *http.Request, body []byte) {
+	req.ContentLength = int64(len(body))
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+}
+
 func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetchFunc fetchProfileFunc) <-chan *ProfileResult {
 	channel := make(chan *ProfileResult)
 	go func(query string) {
 		defer close(channel)
+		// send returns false if context is done and consumer may not read anymore
+		send := func(result *ProfileResult) bool {
+			select {
+			case channel <- result:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
 		var nextCursor string
 		profilesNbr := 0
 		for profilesNbr < maxProfilesNbr {
-			select {
-			case <-ctx.Done():
-				channel <- &ProfileResult{Error: ctx.Err()}
+			if ctx.Err() != nil {
+				send(&ProfileResult{Error: ctx.Err()})
 				return
-			default:
 			}
 
-			profiles, next, err := fetchFunc(query, maxProfilesNbr, nextCursor)
+			profiles, next, err := fetchFunc(ctx, query, maxProfilesNbr, nextCursor)
 			if err != nil {
-				channel <- &ProfileResult{Error: err}
+				send(&ProfileResult{Error: err})
 				return
 			}
 
@@ -87,16 +107,11 @@ func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetc
 			}
 
 			for _, profile := range profiles {
-				select {
-				case <-ctx.Done():
-					channel <- &ProfileResult{Error: ctx.Err()}
-					return
-				default:
-				}
-
 				if profilesNbr < maxProfilesNbr {
 					nextCursor = next
-					channel <- &ProfileResult{Profile: *profile}
+					if !send(&ProfileResult{Profile: *profile}) {
+						return
+					}
 				} else {
 					break
 				}
@@ -107,23 +122,31 @@ func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetc
 	return channel
 }
 
-func getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, fetchFunc fetchTweetFunc) <-chan *TweetResult {
+func (s *Scraper) getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, fetchFunc fetchTweetFunc) <-chan *TweetResult {
 	channel := make(chan *TweetResult)
 	go func(query string) {
 		defer close(channel)
+		// send returns false if context is done and consumer may not read anymore
+		send := func(result *TweetResult) bool {
+			select {
+			case channel <- result:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
 		var nextCursor string
 		tweetsNbr := 0
 		for tweetsNbr < maxTweetsNbr {
-			select {
-			case <-ctx.Done():
-				channel <- &TweetResult{Error: ctx.Err()}
+			if ctx.Err() != nil {
+				send(&TweetResult{Error: ctx.Err()})
 				return
-			default:
 			}
 
-			tweets, next, err := fetchFunc(query, maxTweetsNbr, nextCursor)
+			tweets, next, err := fetchFunc(ctx, query, s.pageSize(ctx, maxTweetsNbr), nextCursor)
 			if err != nil {
-				channel <- &TweetResult{Error: err}
+				send(&TweetResult{Error: err})
 				return
 			}
 
@@ -132,16 +155,14 @@ func getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, fetch
 			}
 
 			for _, tweet := range tweets {
-				select {
-				case <-ctx.Done():
-					channel <- &TweetResult{Error: ctx.Err()}
-					return
-				default:
-				}
-
 				if tweetsNbr < maxTweetsNbr {
 					nextCursor = next
-					channel <- &TweetResult{Tweet: *tweet}
+					if !s.transformTweet(tweet) {
+						continue
+					}
+					if !send(&TweetResult{Tweet: *tweet}) {
+						return
+					}
 				} else {
 					break
 				}
@@ -189,6 +210,7 @@ func parseLegacyTweet(user *legacyUser, tweet *legacyTweet) *Tweet {
 	if tweet.InReplyToStatusIDStr != "" {
 		tw.IsReply = true
 		tw.InReplyToStatusID = tweet.InReplyToStatusIDStr
+		tw.InReplyToUserID = tweet.InReplyToUserIDStr
 	}
 	if tweet.RetweetedStatusIDStr != "" || tweet.RetweetedStatusResult.Result != nil {
 		tw.IsRetweet = true
@@ -1,71 +1,165 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
-	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	twitterscraper "github.com/imperatrona/twitter-scraper"
 	"github.com/joho/godotenv"
+	// SQLite driver of timeline -db archive and query
+	_ "modernc.org/sqlite"
 )
 
+// Exit codes, the last line on stderr is always a JSON summary with the same code.
+const (
+	exitSuccess   = 0
+	exitPartial   = 1 // finished, but some targets failed
+	exitAuth      = 2
+	exitRateLimit = 3 // aborted after hitting rate limit
+	exitConfig    = 4
+)
+
+// tweetOutput is a tweet written by timeline: Timestamp is unix time and Time is
+// RFC3339 in the selected zone, TimeParsed is omitted.
+type tweetOutput struct {
+	*twitterscraper.Tweet
+	TimeParsed *struct{} `json:",omitempty"`
+	Time       string
+}
+
+type summary struct {
+	RunID    string `json:"run_id"`
+	Status   string `json:"status"`
+	ExitCode int    `json:"exit_code"`
+	Targets  int    `json:"targets"`
+	Failed   int    `json:"failed"`
+	Tweets   int    `json:"tweets"`
+	Error    string `json:"error,omitempty"`
+}
+
+// runID identifies this run in logs, summary, output filenames, provenance of
+// tweets and media manifest, so artifacts of concurrent runs don't collide.
+var runID = twitterscraper.NewRunID()
+
+var statuses = map[int]string{
+	exitSuccess:   "success",
+	exitPartial:   "partial",
+	exitAuth:      "auth_failure",
+	exitRateLimit: "rate_limited",
+	exitConfig:    "config_error",
+}
+
+// exit prints summary to stderr and exits with its code.
+func exit(result summary, code int, err error) {
+	result.RunID = runID
+	result.Status = statuses[code]
+	result.ExitCode = code
+	if err != nil {
+		log.Print(err)
+		result.Error = err.Error()
+	}
+	json.NewEncoder(os.Stderr).Encode(result)
+	os.Exit(code)
+}
+
+func isRateLimit(err error) bool {
+	return errors.Is(err, twitterscraper.ErrRateLimited)
+}
+
+func isAccountError(err error) bool {
+	return errors.Is(err, twitterscraper.ErrAccountLocked) || errors.Is(err, twitterscraper.ErrAccountSuspended)
+}
+
 func main() {
+	log.SetPrefix(runID + " ")
+
+	// commands working with local files don't need authentication
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		validate(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "browse" {
+		browse(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "merge" {
+		merge(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "prune" {
+		prune(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "pseudonymize" {
+		pseudonymize(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "verify-media" {
+		verifyMedia(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "report" {
+		report(os.Args[2:])
+		return
+	}
+	// Full-text search of tweets archived with timeline -db
+	if len(os.Args) > 1 && os.Args[1] == "query" {
+		query(os.Args[2:])
+		return
+	}
+
 	// Load .env file
 	if err := godotenv.Load(); err != nil {
-		log.Fatal("Error loading .env file")
+		exit(summary{}, exitConfig, fmt.Errorf("error loading .env file: %w", err))
 	}
 
-	// Get auth tokens from environment variables
-	authToken := os.Getenv("TWITTER_AUTH_TOKEN_1")
-	csrfToken := os.Getenv("TWITTER_CSRF_TOKEN_1")
-
-	if authToken == "" || csrfToken == "" {
-		log.Fatal("TWITTER_AUTH_TOKEN_1 or TWITTER_CSRF_TOKEN_1 environment variables are not set")
+	// Get auth tokens of all accounts from environment variables,
+	// TWITTER_AUTH_TOKEN_N and TWITTER_CSRF_TOKEN_N starting from 1
+	var tokens []twitterscraper.AuthToken
+	for i := 1; ; i++ {
+		authToken := os.Getenv(fmt.Sprintf("TWITTER_AUTH_TOKEN_%d", i))
+		csrfToken := os.Getenv(fmt.Sprintf("TWITTER_CSRF_TOKEN_%d", i))
+		if authToken == "" || csrfToken == "" {
+			break
+		}
+		log.Printf("Using tokens of account-%d (first 4 chars) - Auth: %s... CSRF: %s...",
+			i, authToken[:4], csrfToken[:4])
+		tokens = append(tokens, twitterscraper.AuthToken{Token: authToken, CSRFToken: csrfToken})
 	}
 
-	log.Printf("Using tokens (first 4 chars) - Auth: %s... CSRF: %s...",
-		authToken[:4], csrfToken[:4])
-
-	// Initialize scraper
-	scraper := twitterscraper.New()
+	if len(tokens) == 0 {
+		exit(summary{}, exitConfig, errors.New("TWITTER_AUTH_TOKEN_1 or TWITTER_CSRF_TOKEN_1 environment variables are not set"))
+	}
 
-	// Create and set cookies directly
-	expires := time.Now().Add(365 * 24 * time.Hour)
-	cookies := []*http.Cookie{
-		{
-			Name:     "auth_token",
-			Value:    authToken,
-			Path:     "/",
-			Domain:   "twitter.com",
-			Expires:  expires,
-			Secure:   true,
-			HttpOnly: true,
-		},
-		{
-			Name:     "ct0",
-			Value:    csrfToken,
-			Path:     "/",
-			Domain:   "twitter.com",
-			Expires:  expires,
-			Secure:   true,
-			HttpOnly: false,
-		},
+	// Initialize scraper, accounts are rotated on every request
+	scraper := twitterscraper.New(twitterscraper.WithRunID(runID))
+	for _, token := range tokens {
+		scraper.AddAccount(token)
 	}
 
-	// First clear any existing cookies
-	scraper.ClearCookies()
-	scraper.SetCookies(cookies)
+	// interrupt or termination by orchestrator cancels requests in flight and stops the session
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Verify cookies were set
-	currentCookies := scraper.GetCookies()
-	for _, cookie := range currentCookies {
-		log.Printf("Cookie set: %s = %s...", cookie.Name, cookie.Value[:4])
+	// Self-test checks fingerprint of scraper without using accounts
+	if len(os.Args) > 1 && os.Args[1] == "selftest" {
+		selftest(ctx, scraper, os.Args[2:])
+		return
 	}
 
 	// Try to get a profile first as a test
-	testProfile, err := scraper.GetProfile("altcoindealer")
+	testProfile, err := scraper.GetProfile(ctx, "altcoindealer")
 	if err != nil {
 		log.Printf("Test profile fetch failed: %v", err)
 	} else {
@@ -73,13 +167,53 @@ func main() {
 	}
 
 	// Now check login status
-	if !scraper.IsLoggedIn() {
-		log.Fatal("Failed to authenticate with provided tokens")
+	if !scraper.IsLoggedIn(ctx) {
+		exit(summary{}, exitAuth, errors.New("failed to authenticate with provided tokens"))
 	}
 
 	// If we get here, authentication worked
 	log.Printf("Successfully authenticated!")
 
+	// Pipeline mode: read usernames from arguments or stdin ("-") and write tweets as NDJSON to stdout
+	if len(os.Args) > 1 && os.Args[1] == "timeline" {
+		timeline(ctx, scraper, os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "targets" {
+		targets(ctx, scraper, os.Args[2:])
+		return
+	}
+	// Daemon mode: write new tweets of users as NDJSON until interrupted
+	if len(os.Args) > 1 && os.Args[1] == "monitor" {
+		monitor(ctx, scraper, os.Args[2:])
+		return
+	}
+	// Follower tracking: write who followed and unfollowed the account since the previous run
+	if len(os.Args) > 1 && os.Args[1] == "followers" {
+		followers(ctx, scraper, os.Args[2:])
+		return
+	}
+	// Conversation report: write participants of replies to a tweet
+	if len(os.Args) > 1 && os.Args[1] == "participants" {
+		participants(ctx, scraper, os.Args[2:])
+		return
+	}
+	// Rehydration: write tweets of IDs list as NDJSON
+	if len(os.Args) > 1 && os.Args[1] == "hydrate" {
+		hydrate(ctx, scraper, os.Args[2:])
+		return
+	}
+	// Scripted flow: write tweets emitted by Starlark script as NDJSON
+	if len(os.Args) > 1 && os.Args[1] == "script" {
+		runScript(ctx, scraper, os.Args[2:])
+		return
+	}
+	// Audience overlap: write followers of users, their intersection and exclusive followers
+	if len(os.Args) > 1 && os.Args[1] == "audience" {
+		audience(ctx, scraper, os.Args[2:])
+		return
+	}
+
 	// Username to scrape (default to "x" if no argument provided)
 	username := "altcoindealer"
 	if len(os.Args) > 1 {
@@ -87,9 +221,9 @@ func main() {
 	}
 
 	// Get profile
-	profile, err := scraper.GetProfile(username)
+	profile, err := scraper.GetProfile(ctx, username)
 	if err != nil {
-		log.Fatal("Error getting profile:", err)
+		exit(summary{Targets: 1, Failed: 1}, exitCode(err), fmt.Errorf("error getting profile: %w", err))
 	}
 
 	// Print profile information
@@ -104,4 +238,198 @@ func main() {
 	fmt.Printf("Tweets: %d\n", profile.TweetsCount)
 	fmt.Printf("Verified: %v\n", profile.IsVerified)
 	fmt.Printf("Private: %v\n", profile.IsPrivate)
+
+	exit(summary{Targets: 1}, exitSuccess, nil)
+}
+
+// timeline writes tweets of every user as NDJSON to stdout, logs stay on stderr.
+// Targets read from stdin may override flags, see target.
+//
+//	cat users.txt | go run . timeline -n 50 - | jq .Text
+//	go run . timeline -fields id,text,likes,time elonmusk
+//	go run . timeline -media media -media-workers 8 elonmusk
+func timeline(ctx context.Context, scraper *twitterscraper.Scraper, args []string) {
+	flags := flag.NewFlagSet("timeline", flag.ContinueOnError)
+	maxTweetsNbr := flags.Int("n", 100, "max tweets per user")
+	tz := flags.String("tz", "UTC", "time zone of output times, IANA name or \"account\" for account profile zone")
+	fieldList := flags.String("fields", "", "comma separated fields to output, all by default")
+	mediaDir := flags.String("media", "", "directory to download media of tweets to")
+	mediaWorkers := flags.Int("media-workers", 4, "parallel media downloads")
+	mediaMetadata := flags.Bool("media-metadata", false, "embed author, tweet URL, date and text into downloaded media")
+	dateFormat := flags.String("date-format", "2006-01-02", "Go time layout of {date} in sink paths")
+	politeness := flags.String("politeness", "", "pacing profile of requests: aggressive, normal or stealth")
+	verifyThreads := flags.Bool("verify-threads", false, "fetch tweets missing in threads, unavailable ones are written as tombstones")
+	dbFile := flags.String("db", "", "SQLite archive to add tweets to, searchable with query")
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	if err := setPoliteness(scraper, *politeness); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+
+	fields, err := parseFields(*fieldList)
+	if err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+
+	loc, err := loadLocation(ctx, scraper, *tz)
+	if err != nil {
+		exit(summary{}, exitConfig, fmt.Errorf("error loading time zone: %w", err))
+	}
+
+	lines := flags.Args()
+	if len(lines) == 0 || (len(lines) == 1 && lines[0] == "-") {
+		if lines, err = readLines(os.Stdin); err != nil {
+			exit(summary{}, exitConfig, fmt.Errorf("error reading stdin: %w", err))
+		}
+	}
+	// lines of targets list may have user ID and options after username
+	list, err := parseTargets(lines)
+	if err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+
+	encode := outputEncoding(loc, fields)
+	files := &sinkFiles{encode: encode, started: time.Now().In(loc), dateFormat: *dateFormat}
+	targets := make([]twitterscraper.Target, len(list))
+	for i, t := range list {
+		if targets[i], err = t.sessionTarget(files.open); err != nil {
+			files.Close()
+			exit(summary{}, exitConfig, err)
+		}
+	}
+
+	out := twitterscraper.NewJSONLStore(os.Stdout).WithEncoding(encode)
+	sinks := []twitterscraper.Sink{twitterscraper.StoreSink(out)}
+
+	// media is downloaded by own workers while the next targets are scraped
+	var downloader *twitterscraper.MediaDownloader
+	if *mediaDir != "" {
+		if err := os.MkdirAll(*mediaDir, 0755); err != nil {
+			exit(summary{}, exitConfig, err)
+		}
+		downloader = scraper.NewMediaDownloader(ctx, *mediaDir, *mediaWorkers, 1000).WithMetadata(*mediaMetadata)
+		sinks = append(sinks, downloader)
+		for i := range targets {
+			if len(targets[i].Sinks) > 0 {
+				targets[i].Sinks = append(targets[i].Sinks, downloader)
+			}
+		}
+	}
+
+	// archive keeps tweets of all targets, including ones with own sinks
+	var archive *twitterscraper.SQLiteStore
+	if *dbFile != "" {
+		if archive, err = twitterscraper.OpenSQLiteStore("sqlite", *dbFile); err != nil {
+			files.Close()
+			exit(summary{}, exitConfig, fmt.Errorf("error opening archive: %w", err))
+		}
+		sink := twitterscraper.StoreSink(archive)
+		sinks = append(sinks, sink)
+		for i := range targets {
+			if len(targets[i].Sinks) > 0 {
+				targets[i].Sinks = append(targets[i].Sinks, sink)
+			}
+		}
+	}
+
+	session := twitterscraper.NewSession(scraper, *maxTweetsNbr).WithThreadVerification(*verifyThreads)
+	sessionResult, err := session.RunTargets(ctx, targets, sinks...)
+	if flushErr := out.Close(); err == nil && flushErr != nil {
+		err = fmt.Errorf("error writing output: %w", flushErr)
+	}
+	if closeErr := files.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("error writing output: %w", closeErr)
+	}
+	if archive != nil {
+		if closeErr := archive.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("error writing archive: %w", closeErr)
+		}
+	}
+	if downloader != nil {
+		downloads, manifestErr := downloader.Close()
+		if err == nil && manifestErr != nil {
+			err = fmt.Errorf("error writing media manifest: %w", manifestErr)
+		}
+		for mediaURL, downloadErr := range downloads.Errors {
+			log.Printf("Error downloading %s: %v", mediaURL, downloadErr)
+		}
+		log.Printf("Media downloaded: %d (resumed: %d), skipped: %d, failed: %d",
+			downloads.Downloaded, downloads.Resumed, downloads.Skipped, downloads.Failed)
+	}
+
+	for _, t := range list {
+		if userErr := sessionResult.Errors[t.Username]; userErr != nil {
+			log.Printf("Error getting tweets of @%s: %v", t.Username, userErr)
+		}
+	}
+
+	stats := scraper.Stats()
+	log.Printf("API calls: %d, errors: %d, by endpoint: %v, by account: %v, by status: %v",
+		stats.Requests, stats.Errors, stats.ByEndpoint, stats.ByAccount, stats.ByStatus)
+
+	result := summary{Targets: sessionResult.Targets, Failed: sessionResult.Failed, Tweets: sessionResult.Tweets}
+	switch {
+	case isRateLimit(err):
+		exit(result, exitRateLimit, err)
+	case isAccountError(err):
+		exit(result, exitAuth, err)
+	case err != nil:
+		exit(result, exitPartial, err)
+	case result.Failed > 0:
+		exit(result, exitPartial, nil)
+	}
+	exit(result, exitSuccess, nil)
+}
+
+// setPoliteness applies politeness profile by name, empty name keeps scraper as is.
+func setPoliteness(scraper *twitterscraper.Scraper, name string) error {
+	if name == "" {
+		return nil
+	}
+	p, err := twitterscraper.PolitenessProfile(name)
+	if err != nil {
+		return err
+	}
+	scraper.SetPoliteness(p)
+	return nil
+}
+
+// outputEncoding returns tweets with time in the selected zone and selected fields only.
+func outputEncoding(loc *time.Location, fields []string) func(tweet *twitterscraper.Tweet) (interface{}, error) {
+	return func(tweet *twitterscraper.Tweet) (interface{}, error) {
+		var output interface{} = tweetOutput{
+			Tweet: tweet,
+			Time:  time.Unix(tweet.Timestamp, 0).In(loc).Format(time.RFC3339),
+		}
+		if len(fields) > 0 {
+			return onlyFields(output, fields)
+		}
+		return output, nil
+	}
+}
+
+// loadLocation returns time zone by IANA name, "account" means time zone of account profile.
+func loadLocation(ctx context.Context, scraper *twitterscraper.Scraper, name string) (*time.Location, error) {
+	if name == "account" {
+		settings, err := scraper.GetAccountSettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return settings.Location(), nil
+	}
+	return time.LoadLocation(name)
+}
+
+// readLines returns non-empty lines, skipping # comments.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
 }
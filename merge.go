@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+// dumpRecord is a tweet read back from timeline output.
+type dumpRecord struct {
+	*twitterscraper.Tweet
+	Time string
+	// Raw is record as it's in dump.
+	Raw json.RawMessage `json:"-"`
+}
+
+// createdAt returns time of tweet from Timestamp or Time, zero if record has
+// neither, e.g. in dump written with -fields.
+func (record dumpRecord) createdAt() time.Time {
+	if record.Tweet != nil && record.Timestamp > 0 {
+		return time.Unix(record.Timestamp, 0)
+	}
+	if created, err := time.Parse(time.RFC3339, record.Time); err == nil {
+		return created
+	}
+	return time.Time{}
+}
+
+// output returns record as written by timeline, Time is set in UTC if it's missing.
+func (record dumpRecord) output() tweetOutput {
+	output := tweetOutput{Tweet: record.Tweet, Time: record.Time}
+	if output.Time == "" {
+		output.Time = time.Unix(record.Timestamp, 0).UTC().Format(time.RFC3339)
+	}
+	return output
+}
+
+// mergedOutput is a tweet written by merge with runs it was found in.
+type mergedOutput struct {
+	tweetOutput
+	// Runs are run IDs of provenance, or files of records without it.
+	Runs []string
+}
+
+// addRun adds run of record read from filename, if it isn't in Runs yet.
+func (output *mergedOutput) addRun(record dumpRecord, filename string) {
+	run := filename
+	if record.Tweet != nil && record.Provenance != nil && record.Provenance.RunID != "" {
+		run = record.Provenance.RunID
+	}
+	for _, r := range output.Runs {
+		if r == run {
+			return
+		}
+	}
+	output.Runs = append(output.Runs, run)
+}
+
+// merge combines dumps of several runs into one NDJSON on stdout. Every tweet is
+// written once with metrics of the freshest run, which is decided by fetch time
+// in provenance or by order of files if it's unknown.
+//
+//	go run . merge monday.ndjson tuesday.ndjson > merged.ndjson
+func merge(args []string) {
+	if len(args) < 2 {
+		exit(summary{}, exitConfig, errors.New("usage: merge <file> <file>..."))
+	}
+
+	var order []string
+	merged := make(map[string]*mergedOutput)
+	for _, filename := range args {
+		records, err := readDump(filename)
+		if err != nil {
+			exit(summary{Targets: len(args)}, exitConfig, fmt.Errorf("error reading %s: %w", filename, err))
+		}
+		for _, record := range records {
+			current, ok := merged[record.ID]
+			if !ok {
+				order = append(order, record.ID)
+				merged[record.ID] = &mergedOutput{tweetOutput: record.output()}
+				merged[record.ID].addRun(record, filename)
+				continue
+			}
+			current.addRun(record, filename)
+			if !fetchedBefore(record.Tweet, current.Tweet) {
+				current.tweetOutput = record.output()
+			}
+		}
+	}
+
+	tweets := make([]*twitterscraper.Tweet, 0, len(order))
+	byTweet := make(map[*twitterscraper.Tweet]*mergedOutput, len(order))
+	for _, id := range order {
+		tweets = append(tweets, merged[id].Tweet)
+		byTweet[merged[id].Tweet] = merged[id]
+	}
+	twitterscraper.SortTweets(tweets)
+
+	out := bufio.NewWriter(os.Stdout)
+	encoder := json.NewEncoder(out)
+	for _, tweet := range tweets {
+		if err := encoder.Encode(byTweet[tweet]); err != nil {
+			exit(summary{Targets: len(args)}, exitPartial, fmt.Errorf("error writing output: %w", err))
+		}
+	}
+	if err := out.Flush(); err != nil {
+		exit(summary{Targets: len(args)}, exitPartial, fmt.Errorf("error writing output: %w", err))
+	}
+	exit(summary{Targets: len(args), Tweets: len(tweets)}, exitSuccess, nil)
+}
+
+// fetchedBefore reports if a is known to be fetched before b.
+func fetchedBefore(a, b *twitterscraper.Tweet) bool {
+	if a.Provenance == nil || b.Provenance == nil {
+		return false
+	}
+	return a.Provenance.FetchedAt.Before(b.Provenance.FetchedAt)
+}
+
+// readDump reads NDJSON or JSON array written by timeline or merge.
+func readDump(filename string) ([]dumpRecord, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimSpace(data)
+	var raws []json.RawMessage
+	if len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &raws); err != nil {
+			return nil, err
+		}
+	} else {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		for {
+			var raw json.RawMessage
+			if err := decoder.Decode(&raw); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			raws = append(raws, raw)
+		}
+	}
+
+	records := make([]dumpRecord, 0, len(raws))
+	for _, raw := range raws {
+		record := dumpRecord{Raw: raw}
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
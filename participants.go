@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+// participants scrapes replies to tweet and writes unique participants of
+// conversation with their reply counts and first and last reply times as
+// NDJSON to stdout, the most active first.
+//
+//	go run . participants -depth 3 -limit 1000 1328684389388185600
+func participants(ctx context.Context, scraper *twitterscraper.Scraper, args []string) {
+	flags := flag.NewFlagSet("participants", flag.ContinueOnError)
+	depth := flags.Int("depth", 0, "maximum depth of replies, no limit if 0")
+	limit := flags.Int("limit", 0, "maximum number of replies, no limit if 0")
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	if flags.NArg() != 1 {
+		exit(summary{}, exitConfig, errors.New("expected tweet ID"))
+	}
+
+	tree, err := scraper.GetReplies(ctx, flags.Arg(0), *depth, *limit)
+	if err != nil {
+		exit(summary{Targets: 1, Failed: 1}, exitCode(err), fmt.Errorf("error scraping replies: %w", err))
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for _, p := range tree.Participants() {
+		if err := encoder.Encode(p); err != nil {
+			exit(summary{Targets: 1}, exitPartial, fmt.Errorf("error writing output: %w", err))
+		}
+	}
+	exit(summary{Targets: 1}, exitSuccess, nil)
+}
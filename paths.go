@@ -0,0 +1,64 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxNameBytes is the longest file name allowed by common file systems.
+const maxNameBytes = 255
+
+// names reserved by Windows regardless of extension
+var reservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// safeName makes value usable as a single path element on Windows, macOS and
+// Linux: separators, characters invalid on Windows and control characters
+// are replaced with _, trailing dots and spaces are removed, reserved device
+// names get _ suffix and name is cut to 255 bytes.
+func safeName(value string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '_'
+		}
+		return r
+	}, value)
+	name = strings.TrimRight(name, ". ")
+	if name == "" {
+		return "_"
+	}
+	base := name
+	if i := strings.IndexByte(base, '.'); i >= 0 {
+		base = base[:i]
+	}
+	if reservedNames[strings.ToUpper(base)] {
+		name = base + "_" + name[len(base):]
+	}
+	for len(name) > maxNameBytes {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+// outputPath expands placeholders of path template with safe values and
+// converts / separators to separators of platform:
+//
+//	{username}  username of target
+//	{date}      start of run in dateFormat
+//	{run}       run ID
+//
+// Template itself is trusted, only values are sanitized.
+func outputPath(template, username string, started time.Time, dateFormat string) string {
+	path := strings.NewReplacer(
+		"{username}", safeName(username),
+		"{date}", safeName(started.Format(dateFormat)),
+		"{run}", runID,
+	).Replace(template)
+	return filepath.FromSlash(path)
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+// prune applies retention policy to local storage: tweets older than -days are
+// removed from dump files, and least recently used files of -media directory are
+// removed until it fits into -max-gb.
+//
+//	go run . prune -days 90 -media ./media -max-gb 5 tweets.ndjson
+func prune(args []string) {
+	flags := flag.NewFlagSet("prune", flag.ContinueOnError)
+	days := flags.Int("days", 0, "remove tweets older than days, 0 keeps all")
+	mediaDir := flags.String("media", "", "media directory to cap")
+	maxGB := flags.Float64("max-gb", 0, "max size of media directory in GB")
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	if *days <= 0 && *mediaDir == "" {
+		exit(summary{}, exitConfig, errors.New("usage: prune [-days n] [-media dir -max-gb n] [file]..."))
+	}
+	if *mediaDir != "" && *maxGB <= 0 {
+		exit(summary{}, exitConfig, errors.New("-max-gb is required with -media"))
+	}
+
+	// Tweets of summary is number of removed tweets
+	result := summary{Targets: flags.NArg()}
+	if *days > 0 {
+		cutoff := time.Now().AddDate(0, 0, -*days)
+		for _, filename := range flags.Args() {
+			removed, err := pruneDump(filename, cutoff)
+			if err != nil {
+				log.Printf("Error pruning %s: %v", filename, err)
+				result.Failed++
+				continue
+			}
+			log.Printf("Removed %d tweets older than %d days from %s", removed, *days, filename)
+			result.Tweets += removed
+		}
+	}
+
+	if *mediaDir != "" {
+		removed, err := pruneMedia(*mediaDir, int64(*maxGB*(1<<30)))
+		if err != nil {
+			exit(result, exitPartial, fmt.Errorf("error pruning %s: %w", *mediaDir, err))
+		}
+		log.Printf("Removed %d files from %s", removed, *mediaDir)
+	}
+
+	if result.Failed > 0 {
+		exit(result, exitPartial, nil)
+	}
+	exit(result, exitSuccess, nil)
+}
+
+// pruneDump rewrites dump without tweets created before cutoff. Records
+// without time are kept, kept records are written as they were, JSON array
+// dump is written as NDJSON. Dump is locked while it's rewritten.
+func pruneDump(filename string, cutoff time.Time) (int, error) {
+	lock, err := twitterscraper.LockFile(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer lock.Unlock()
+
+	records, err := readDump(filename)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	removed := 0
+	out := bufio.NewWriter(tmp)
+	for _, record := range records {
+		if created := record.createdAt(); !created.IsZero() && created.Before(cutoff) {
+			removed++
+			continue
+		}
+		raw := record.Raw
+		if bytes.ContainsAny(raw, "\r\n") {
+			// record of indented JSON array must fit into line
+			var compacted bytes.Buffer
+			if err := json.Compact(&compacted, raw); err != nil {
+				tmp.Close()
+				return 0, err
+			}
+			raw = compacted.Bytes()
+		}
+		out.Write(raw)
+		out.WriteByte('\n')
+	}
+	if err := out.Flush(); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	return removed, os.Rename(tmp.Name(), filename)
+}
+
+// pruneMedia removes least recently used files until size of dir is not greater
+// than maxSize. Modification time is used as time of the last use.
+func pruneMedia(dir string, maxSize int64) (int, error) {
+	type mediaFile struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	var files []mediaFile
+	var total int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		// manifest is not media, verify-media needs it
+		if d.Name() == twitterscraper.MediaManifestFile {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, mediaFile{path: path, size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	removed := 0
+	for _, file := range files {
+		if total <= maxSize {
+			break
+		}
+		if err := os.Remove(file.path); err != nil {
+			return removed, err
+		}
+		total -= file.size
+		removed++
+	}
+	return removed, nil
+}
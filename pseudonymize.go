@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+// pseudonymizer replaces user IDs and usernames with HMAC of them, so the same
+// user gets the same pseudonym in every export made with the same key.
+type pseudonymizer struct {
+	key []byte
+}
+
+// pseudonymize writes dumps to stdout with users pseudonymized and fields dropped,
+// for datasets shared under privacy constraints. Key is read from
+// PSEUDONYMIZE_KEY environment variable to keep it out of shell history.
+//
+//	PSEUDONYMIZE_KEY=secret go run . pseudonymize -drop Name,HTML tweets.ndjson
+func pseudonymize(args []string) {
+	flags := flag.NewFlagSet("pseudonymize", flag.ContinueOnError)
+	drop := flags.String("drop", "Name,HTML,Place,Provenance", "comma separated fields to drop")
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	key := os.Getenv("PSEUDONYMIZE_KEY")
+	if key == "" {
+		exit(summary{}, exitConfig, errors.New("PSEUDONYMIZE_KEY environment variable is not set"))
+	}
+	if flags.NArg() == 0 {
+		exit(summary{}, exitConfig, errors.New("usage: pseudonymize [-drop fields] <file>..."))
+	}
+
+	var dropped []string
+	for _, field := range strings.Split(*drop, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			dropped = append(dropped, field)
+		}
+	}
+
+	p := pseudonymizer{key: []byte(key)}
+	result := summary{Targets: flags.NArg()}
+	out := bufio.NewWriter(os.Stdout)
+	encoder := json.NewEncoder(out)
+	for _, filename := range flags.Args() {
+		records, err := readDump(filename)
+		if err != nil {
+			exit(result, exitConfig, fmt.Errorf("error reading %s: %w", filename, err))
+		}
+		for _, record := range records {
+			p.tweet(record.Tweet)
+			record, err := withoutFields(record.output(), dropped)
+			if err != nil {
+				exit(result, exitPartial, err)
+			}
+			if err := encoder.Encode(record); err != nil {
+				exit(result, exitPartial, fmt.Errorf("error writing output: %w", err))
+			}
+			result.Tweets++
+		}
+	}
+	if err := out.Flush(); err != nil {
+		exit(result, exitPartial, fmt.Errorf("error writing output: %w", err))
+	}
+	exit(result, exitSuccess, nil)
+}
+
+func (p pseudonymizer) hash(value string) string {
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte(strings.ToLower(value)))
+	return hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+// tweet pseudonymizes author, replied user, advertiser, mentions and referenced
+// tweets in place.
+func (p pseudonymizer) tweet(tweet *twitterscraper.Tweet) {
+	if tweet == nil {
+		return
+	}
+
+	for i, mention := range tweet.Mentions {
+		if mention.Username != "" {
+			re := regexp.MustCompile(`(?i)@` + regexp.QuoteMeta(mention.Username) + `\b`)
+			tweet.Text = re.ReplaceAllLiteralString(tweet.Text, "@"+p.hash(mention.Username))
+		}
+		tweet.Mentions[i] = twitterscraper.Mention{ID: p.hash(mention.ID), Username: p.hash(mention.Username)}
+	}
+
+	if tweet.Username != "" {
+		tweet.PermanentURL = strings.Replace(tweet.PermanentURL, "/"+tweet.Username+"/", "/"+p.hash(tweet.Username)+"/", 1)
+	}
+	tweet.UserID = p.hash(tweet.UserID)
+	tweet.Username = p.hash(tweet.Username)
+	tweet.InReplyToUserID = p.hash(tweet.InReplyToUserID)
+	if tweet.Ad != nil {
+		// advertiser is identified by its hashed ID and username, display
+		// name can't be hashed consistently and is dropped
+		tweet.Ad.AdvertiserID = p.hash(tweet.Ad.AdvertiserID)
+		tweet.Ad.AdvertiserUsername = p.hash(tweet.Ad.AdvertiserUsername)
+		tweet.Ad.AdvertiserName = ""
+	}
+
+	p.tweet(tweet.InReplyToStatus)
+	p.tweet(tweet.QuotedStatus)
+	p.tweet(tweet.RetweetedStatus)
+	for _, t := range tweet.Thread {
+		p.tweet(t)
+	}
+}
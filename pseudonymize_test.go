@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestPseudonymizerTweet(t *testing.T) {
+	p := pseudonymizer{key: []byte("secret")}
+	tweet := &twitterscraper.Tweet{
+		UserID:          "1",
+		Username:        "author",
+		InReplyToUserID: "2",
+		InReplyToStatus: &twitterscraper.Tweet{UserID: "2", Username: "parent"},
+		Ad:              &twitterscraper.Ad{AdvertiserID: "3", AdvertiserUsername: "brand", AdvertiserName: "Brand Inc"},
+	}
+	p.tweet(tweet)
+
+	if tweet.UserID != p.hash("1") || tweet.Username != p.hash("author") {
+		t.Errorf("Expected author pseudonymized, got %q %q", tweet.UserID, tweet.Username)
+	}
+	if tweet.InReplyToUserID != p.hash("2") || tweet.InReplyToUserID != tweet.InReplyToStatus.UserID {
+		t.Errorf("Expected replied user pseudonymized as parent author, got %q", tweet.InReplyToUserID)
+	}
+	if tweet.Ad.AdvertiserID != p.hash("3") || tweet.Ad.AdvertiserUsername != p.hash("brand") || tweet.Ad.AdvertiserName != "" {
+		t.Errorf("Expected advertiser pseudonymized, got %+v", *tweet.Ad)
+	}
+}
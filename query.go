@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+// query searches text of tweets archived with timeline -db and writes the
+// most relevant ones as NDJSON to stdout. Terms use FTS5 query syntax, such
+// as "go AND generics" or "\"exact phrase\"".
+//
+//	go run . query -db archive.db -limit 20 "terms"
+func query(args []string) {
+	flags := flag.NewFlagSet("query", flag.ContinueOnError)
+	dbFile := flags.String("db", "tweets.db", "SQLite archive written by timeline -db")
+	limit := flags.Int("limit", 50, "maximum number of tweets, no limit if 0")
+	tz := flags.String("tz", "UTC", "time zone of output times, IANA name")
+	fieldList := flags.String("fields", "", "comma separated fields to output, all by default")
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	if flags.NArg() == 0 {
+		exit(summary{}, exitConfig, errors.New("usage: query [-db file] [-limit n] <terms>"))
+	}
+
+	fields, err := parseFields(*fieldList)
+	if err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		exit(summary{}, exitConfig, fmt.Errorf("error loading time zone: %w", err))
+	}
+	if _, err := os.Stat(*dbFile); err != nil {
+		exit(summary{}, exitConfig, fmt.Errorf("error opening archive: %w", err))
+	}
+
+	store, err := twitterscraper.OpenSQLiteStore("sqlite", *dbFile)
+	if err != nil {
+		exit(summary{}, exitConfig, fmt.Errorf("error opening archive: %w", err))
+	}
+	tweets, err := store.Search(strings.Join(flags.Args(), " "), *limit)
+	store.Close()
+	if err != nil {
+		exit(summary{Targets: 1, Failed: 1}, exitConfig, fmt.Errorf("error searching archive: %w", err))
+	}
+
+	out := twitterscraper.NewJSONLStore(os.Stdout).WithEncoding(outputEncoding(loc, fields))
+	for _, tweet := range tweets {
+		if err := out.Put(tweet); err != nil {
+			exit(summary{Targets: 1}, exitPartial, fmt.Errorf("error writing output: %w", err))
+		}
+	}
+	if err := out.Close(); err != nil {
+		exit(summary{Targets: 1}, exitPartial, fmt.Errorf("error writing output: %w", err))
+	}
+	exit(summary{Targets: 1, Tweets: len(tweets)}, exitSuccess, nil)
+}
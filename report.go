@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+// report writes engagement statistics of dumps as JSON to stdout and, with
+// -markdown, as human readable report to file. Tweets found in several dumps
+// are counted once.
+//
+//	go run . report -top 5 -markdown report.md tweets.ndjson
+func report(args []string) {
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	topNbr := flags.Int("top", 10, "top tweets by every metric, negative for all")
+	markdown := flags.String("markdown", "", "file to write Markdown report to")
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	if flags.NArg() == 0 {
+		exit(summary{}, exitConfig, errors.New("usage: report [-top n] [-markdown file] <file>..."))
+	}
+
+	var tweets []*twitterscraper.Tweet
+	seen := make(map[string]bool)
+	for _, filename := range flags.Args() {
+		records, err := readDump(filename)
+		if err != nil {
+			exit(summary{Targets: flags.NArg()}, exitConfig, fmt.Errorf("error reading %s: %w", filename, err))
+		}
+		for _, record := range records {
+			if record.Tweet != nil && !seen[record.ID] {
+				seen[record.ID] = true
+				tweets = append(tweets, record.Tweet)
+			}
+		}
+	}
+
+	engagement := twitterscraper.NewEngagementReport(tweets, *topNbr)
+	result := summary{Targets: flags.NArg(), Tweets: engagement.Tweets}
+	if *markdown != "" {
+		if err := os.WriteFile(*markdown, []byte(engagement.Markdown()), 0644); err != nil {
+			exit(result, exitPartial, fmt.Errorf("error writing %s: %w", *markdown, err))
+		}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(engagement); err != nil {
+		exit(result, exitPartial, fmt.Errorf("error writing output: %w", err))
+	}
+	exit(result, exitSuccess, nil)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+	"github.com/imperatrona/twitter-scraper/script"
+)
+
+// runScript runs Starlark script and writes tweets it emits as NDJSON to
+// stdout, see package script for builtins.
+//
+//	go run . script flow.star > tweets.ndjson
+func runScript(ctx context.Context, scraper *twitterscraper.Scraper, args []string) {
+	flags := flag.NewFlagSet("script", flag.ContinueOnError)
+	tz := flags.String("tz", "UTC", "time zone of output times, IANA name or \"account\" for account profile zone")
+	fieldList := flags.String("fields", "", "comma separated fields to output, all by default")
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	if flags.NArg() != 1 {
+		exit(summary{}, exitConfig, errors.New("expected script file"))
+	}
+
+	fields, err := parseFields(*fieldList)
+	if err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	loc, err := loadLocation(ctx, scraper, *tz)
+	if err != nil {
+		exit(summary{}, exitConfig, fmt.Errorf("error loading time zone: %w", err))
+	}
+
+	out := twitterscraper.NewJSONLStore(os.Stdout).WithEncoding(outputEncoding(loc, fields))
+	result := summary{Targets: 1}
+	err = script.Run(ctx, scraper, flags.Arg(0), nil, func(tweet *twitterscraper.Tweet) error {
+		result.Tweets++
+		return out.Put(tweet)
+	})
+	if closeErr := out.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("error writing output: %w", closeErr)
+	}
+	if err != nil {
+		result.Failed = 1
+		exit(result, exitCode(err), fmt.Errorf("error running script: %w", err))
+	}
+	exit(result, exitSuccess, nil)
+}
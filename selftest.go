@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+// defaultCheckers report reputation of IP when no -checker is given.
+var defaultCheckers = []string{"http://ip-api.com/json/?fields=query,proxy,hosting"}
+
+// selftest writes report of User-Agent, TLS, guest token and IP reputation
+// checks as JSON to stdout and exits with exitPartial if any check found a
+// detection risk, so it can gate a big run.
+//
+//	go run . selftest -checker 'https://ipinfo.io/json' && go run . timeline -n 3200 - < users.txt
+func selftest(ctx context.Context, scraper *twitterscraper.Scraper, args []string) {
+	flags := flag.NewFlagSet("selftest", flag.ContinueOnError)
+	var checkers []string
+	flags.Func("checker", "URL of IP reputation checker returning JSON, may be repeated, replaces default checker", func(value string) error {
+		checkers = append(checkers, value)
+		return nil
+	})
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	if len(checkers) == 0 {
+		checkers = defaultCheckers
+	}
+
+	report := scraper.SelfTest(ctx, checkers...)
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		exit(summary{}, exitPartial, fmt.Errorf("error writing output: %w", err))
+	}
+
+	risks := report.Risks()
+	if len(risks) == 0 {
+		exit(summary{}, exitSuccess, nil)
+	}
+	names := make([]string, len(risks))
+	for i, risk := range risks {
+		names[i] = risk.Name
+	}
+	exit(summary{}, exitPartial, fmt.Errorf("detection risks found: %s", strings.Join(names, ", ")))
+}
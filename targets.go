@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+// target is a line of targets file: username, optional user ID known from
+// the previous validation, which allows to follow renamed accounts, and
+// options overriding command flags for this target. Sink is path template with
+// {username}, {date} and {run} placeholders, run ID is inserted into filename
+// without {run}, elon.ndjson is written to elon.<run ID>.ndjson.
+//
+//	elonmusk 44196397 limit=50 replies=true since=2024-01-01 until=2024-06-01 sink=elon.ndjson politeness=stealth
+//	elonmusk sink=archive/{username}/{date}-{run}.ndjson
+//	elonmusk author-replies=true
+type target struct {
+	Username string
+	UserID   string
+	// Options are key=value overrides as written in file.
+	Options []string
+}
+
+// target options
+const (
+	optionLimit   = "limit"
+	optionReplies = "replies"
+	optionSince   = "since"
+	optionUntil   = "until"
+	optionSink    = "sink"
+	// optionAuthorReplies keeps only replies of user to other users.
+	optionAuthorReplies = "author-replies"
+	// optionPoliteness is name of politeness profile, see twitterscraper.PolitenessProfile.
+	optionPoliteness = "politeness"
+)
+
+func parseTargets(lines []string) ([]target, error) {
+	targets := make([]target, 0, len(lines))
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		t := target{Username: strings.TrimPrefix(fields[0], "@")}
+		for _, field := range fields[1:] {
+			switch {
+			case strings.Contains(field, "="):
+				t.Options = append(t.Options, field)
+			case t.UserID == "":
+				t.UserID = field
+			default:
+				return nil, fmt.Errorf("target %s: unexpected %q", t.Username, field)
+			}
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// sessionTarget applies options of target, sink option is passed to openSink.
+func (t target) sessionTarget(openSink func(username, template string) (twitterscraper.Sink, error)) (twitterscraper.Target, error) {
+	st := twitterscraper.Target{Username: t.Username}
+	for _, option := range t.Options {
+		key, value, _ := strings.Cut(option, "=")
+		var err error
+		switch key {
+		case optionLimit:
+			st.MaxTweets, err = strconv.Atoi(value)
+		case optionReplies:
+			st.Replies, err = strconv.ParseBool(value)
+		case optionAuthorReplies:
+			st.AuthorReplies, err = strconv.ParseBool(value)
+		case optionSince:
+			st.Since, err = parseDate(value)
+		case optionUntil:
+			st.Until, err = parseDate(value)
+		case optionPoliteness:
+			var p twitterscraper.Politeness
+			if p, err = twitterscraper.PolitenessProfile(value); err == nil {
+				st.Politeness = &p
+			}
+		case optionSink:
+			var sink twitterscraper.Sink
+			if sink, err = openSink(t.Username, value); err == nil {
+				st.Sinks = append(st.Sinks, sink)
+			}
+		default:
+			err = errors.New("unknown option")
+		}
+		if err != nil {
+			return st, fmt.Errorf("target %s: %s: %w", t.Username, option, err)
+		}
+	}
+	return st, nil
+}
+
+// parseDate parses date as 2006-01-02 in UTC or RFC 3339 time.
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// sinkFiles opens output files of sink option, file shared by several targets
+// is opened once. Sink option is path template, see outputPath.
+type sinkFiles struct {
+	encode     func(tweet *twitterscraper.Tweet) (interface{}, error)
+	started    time.Time
+	dateFormat string
+	names      []string
+	stores     map[string]*twitterscraper.JSONLStore
+}
+
+func (files *sinkFiles) open(username, template string) (twitterscraper.Sink, error) {
+	filename := outputPath(template, username, files.started, files.dateFormat)
+	if !strings.Contains(template, "{run}") {
+		filename = runFilename(filename)
+	}
+	store, ok := files.stores[filename]
+	if !ok {
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return nil, err
+		}
+		var err error
+		if store, err = twitterscraper.OpenJSONLStore(filename); err != nil {
+			return nil, err
+		}
+		if files.stores == nil {
+			files.stores = make(map[string]*twitterscraper.JSONLStore)
+		}
+		store.WithEncoding(files.encode)
+		files.names = append(files.names, filename)
+		files.stores[filename] = store
+	}
+	return twitterscraper.StoreSink(store), nil
+}
+
+// runFilename inserts run ID before extension of filename, so concurrent runs
+// writing the same sink don't overwrite each other.
+func runFilename(filename string) string {
+	ext := filepath.Ext(filename)
+	return strings.TrimSuffix(filename, ext) + "." + runID + ext
+}
+
+// Close flushes and closes all files, returning the first error.
+func (files *sinkFiles) Close() error {
+	var err error
+	for _, filename := range files.names {
+		if closeErr := files.stores[filename].Close(); err == nil && closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// targets manages target lists.
+//
+//	go run . targets validate users.txt > users.clean.txt
+func targets(ctx context.Context, scraper *twitterscraper.Scraper, args []string) {
+	if len(args) == 0 || args[0] != "validate" {
+		exit(summary{}, exitConfig, fmt.Errorf("usage: targets validate [-keep-protected] [file|-]"))
+	}
+	validateTargets(ctx, scraper, args[1:])
+}
+
+// validateTargets checks every target before a big run and writes cleaned list
+// to stdout: renamed accounts get the new handle, suspended, not found and
+// protected ones are removed, duplicates are dropped, options are kept.
+// Issues are logged to stderr.
+func validateTargets(ctx context.Context, scraper *twitterscraper.Scraper, args []string) {
+	flags := flag.NewFlagSet("targets validate", flag.ContinueOnError)
+	keepProtected := flags.Bool("keep-protected", false, "keep protected accounts, if the scraper account follows them")
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+
+	input := os.Stdin
+	if flags.NArg() > 0 && flags.Arg(0) != "-" {
+		f, err := os.Open(flags.Arg(0))
+		if err != nil {
+			exit(summary{}, exitConfig, err)
+		}
+		defer f.Close()
+		input = f
+	}
+	lines, err := readLines(input)
+	if err != nil {
+		exit(summary{}, exitConfig, fmt.Errorf("error reading targets: %w", err))
+	}
+	list, err := parseTargets(lines)
+	if err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+
+	// known IDs reveal renamed accounts, the old handle doesn't exist anymore
+	var ids []string
+	for _, t := range list {
+		if t.UserID != "" {
+			ids = append(ids, t.UserID)
+		}
+	}
+	counts, err := scraper.GetUserCountsByIDs(ctx, ids)
+	if err != nil {
+		exit(summary{Targets: len(list)}, exitCode(err), fmt.Errorf("error looking up user IDs: %w", err))
+	}
+	current := make(map[string]string, len(counts))
+	for _, user := range counts {
+		current[user.UserID] = user.Username
+	}
+
+	usernames := make([]string, len(list))
+	for i, t := range list {
+		usernames[i] = t.Username
+		if username, ok := current[t.UserID]; ok && !strings.EqualFold(username, t.Username) {
+			log.Printf("@%s renamed to @%s", t.Username, username)
+			usernames[i] = username
+		}
+	}
+
+	checks, err := scraper.CheckUsers(ctx, usernames)
+	if err != nil {
+		exit(summary{Targets: len(list)}, exitCode(err), fmt.Errorf("error checking users: %w", err))
+	}
+
+	result := summary{Targets: len(list)}
+	seen := make(map[string]bool)
+	for i, check := range checks {
+		switch {
+		case !check.Exists:
+			log.Printf("@%s not found", check.Username)
+		case check.Suspended:
+			log.Printf("@%s is suspended", check.Username)
+		case check.Protected && !*keepProtected:
+			log.Printf("@%s is protected", check.Username)
+		case seen[check.UserID]:
+			log.Printf("@%s is duplicated", check.Username)
+		default:
+			seen[check.UserID] = true
+			fmt.Println(strings.Join(append([]string{check.Username, check.UserID}, list[i].Options...), " "))
+			continue
+		}
+		result.Failed++
+	}
+
+	if result.Failed > 0 {
+		exit(result, exitPartial, nil)
+	}
+	exit(result, exitSuccess, nil)
+}
+
+// exitCode of failed API request.
+func exitCode(err error) int {
+	switch {
+	case isRateLimit(err):
+		return exitRateLimit
+	case isAccountError(err):
+		return exitAuth
+	}
+	return exitPartial
+}
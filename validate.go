@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"sort"
+)
+
+// tweetSchema describes tweets written by timeline, see schema/tweet.schema.json.
+//
+//go:embed schema/tweet.schema.json
+var tweetSchema []byte
+
+// schema is the subset of JSON Schema used in schema/tweet.schema.json.
+type schema struct {
+	Type       schemaType         `json:"type"`
+	Required   []string           `json:"required"`
+	Properties map[string]*schema `json:"properties"`
+	Items      *schema            `json:"items"`
+}
+
+// schemaType is a single type or a list of allowed types.
+type schemaType []string
+
+func (t *schemaType) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = schemaType{name}
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*t = names
+	return nil
+}
+
+// validate prints schema of output or checks that NDJSON dump matches it.
+//
+//	go run . validate tweets.ndjson
+//	go run . validate schema > tweet.schema.json
+func validate(args []string) {
+	if len(args) != 1 {
+		exit(summary{}, exitConfig, errors.New("usage: validate <file|schema>"))
+	}
+	if args[0] == "schema" {
+		os.Stdout.Write(tweetSchema)
+		return
+	}
+
+	var s schema
+	if err := json.Unmarshal(tweetSchema, &s); err != nil {
+		exit(summary{}, exitConfig, fmt.Errorf("error loading schema: %w", err))
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	defer f.Close()
+
+	result, err := validateNDJSON(f, &s)
+	if err != nil {
+		exit(result, exitConfig, fmt.Errorf("error reading %s: %w", args[0], err))
+	}
+	if result.Failed > 0 {
+		exit(result, exitPartial, fmt.Errorf("%d of %d records don't match schema", result.Failed, result.Targets))
+	}
+	exit(result, exitSuccess, nil)
+}
+
+// validateNDJSON logs every invalid record with its line number.
+func validateNDJSON(r io.Reader, s *schema) (summary, error) {
+	var result summary
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for lineNbr := 1; scanner.Scan(); lineNbr++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		result.Targets++
+
+		var value interface{}
+		if err := json.Unmarshal(line, &value); err != nil {
+			log.Printf("line %d: %v", lineNbr, err)
+			result.Failed++
+			continue
+		}
+		if errs := s.validate(value, ""); len(errs) > 0 {
+			for _, err := range errs {
+				log.Printf("line %d: %s", lineNbr, err)
+			}
+			result.Failed++
+			continue
+		}
+		result.Tweets++
+	}
+	return result, scanner.Err()
+}
+
+func (s *schema) validate(value interface{}, path string) []string {
+	if len(s.Type) > 0 && !s.Type.matches(value) {
+		return []string{fmt.Sprintf("%s: expected %v, got %s", pathOrRoot(path), []string(s.Type), jsonType(value))}
+	}
+
+	var errs []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				errs = append(errs, fmt.Sprintf("%s: missing %s", pathOrRoot(path), name))
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := s.Properties[name]; ok {
+				errs = append(errs, property.validate(v[name], path+"."+name)...)
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				errs = append(errs, s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return errs
+}
+
+func (t schemaType) matches(value interface{}) bool {
+	actual := jsonType(value)
+	for _, name := range t {
+		if name == actual || (name == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "$"
+	}
+	return "$" + path
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+// verifyMedia checks files of media directory against sizes and checksums of
+// its manifest written by timeline -media.
+//
+//	go run . verify-media ./media
+//	go run . verify-media -missing-ok ./media
+func verifyMedia(args []string) {
+	flags := flag.NewFlagSet("verify-media", flag.ContinueOnError)
+	missingOK := flags.Bool("missing-ok", false, "don't report files removed from directory, e.g. by prune")
+	if err := flags.Parse(args); err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	if flags.NArg() != 1 {
+		exit(summary{}, exitConfig, errors.New("usage: verify-media [-missing-ok] <dir>"))
+	}
+	dir := flags.Arg(0)
+
+	manifest, err := twitterscraper.ReadMediaManifest(dir)
+	if err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+	issues, err := twitterscraper.VerifyMedia(dir)
+	if err != nil {
+		exit(summary{}, exitConfig, err)
+	}
+
+	// Targets of summary is number of files in manifest
+	result := summary{Targets: len(manifest.Files)}
+	for _, issue := range issues {
+		if *missingOK && errors.Is(issue.Err, os.ErrNotExist) {
+			continue
+		}
+		log.Printf("%s: %v", issue.Name, issue.Err)
+		result.Failed++
+	}
+
+	if result.Failed > 0 {
+		exit(result, exitPartial, fmt.Errorf("%d of %d files failed verification", result.Failed, result.Targets))
+	}
+	exit(result, exitSuccess, nil)
+}